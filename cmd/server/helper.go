@@ -5,7 +5,8 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/config"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // Helper functions for environment variables and configuration
@@ -18,13 +19,16 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
-// getDurationOrDefault parses a duration from an environment variable or returns the default
+// getDurationOrDefault parses a duration from an environment variable or
+// returns the default. Accepts the extended d/w/y units (see
+// config.ParseExtendedDuration) alongside time.ParseDuration's s/m/h, so
+// operators can write e.g. CLAIM_INTERVAL=7d instead of computing hours.
 func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
+		if duration, err := config.ParseExtendedDuration(value); err == nil {
 			return duration
 		} else {
-			logrus.Warnf("Invalid duration in %s: %v, using default: %v", key, err, defaultValue)
+			logging.Warnf("Invalid duration in %s: %v, using default: %v", key, err, defaultValue)
 		}
 	}
 	return defaultValue
@@ -36,7 +40,7 @@ func getEnvBool(key string, defaultValue bool) bool {
 		if parsed, err := strconv.ParseBool(value); err == nil {
 			return parsed
 		} else {
-			logrus.Warnf("Invalid boolean in %s: %v, using default: %v", key, err, defaultValue)
+			logging.Warnf("Invalid boolean in %s: %v, using default: %v", key, err, defaultValue)
 		}
 	}
 	return defaultValue
@@ -48,7 +52,7 @@ func getEnvInt(key string, defaultValue int) int {
 		if parsed, err := strconv.Atoi(value); err == nil {
 			return parsed
 		} else {
-			logrus.Warnf("Invalid integer in %s: %v, using default: %v", key, err, defaultValue)
+			logging.Warnf("Invalid integer in %s: %v, using default: %v", key, err, defaultValue)
 		}
 	}
 	return defaultValue
@@ -60,7 +64,7 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
 			return parsed
 		} else {
-			logrus.Warnf("Invalid float in %s: %v, using default: %v", key, err, defaultValue)
+			logging.Warnf("Invalid float in %s: %v, using default: %v", key, err, defaultValue)
 		}
 	}
 	return defaultValue
@@ -4,8 +4,11 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,17 +18,18 @@ import (
 	"time"
 
 	"github.com/yourorg/restake-yield-ea/internal/aggregate"
+	"github.com/yourorg/restake-yield-ea/internal/canary"
 	"github.com/yourorg/restake-yield-ea/internal/circuitbreaker"
 	"github.com/yourorg/restake-yield-ea/internal/config"
 	"github.com/yourorg/restake-yield-ea/internal/enterprise"
 	"github.com/yourorg/restake-yield-ea/internal/fetch"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 	"github.com/yourorg/restake-yield-ea/internal/model"
+	"github.com/yourorg/restake-yield-ea/internal/ratelimit"
 	"github.com/yourorg/restake-yield-ea/internal/security"
-	"github.com/yourorg/restake-yield-ea/internal/types"
 	"github.com/yourorg/restake-yield-ea/internal/validation"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 )
 
@@ -51,6 +55,65 @@ type ServerConfig struct {
 
 	// Whether to enable Prometheus metrics
 	EnableMetrics bool
+
+	// Path to an optional YAML/JSON file that, when set, is hot-reloaded via
+	// fsnotify so operators can retune aggregation mode, timeouts, rate
+	// limits and circuit-breaker params without restarting the adapter
+	ConfigFile string
+
+	// MaxStaleness is the oldest a cached/stale-tier fallback response is
+	// allowed to be; anything older fails the request instead of serving it
+	MaxStaleness time.Duration
+
+	// Path to the on-disk checkpoint file used as the last-resort "stale"
+	// fallback tier when no provider responds and in-memory history is gone
+	CheckpointPath string
+
+	// How often the current aggregate is persisted to CheckpointPath
+	CheckpointInterval time.Duration
+
+	// RateLimitRequests and RateLimitWindow set the per-identifier request
+	// budget for the keyed rate limiter in front of the HTTP API (distinct
+	// from the single process-wide RateLimitRPS/RateLimitBurst pair below,
+	// which only gates the enterprise-mode path). Zero disables it.
+	RateLimitRequests int
+	RateLimitWindow   time.Duration
+
+	// RateLimitKeyMode selects the identifier the keyed limiter buckets by:
+	// "ip" (default), "api_key", or "wallet"
+	RateLimitKeyMode string
+
+	// RateLimitRedisAddr, if set, backs the keyed limiter with Redis so the
+	// budget is shared across every instance behind a load balancer instead
+	// of each one enforcing its own in-memory quota
+	RateLimitRedisAddr string
+
+	// AdminToken gates every /admin/* route: a request must send it as
+	// either "Authorization: Bearer <token>" or "X-Admin-Token: <token>",
+	// checked with a constant-time comparison. Empty disables the
+	// endpoints entirely (they 503) rather than leaving them open, since
+	// an operator who forgot to set it almost certainly didn't mean to
+	// expose aggregation-mode promotion to the network.
+	AdminToken string
+}
+
+// reloadableConfig is the subset of server behavior operators can change at
+// runtime via ConfigFile, applied atomically behind Server.dynMu
+type reloadableConfig struct {
+	AggregationMode      string             `json:"aggregationMode"`
+	Timeout              time.Duration      `json:"timeout"`
+	EnableValidation     bool               `json:"enableValidation"`
+	EnableCircuitBreaker bool               `json:"enableCircuitBreaker"`
+	RateLimitRPS         float64            `json:"rateLimitRPS"`
+	RateLimitBurst       int                `json:"rateLimitBurst"`
+	ProviderWeights      map[string]float64 `json:"providerWeights"`
+	MaxStaleness         time.Duration      `json:"maxStaleness"`
+
+	// KeyedRateLimitRequests and KeyedRateLimitWindow reload the per-IP/
+	// API-key/wallet budget enforced by Server.keyedLimiter; zero values
+	// leave the limiter disabled, matching RateLimitRequests at startup.
+	KeyedRateLimitRequests int           `json:"keyedRateLimitRequests"`
+	KeyedRateLimitWindow   time.Duration `json:"keyedRateLimitWindow"`
 }
 
 // Server represents the External Adapter server instance
@@ -67,6 +130,32 @@ type Server struct {
 	// Circuit breaker for fault detection
 	breaker *circuitbreaker.CircuitBreaker
 
+	// Canary controller for shadow-running a candidate aggregation mode
+	// alongside the primary one (see /admin/canary)
+	canaryCtrl               *canary.Controller
+	canaryPromoteMinSamples  int
+	canaryPromoteMaxDeltaPct float64
+
+	// Tiered fallback: providerCache holds each provider's last successful
+	// metrics for substituting into an otherwise-live response (the
+	// "partial" tier), checkpointStore persists the last full aggregate to
+	// disk for the last-resort "stale" tier, and tierStats tracks recent
+	// freshness tier hits for the /status hourly ratio breakdown
+	providerCacheMu sync.RWMutex
+	providerCache   map[string]model.Metric
+	checkpointStore *aggregate.CheckpointStore
+	tierStats       *tierStats
+
+	// lastAggregate is the most recent live/partial aggregate result,
+	// snapshotted periodically into checkpointStore
+	lastAggregateMu sync.RWMutex
+	lastAggregate   model.Metric
+	hasLastAggregate bool
+
+	// keyedLimiter enforces the per-IP/API-key/wallet request budget in
+	// front of the HTTP API; nil when RateLimitRequests is unset
+	keyedLimiter *ratelimit.Limiter
+
 	// Metrics registry
 	metrics *serverMetrics
 
@@ -79,11 +168,21 @@ type Server struct {
 	dataIntegrity    *security.DataIntegrityService
 	rateLimit        *rate.Limiter
 	enableEnterprise bool
+
+	// dynMu guards dynamic, the hot-reloadable subset of config applied from
+	// ConfigFile; everything else on Server is set once at startup
+	dynMu      sync.RWMutex
+	dynamic    reloadableConfig
+	cfgWatcher *config.Watcher
 }
 
 // Provider defines the interface for any yield data source
 type Provider interface {
 	Fetch(ctx context.Context) ([]model.Metric, error)
+
+	// Name identifies this provider in the per-provider last-known-good
+	// fallback cache (see Server.providerCache) and in logs/metrics.
+	Name() string
 }
 
 // serverMetrics holds Prometheus metrics for the server
@@ -95,6 +194,9 @@ type serverMetrics struct {
 	aggregateTVL     prometheus.Gauge
 	aggregateAPY     prometheus.Gauge
 	metricCount      prometheus.Gauge
+	configReloads    *prometheus.CounterVec
+	configLastReload prometheus.Gauge
+	fallbackTier     *prometheus.CounterVec
 }
 
 // registerMetrics sets up Prometheus metrics collection
@@ -147,6 +249,26 @@ func registerMetrics() *serverMetrics {
 				Help: "Number of metrics processed",
 			},
 		),
+		configReloads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "restake_config_reloads_total",
+				Help: "Total number of hot config reload attempts",
+			},
+			[]string{"status"},
+		),
+		configLastReload: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "restake_config_last_reload_timestamp",
+				Help: "Unix timestamp of the last successful hot config reload",
+			},
+		),
+		fallbackTier: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "restake_fallback_tier_total",
+				Help: "Total responses served per fallback freshness tier (live, partial, cached, stale)",
+			},
+			[]string{"tier"},
+		),
 	}
 
 	// Register all metrics
@@ -158,11 +280,78 @@ func registerMetrics() *serverMetrics {
 		m.aggregateTVL,
 		m.aggregateAPY,
 		m.metricCount,
+		m.configReloads,
+		m.configLastReload,
+		m.fallbackTier,
 	)
 
 	return m
 }
 
+// tierStatsWindow bounds how far back tierStats.ratios looks when computing
+// hourly freshness-tier hit ratios for /status.
+const tierStatsWindow = time.Hour
+
+// tierSample is one recorded freshness tier hit, timestamped so tierStats
+// can trim samples older than tierStatsWindow.
+type tierSample struct {
+	at   time.Time
+	tier aggregate.Freshness
+}
+
+// tierStats tracks recent fallback freshness-tier hits so handleStatus can
+// report hit ratios over the last hour, trimming samples outside that
+// window on every record/read instead of keeping unbounded history.
+type tierStats struct {
+	mu      sync.Mutex
+	samples []tierSample
+}
+
+func newTierStats() *tierStats {
+	return &tierStats{}
+}
+
+// record appends a tier hit and trims anything older than tierStatsWindow.
+func (t *tierStats) record(tier aggregate.Freshness) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.samples = append(t.samples, tierSample{at: time.Now(), tier: tier})
+	t.trim()
+}
+
+// trim drops samples older than tierStatsWindow. Callers must hold t.mu.
+func (t *tierStats) trim() {
+	cutoff := time.Now().Add(-tierStatsWindow)
+	i := 0
+	for i < len(t.samples) && t.samples[i].at.Before(cutoff) {
+		i++
+	}
+	t.samples = t.samples[i:]
+}
+
+// ratios returns the fraction of the last tierStatsWindow's hits that fell
+// into each freshness tier, for the /status endpoint.
+func (t *tierStats) ratios() map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trim()
+
+	counts := make(map[string]int, len(t.samples))
+	for _, s := range t.samples {
+		counts[string(s.tier)]++
+	}
+
+	ratios := make(map[string]float64, len(counts))
+	total := len(t.samples)
+	if total == 0 {
+		return ratios
+	}
+	for tier, count := range counts {
+		ratios[tier] = float64(count) / float64(total)
+	}
+	return ratios
+}
+
 // main is the entry point for the application
 func main() {
 	// Configure logging
@@ -183,32 +372,32 @@ func main() {
 func setupLogging() {
 	logFormat := strings.ToLower(os.Getenv("LOG_FORMAT"))
 	logLevel := strings.ToLower(os.Getenv("LOG_LEVEL"))
+	logFile := os.Getenv("LOG_FILE")
 
-	// Set log formatter based on environment
-	switch logFormat {
-	case "json":
-		logrus.SetFormatter(&logrus.JSONFormatter{})
-	default:
-		logrus.SetFormatter(&logrus.TextFormatter{
-			FullTimestamp: true,
-		})
+	format := logging.FormatText
+	if logFormat == "json" {
+		format = logging.FormatJSON
 	}
 
-	// Set log level based on environment
-	switch logLevel {
-	case "debug":
-		logrus.SetLevel(logrus.DebugLevel)
-	case "info":
-		logrus.SetLevel(logrus.InfoLevel)
-	case "warn", "warning":
-		logrus.SetLevel(logrus.WarnLevel)
-	case "error":
-		logrus.SetLevel(logrus.ErrorLevel)
-	default:
-		logrus.SetLevel(logrus.InfoLevel)
+	out := io.Writer(os.Stderr)
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+		if err != nil {
+			logging.Warnf("Unable to open LOG_FILE %q, logging to stderr: %v", logFile, err)
+		} else {
+			out = f
+		}
 	}
+	logging.Configure(format, out)
 
-	logrus.Info("Logging configured")
+	lvl, err := logging.ParseLevel(logLevel)
+	if err != nil {
+		logging.Warnf("Invalid LOG_LEVEL %q, defaulting to info: %v", logLevel, err)
+		lvl = slog.LevelInfo
+	}
+	logging.SetLevel(lvl)
+
+	logging.Info("Logging configured")
 }
 
 // loadConfig loads server configuration from environment variables
@@ -218,9 +407,18 @@ func loadConfig() ServerConfig {
 		Port:                 getEnvOrDefault("PORT", "8080"),
 		AggregationMode:      getEnvOrDefault("AGGREGATION_MODE", "weighted"),
 		Timeout:              getDurationOrDefault("TIMEOUT", 10*time.Second),
-		EnableCircuitBreaker: getBoolOrDefault("ENABLE_CIRCUIT_BREAKER", true),
-		EnableValidation:     getBoolOrDefault("ENABLE_VALIDATION", true),
-		EnableMetrics:        getBoolOrDefault("ENABLE_METRICS", true),
+		EnableCircuitBreaker: getEnvBool("ENABLE_CIRCUIT_BREAKER", true),
+		EnableValidation:     getEnvBool("ENABLE_VALIDATION", true),
+		EnableMetrics:        getEnvBool("ENABLE_METRICS", true),
+		ConfigFile:           getEnvOrDefault("CONFIG_FILE", ""),
+		MaxStaleness:         getDurationOrDefault("MAX_STALENESS", 30*time.Minute),
+		CheckpointPath:       getEnvOrDefault("CHECKPOINT_PATH", "data/aggregate-checkpoint.json"),
+		CheckpointInterval:   getDurationOrDefault("CHECKPOINT_INTERVAL", 5*time.Minute),
+		RateLimitRequests:    getEnvInt("RATE_LIMIT_REQUESTS", 0),
+		RateLimitWindow:      getDurationOrDefault("RATE_LIMIT_WINDOW", time.Minute),
+		RateLimitKeyMode:     getEnvOrDefault("RATE_LIMIT_KEY", "ip"),
+		RateLimitRedisAddr:   getEnvOrDefault("RATE_LIMIT_REDIS_ADDR", ""),
+		AdminToken:           getEnvOrDefault("ADMIN_TOKEN", ""),
 	}
 }
 
@@ -237,23 +435,23 @@ func createProviders() []Provider {
 func NewServer(config ServerConfig, providers []Provider) *Server {
 	// Ensure we have at least one provider
 	if len(providers) == 0 {
-		logrus.Fatal("No providers configured")
+		logging.Fatal("No providers configured")
 	}
 
 	// Create circuit breaker if enabled
 	var circuitBreaker *circuitbreaker.CircuitBreaker
 	if config.EnableCircuitBreaker {
 		// Configure with sensible defaults for yield data
-		circuitBreaker = circuitbreaker.NewCircuitBreaker(circuitbreaker.Options{
-			MaxAPY:          100.0, // 10000% maximum APY threshold
-			MaxTVLChange:    50.0,  // 50% maximum TVL change threshold
-			MinProviders:    2,     // Minimum 2 providers required
-			CooldownPeriod:  5 * time.Minute,
-			HealthThreshold: 3,     // Number of successful checks to return to closed state
-			OnTrip: func(reason string) {
-				logrus.Warnf("Circuit breaker tripped: %s", reason)
-			},
-		})
+		circuitBreaker = circuitbreaker.New(circuitbreaker.Thresholds{
+			MaxAPY:       100.0, // 10000% maximum APY threshold
+			MaxTVLChange: 50.0,  // 50% maximum TVL change threshold
+			MinProviders: 2,     // Minimum 2 providers required
+		}).
+			WithResetDelay(5 * time.Minute).
+			WithSuccessThreshold(3). // Number of successful checks to return to closed state
+			WithTripCallback(func(reason string, metrics []model.Metric) {
+				logging.Warnf("Circuit breaker tripped: %s", reason)
+			})
 	}
 
 	// Initialize metrics if enabled
@@ -264,26 +462,72 @@ func NewServer(config ServerConfig, providers []Provider) *Server {
 	
 	// Check for enterprise mode
 	enableEnterprise := getEnvBool("ENABLE_ENTERPRISE_FEATURES", false)
-	
+
+	// Initialize the keyed (IP/API-key/wallet) rate limiter. Mutating admin
+	// routes get a quarter of the default budget since a burst there is far
+	// more likely to be abuse than normal polling.
+	var keyedLimiter *ratelimit.Limiter
+	if config.RateLimitRequests > 0 {
+		tightRequests := config.RateLimitRequests / 4
+		if tightRequests < 1 {
+			tightRequests = 1
+		}
+		var store ratelimit.Store
+		if config.RateLimitRedisAddr != "" {
+			store = ratelimit.NewRedisStore(config.RateLimitRedisAddr)
+		}
+		keyedLimiter = ratelimit.New(ratelimit.Config{
+			Requests: config.RateLimitRequests,
+			Window:   config.RateLimitWindow,
+			KeyMode:  ratelimit.KeyMode(config.RateLimitKeyMode),
+			Store:    store,
+			RouteOverrides: map[string]ratelimit.RouteLimit{
+				"/admin/canary/promote": {Requests: tightRequests, Window: config.RateLimitWindow},
+			},
+		})
+	}
+
 	// Initialize server with basic features
 	server := &Server{
-		config:           config,
-		providers:        providers,
-		breaker:          circuitBreaker,
-		metrics:          metricsRegistry,
-		validationOpts:   validation.DefaultValidationOptions(),
-		enableEnterprise: enableEnterprise,
+		config:                   config,
+		providers:                providers,
+		breaker:                  circuitBreaker,
+		canaryCtrl:               canary.NewController(),
+		canaryPromoteMinSamples:  getEnvInt("CANARY_PROMOTE_MIN_SAMPLES", 20),
+		canaryPromoteMaxDeltaPct: getEnvFloat("CANARY_PROMOTE_MAX_DELTA_PCT", 0.02),
+		providerCache:            make(map[string]model.Metric, len(providers)),
+		checkpointStore:          aggregate.NewCheckpointStore(config.CheckpointPath),
+		tierStats:                newTierStats(),
+		keyedLimiter:             keyedLimiter,
+		metrics:                  metricsRegistry,
+		validationOpts:           validation.DefaultValidationOptions(),
+		enableEnterprise:         enableEnterprise,
+		dynamic: reloadableConfig{
+			AggregationMode:        config.AggregationMode,
+			Timeout:                config.Timeout,
+			EnableValidation:       config.EnableValidation,
+			EnableCircuitBreaker:   config.EnableCircuitBreaker,
+			MaxStaleness:           config.MaxStaleness,
+			KeyedRateLimitRequests: config.RateLimitRequests,
+			KeyedRateLimitWindow:   config.RateLimitWindow,
+		},
 	}
-	
+
+	server.checkpointStore.StartPeriodicSave(context.Background(), config.CheckpointInterval, server.snapshotLastAggregate)
+
 	// Initialize enterprise features if enabled
 	if enableEnterprise {
-		logrus.Info("Initializing enterprise features...")
+		logging.Info("Initializing enterprise features...")
 		
 		// Initialize rate limiter
 		requestsPerSecond := getEnvFloat("RATE_LIMIT_RPS", 10.0) // Default: 10 requests per second
 		burstSize := getEnvInt("RATE_LIMIT_BURST", 20)          // Default: burst of 20 requests
 		server.rateLimit = rate.NewLimiter(rate.Limit(requestsPerSecond), burstSize)
-		logrus.Infof("Rate limiting initialized: %v req/s, burst: %d", requestsPerSecond, burstSize)
+		server.dynMu.Lock()
+		server.dynamic.RateLimitRPS = requestsPerSecond
+		server.dynamic.RateLimitBurst = burstSize
+		server.dynMu.Unlock()
+		logging.Infof("Rate limiting initialized: %v req/s, burst: %d", requestsPerSecond, burstSize)
 		
 		// Initialize multi-chain client
 		if multiChainEnabled := getEnvBool("MULTICHAIN_ENABLED", false); multiChainEnabled {
@@ -310,25 +554,37 @@ func NewServer(config ServerConfig, providers []Provider) *Server {
 			}
 			
 			server.multiChainClient = fetch.NewMultiChainClient(chains)
-			logrus.Info("Multi-chain client initialized")
+			logging.Info("Multi-chain client initialized")
 		}
 		
 		// Initialize data integrity service if enabled
 		if dataIntegrityEnabled := getEnvBool("DATA_INTEGRITY_ENABLED", false); dataIntegrityEnabled {
 			signatureValidity := getDurationOrDefault("SIGNATURE_VALIDITY", 24*time.Hour)
-			
-			dataIntegrity, err := security.NewDataIntegrityService(security.VerificationOptions{
-				SignatureEnabled:     true,
-				VerificationRequired: getEnvBool("VERIFICATION_REQUIRED", false),
-				SignatureValidity:    signatureValidity,
-				StrictMode:           getEnvBool("STRICT_MODE", false),
-			})
-			
+			opts := security.VerificationOptions{
+				SignatureEnabled:       true,
+				VerificationRequired:   getEnvBool("VERIFICATION_REQUIRED", false),
+				SignatureValidity:      signatureValidity,
+				StrictMode:             getEnvBool("STRICT_MODE", false),
+				KeyRotationGracePeriod: getDurationOrDefault("KEY_ROTATION_GRACE_PERIOD", 7*24*time.Hour),
+			}
+
+			// KEYSTORE_PATH opts into a persistent signing key that survives
+			// restarts instead of minting a fresh one on every startup,
+			// which would otherwise invalidate any verifier that pinned the
+			// previous public key.
+			var dataIntegrity *security.DataIntegrityService
+			var err error
+			if keystorePath := getEnvOrDefault("KEYSTORE_PATH", ""); keystorePath != "" {
+				dataIntegrity, err = security.NewDataIntegrityServiceFromKeystore(keystorePath, os.Getenv("KEYSTORE_PASSPHRASE"), opts)
+			} else {
+				dataIntegrity, err = security.NewDataIntegrityService(opts)
+			}
+
 			if err != nil {
-				logrus.Warnf("Failed to initialize data integrity service: %v", err)
+				logging.Warnf("Failed to initialize data integrity service: %v", err)
 			} else {
 				server.dataIntegrity = dataIntegrity
-				logrus.Info("Data integrity service initialized")
+				logging.Info("Data integrity service initialized")
 			}
 		}
 		
@@ -346,15 +602,21 @@ func NewServer(config ServerConfig, providers []Provider) *Server {
 			})
 			
 			if err != nil {
-				logrus.Warnf("Failed to initialize metrics exporter: %v", err)
+				logging.Warnf("Failed to initialize metrics exporter: %v", err)
 			} else {
 				server.metricsExporter = exporter
-				logrus.Info("Metrics exporter initialized")
+				logging.Info("Metrics exporter initialized")
 			}
 		}
 	}
 	
-	logrus.WithFields(logrus.Fields{
+	if config.ConfigFile != "" {
+		if err := server.startConfigWatcher(config.ConfigFile); err != nil {
+			logging.Warnf("Failed to start config watcher for %s, continuing with static config: %v", config.ConfigFile, err)
+		}
+	}
+
+	logging.WithFields(logging.Fields{
 		"port":              config.Port,
 		"aggregation_mode":  config.AggregationMode,
 		"timeout":           config.Timeout,
@@ -362,23 +624,68 @@ func NewServer(config ServerConfig, providers []Provider) *Server {
 		"validation":        config.EnableValidation,
 		"metrics":           config.EnableMetrics,
 		"provider_count":    len(providers),
+		"config_file":       config.ConfigFile,
 	}).Info("Server initialized")
 
-	return s
+	return server
 
 }
 
+// rateLimited wraps next with the keyed rate limiter for route, or returns
+// next unchanged if no keyed limiter is configured.
+func (s *Server) rateLimited(route string, next http.HandlerFunc) http.HandlerFunc {
+	if s.keyedLimiter == nil {
+		return next
+	}
+	return s.keyedLimiter.Middleware(route, next)
+}
+
+// requireAdminToken gates next behind config.AdminToken: the caller must
+// present it as "Authorization: Bearer <token>" or "X-Admin-Token: <token>",
+// checked with subtle.ConstantTimeCompare to avoid a timing side-channel. If
+// AdminToken is unset, next is never reached - /admin/* refuses every
+// request with 503 rather than silently falling back to rate-limiting-only
+// protection, since these routes (including aggregation-mode promotion)
+// must never be reachable by an unauthenticated caller.
+func (s *Server) requireAdminToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.AdminToken == "" {
+			http.Error(w, "admin endpoints are disabled: ADMIN_TOKEN is not configured", http.StatusServiceUnavailable)
+			return
+		}
+
+		token := r.Header.Get("X-Admin-Token")
+		if token == "" {
+			if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+				token = strings.TrimPrefix(auth, "Bearer ")
+			}
+		}
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AdminToken)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
 // Start begins the HTTP server and sets up graceful shutdown
 func (s *Server) Start() {
 	// Create a new router
 	mux := http.NewServeMux()
 	
-	// Register API endpoints
-	mux.HandleFunc("/", s.handleRequest)             // Main Chainlink EA endpoint
-	mux.HandleFunc("/health", s.handleHealth)         // Health check endpoint
-	mux.HandleFunc("/metrics", s.handleMetrics)       // Prometheus metrics endpoint
-	mux.HandleFunc("/status", s.handleStatus)         // Service status endpoint
-	mux.HandleFunc("/circuit", s.handleCircuitStatus) // Circuit breaker status/control
+	// Register API endpoints, applying the keyed rate limiter (if configured)
+	// to everything but the read-only /health and /metrics probes
+	mux.HandleFunc("/", s.rateLimited("/", s.handleRequest))             // Main Chainlink EA endpoint
+	mux.HandleFunc("/health", s.handleHealth)                            // Health check endpoint
+	mux.HandleFunc("/metrics", s.handleMetrics)                          // Prometheus metrics endpoint
+	mux.HandleFunc("/status", s.rateLimited("/status", s.handleStatus))  // Service status endpoint
+	mux.HandleFunc("/circuit", s.rateLimited("/circuit", s.handleCircuitStatus)) // Circuit breaker status/control
+	mux.HandleFunc("/admin/loglevel", s.rateLimited("/admin/loglevel", s.requireAdminToken(s.handleAdminLogLevel)))             // Runtime log level control
+	mux.HandleFunc("/admin/canary", s.rateLimited("/admin/canary", s.requireAdminToken(s.handleAdminCanary)))                   // Canary aggregation mode shadow-run control
+	mux.HandleFunc("/admin/canary/last", s.rateLimited("/admin/canary/last", s.requireAdminToken(s.handleAdminCanaryLast)))     // Last signed canary divergence result
+	mux.HandleFunc("/admin/canary/promote", s.rateLimited("/admin/canary/promote", s.requireAdminToken(s.handleAdminCanaryPromote))) // Promote a passing canary to primary
 
 	// Configure server with timeouts
 	s.server = &http.Server{
@@ -391,26 +698,145 @@ func (s *Server) Start() {
 
 	// Start the server in a goroutine
 	go func() {
-		logrus.Infof("Server starting on port %s", s.config.Port)
+		logging.Infof("Server starting on port %s", s.config.Port)
 		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logrus.Fatalf("Error starting server: %v", err)
+			logging.Fatalf("Error starting server: %v", err)
 		}
 	}()
 
+	// SIGHUP re-triggers the config file reload on demand, for deployments
+	// where fsnotify doesn't fire reliably (see Watcher.TriggerReload)
+	if s.cfgWatcher != nil {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				logging.Info("Received SIGHUP, reloading config")
+				s.cfgWatcher.TriggerReload()
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logrus.Info("Server shutting down...")
+	logging.Info("Server shutting down...")
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := s.server.Shutdown(ctx); err != nil {
-		logrus.Fatalf("Server shutdown failed: %v", err)
+		logging.Fatalf("Server shutdown failed: %v", err)
+	}
+
+	if s.cfgWatcher != nil {
+		s.cfgWatcher.Stop()
 	}
 
-	logrus.Info("Server stopped")
+	logging.Info("Server stopped")
+}
+
+// startConfigWatcher loads path and watches it for changes via fsnotify,
+// applying updates through applyReloadedConfig on every edit
+func (s *Server) startConfigWatcher(path string) error {
+	watcher, err := config.NewWatcher(path, s.applyReloadedConfig)
+	if err != nil {
+		return err
+	}
+
+	watcher.OnSuccess = func() {
+		if s.metrics != nil {
+			s.metrics.configReloads.WithLabelValues("success").Inc()
+			s.metrics.configLastReload.Set(float64(time.Now().Unix()))
+		}
+	}
+	watcher.OnFailure = func(err error) {
+		if s.metrics != nil {
+			s.metrics.configReloads.WithLabelValues("failure").Inc()
+		}
+	}
+
+	watcher.Start(context.Background())
+	s.cfgWatcher = watcher
+	logging.Infof("Watching %s for hot config reloads", path)
+	return nil
+}
+
+// applyReloadedConfig parses data as the reloadable config subset and swaps
+// it into s.dynamic under dynMu. It logs a diff summary of what changed so
+// operators can confirm an edit took effect, and adjusts the rate limiter
+// in place since golang.org/x/time/rate supports live limit/burst changes.
+func (s *Server) applyReloadedConfig(data []byte) error {
+	var next reloadableConfig
+	if err := json.Unmarshal(data, &next); err != nil {
+		return fmt.Errorf("parsing reloaded config: %w", err)
+	}
+
+	if next.AggregationMode == "" {
+		next.AggregationMode = s.config.AggregationMode
+	}
+	if next.Timeout <= 0 {
+		next.Timeout = s.config.Timeout
+	}
+	if next.RateLimitRPS <= 0 {
+		next.RateLimitRPS = 10.0
+	}
+	if next.RateLimitBurst <= 0 {
+		next.RateLimitBurst = 20
+	}
+	if next.MaxStaleness <= 0 {
+		next.MaxStaleness = s.config.MaxStaleness
+	}
+	if next.KeyedRateLimitWindow <= 0 {
+		next.KeyedRateLimitWindow = s.config.RateLimitWindow
+	}
+
+	s.dynMu.Lock()
+	prev := s.dynamic
+	s.dynamic = next
+	s.dynMu.Unlock()
+
+	if s.rateLimit != nil && (next.RateLimitRPS != prev.RateLimitRPS || next.RateLimitBurst != prev.RateLimitBurst) {
+		s.rateLimit.SetLimit(rate.Limit(next.RateLimitRPS))
+		s.rateLimit.SetBurst(next.RateLimitBurst)
+	}
+
+	if s.keyedLimiter != nil && (next.KeyedRateLimitRequests != prev.KeyedRateLimitRequests || next.KeyedRateLimitWindow != prev.KeyedRateLimitWindow) {
+		tightRequests := next.KeyedRateLimitRequests / 4
+		if tightRequests < 1 {
+			tightRequests = 1
+		}
+		s.keyedLimiter.Update(ratelimit.Config{
+			Requests: next.KeyedRateLimitRequests,
+			Window:   next.KeyedRateLimitWindow,
+			KeyMode:  ratelimit.KeyMode(s.config.RateLimitKeyMode),
+			RouteOverrides: map[string]ratelimit.RouteLimit{
+				"/admin/canary/promote": {Requests: tightRequests, Window: next.KeyedRateLimitWindow},
+			},
+		})
+	}
+
+	logging.WithFields(logging.Fields{
+		"aggregation_mode":       fmt.Sprintf("%s -> %s", prev.AggregationMode, next.AggregationMode),
+		"timeout":                fmt.Sprintf("%s -> %s", prev.Timeout, next.Timeout),
+		"enable_validation":      fmt.Sprintf("%v -> %v", prev.EnableValidation, next.EnableValidation),
+		"enable_circuit_breaker": fmt.Sprintf("%v -> %v", prev.EnableCircuitBreaker, next.EnableCircuitBreaker),
+		"rate_limit_rps":         fmt.Sprintf("%v -> %v", prev.RateLimitRPS, next.RateLimitRPS),
+		"rate_limit_burst":       fmt.Sprintf("%v -> %v", prev.RateLimitBurst, next.RateLimitBurst),
+		"max_staleness":          fmt.Sprintf("%s -> %s", prev.MaxStaleness, next.MaxStaleness),
+		"keyed_rate_limit":       fmt.Sprintf("%d/%s -> %d/%s", prev.KeyedRateLimitRequests, prev.KeyedRateLimitWindow, next.KeyedRateLimitRequests, next.KeyedRateLimitWindow),
+	}).Info("applied hot config reload")
+
+	return nil
+}
+
+// currentDynamicConfig returns a copy of the live reloadable config for
+// handlers to read without racing a concurrent reload
+func (s *Server) currentDynamicConfig() reloadableConfig {
+	s.dynMu.RLock()
+	defer s.dynMu.RUnlock()
+	return s.dynamic
 }
 
 // handleHealth is a simple health check endpoint
@@ -436,30 +862,39 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 
 // handleStatus provides detailed service status information
 func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	dynCfg := s.currentDynamicConfig()
 	status := map[string]interface{}{
 		"status": "operational",
 		"uptime": time.Since(startTime).String(),
 		"version": "1.0.0",
 		"providers": len(s.providers),
 		"configuration": map[string]interface{}{
-			"aggregation_mode": s.config.AggregationMode,
-			"circuit_breaker": s.config.EnableCircuitBreaker,
-			"validation": s.config.EnableValidation,
+			"aggregation_mode": dynCfg.AggregationMode,
+			"circuit_breaker": dynCfg.EnableCircuitBreaker,
+			"validation": dynCfg.EnableValidation,
+			"max_staleness": dynCfg.MaxStaleness.String(),
 		},
+		"fallback_tier_ratio_1h": s.tierStats.ratios(),
 	}
 
 	// Add circuit breaker state if enabled
-	if s.config.EnableCircuitBreaker && s.breaker != nil {
+	if dynCfg.EnableCircuitBreaker && s.breaker != nil {
 		status["circuit_state"] = s.breaker.GetState()
 	}
 
+	if latencies, err := fetch.ProviderLatencySnapshots(); err != nil {
+		logging.Warnf("failed to compute provider latency snapshots: %v", err)
+	} else if len(latencies) > 0 {
+		status["provider_latency"] = latencies
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(status)
 }
 
 // handleCircuitStatus allows viewing and controlling the circuit breaker
 func (s *Server) handleCircuitStatus(w http.ResponseWriter, r *http.Request) {
-	if !s.config.EnableCircuitBreaker || s.breaker == nil {
+	if !s.currentDynamicConfig().EnableCircuitBreaker || s.breaker == nil {
 		http.Error(w, "Circuit breaker not enabled", http.StatusServiceUnavailable)
 		return
 	}
@@ -489,6 +924,120 @@ func (s *Server) handleCircuitStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleAdminLogLevel reports the current log level on GET and, on PUT,
+// changes it at runtime without a restart, e.g. dropping to debug while
+// chasing down an incident and back to info once it's resolved.
+func (s *Server) handleAdminLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(map[string]string{"level": logging.Level().String()})
+		return
+	}
+
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lvl, err := logging.ParseLevel(strings.ToLower(body.Level))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logging.SetLevel(lvl)
+	logging.Infof("Log level changed to %s via admin endpoint", lvl)
+	json.NewEncoder(w).Encode(map[string]string{"level": lvl.String()})
+}
+
+// handleAdminCanary reports the active canary mode on GET and, on POST,
+// starts (or stops, with an empty "mode") shadow-running a second
+// aggregation mode alongside the primary so operators can validate it
+// against production traffic before promoting it.
+func (s *Server) handleAdminCanary(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(map[string]string{"mode": s.canaryCtrl.Mode()})
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Mode string `json:"mode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	s.canaryCtrl.SetMode(body.Mode)
+	if body.Mode == "" {
+		logging.Info("Canary shadow-run stopped via admin endpoint")
+	} else {
+		logging.Infof("Canary shadow-run started for mode %q via admin endpoint", body.Mode)
+	}
+	json.NewEncoder(w).Encode(map[string]string{"mode": s.canaryCtrl.Mode()})
+}
+
+// handleAdminCanaryLast returns the most recent signed canary divergence
+// result, letting operators diff it against the primary response externally.
+func (s *Server) handleAdminCanaryLast(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	last, ok := s.canaryCtrl.Last()
+	if !ok {
+		http.Error(w, "No canary result recorded yet", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(last)
+}
+
+// handleAdminCanaryPromote atomically swaps the primary aggregation mode to
+// the canary's candidate mode, but only once it has collected enough
+// samples with a median delta under canaryPromoteMaxDeltaPct - a safe way
+// to validate a new aggregation strategy in production before trusting it.
+func (s *Server) handleAdminCanaryPromote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	mode := s.canaryCtrl.Mode()
+	passing, reason := s.canaryCtrl.Passing(s.canaryPromoteMinSamples, s.canaryPromoteMaxDeltaPct)
+	if !passing {
+		http.Error(w, fmt.Sprintf("Canary not ready for promotion: %s", reason), http.StatusPreconditionFailed)
+		return
+	}
+
+	s.dynMu.Lock()
+	prevMode := s.dynamic.AggregationMode
+	s.dynamic.AggregationMode = mode
+	s.dynMu.Unlock()
+
+	s.canaryCtrl.SetMode("")
+	logging.Infof("Promoted canary aggregation mode %q to primary (was %q): %s", mode, prevMode, reason)
+	json.NewEncoder(w).Encode(map[string]string{
+		"promoted": mode,
+		"previous": prevMode,
+		"reason":   reason,
+	})
+}
+
 // ChainlinkRequest matches the standard Chainlink External Adapter request format
 type ChainlinkRequest struct {
 	ID       string                 `json:"id"`
@@ -531,36 +1080,40 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Snapshot the hot-reloadable config for this request so a concurrent
+	// reload can't apply half-old, half-new settings within one request
+	dynCfg := s.currentDynamicConfig()
+
 	// Increase request counter in metrics
 	if s.metrics != nil {
-		s.metrics.requestCounter.WithLabelValues("started", s.config.AggregationMode).Inc()
+		s.metrics.requestCounter.WithLabelValues("started", dynCfg.AggregationMode).Inc()
 	}
 
 	// Set up context with timeout from config
-	ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+	ctx, cancel := context.WithTimeout(r.Context(), dynCfg.Timeout)
 	defer cancel()
-	
-	// Variable to hold metrics from providers
-	var metrics []model.Metric
-	var err error
-	
-	// Choose data source based on enterprise mode
-	if s.enableEnterprise && s.multiChainClient != nil {
-		// Use multi-chain client for enterprise mode
-		logrus.Info("Using multi-chain client for data fetching")
-		metrics, err = s.multiChainClient.Fetch(ctx)
-	} else {
-		// Use standard providers for normal mode
-		metrics, err = s.fetchAllMetrics(ctx)
-	}
-	
+
+	// Attach request-scoped fields so every log line from here on identifies
+	// which request it belongs to
+	ctx = logging.WithRequestID(ctx, request.ID)
+	ctx = logging.WithJobRunID(ctx, request.JobRunID)
+	ctx = logging.WithAggregationMode(ctx, dynCfg.AggregationMode)
+	ctx = logging.WithProviderCount(ctx, len(s.providers))
+	log := logging.L(ctx)
+
+	// Resolve metrics through the tiered fallback pipeline: live (tier 1),
+	// per-provider last-known-good (tier 2, "partial"), the circuit
+	// breaker's in-memory last-known-good (tier 3, "cached"), and the
+	// on-disk checkpoint (tier 4, "stale"). Only a total failure of every
+	// tier returns an error here.
+	metrics, freshness, collectedAt, err := s.resolveMetrics(ctx, log)
 	if err != nil {
 		s.errorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching metrics: %v", err))
 		return
 	}
 
 	// If validation is enabled, filter invalid metrics
-	if s.config.EnableValidation {
+	if dynCfg.EnableValidation {
 		metrics = validation.FilterInvalid(metrics)
 		if len(metrics) == 0 {
 			s.errorResponse(w, http.StatusServiceUnavailable, "No valid metrics available after validation")
@@ -568,30 +1121,46 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Apply circuit breaker check if enabled
-	if s.config.EnableCircuitBreaker && s.breaker != nil {
+	// Apply circuit breaker anomaly check if enabled; on trip, degrade
+	// through the same cached/stale fallback tiers used when no provider
+	// responds at all, rather than hard-failing immediately
+	if dynCfg.EnableCircuitBreaker && s.breaker != nil {
 		if err := s.breaker.Check(metrics); err != nil {
-			logrus.Warnf("Circuit breaker tripped: %v", err)
-
-			// Attempt to use last known good metrics
-			lastGood := s.breaker.LastGoodMetrics()
-			if lastGood != nil && len(lastGood) > 0 {
-				logrus.Info("Using last known good metrics")
-				metrics = lastGood
-			} else {
+			fallback, fbFreshness, fbCollectedAt, ok := s.degradeToFallback(log, fmt.Sprintf("circuit breaker tripped: %v", err))
+			if !ok {
 				s.errorResponse(w, http.StatusServiceUnavailable, fmt.Sprintf("Circuit breaker open: %v", err))
 				return
 			}
+			metrics, freshness, collectedAt = fallback, fbFreshness, fbCollectedAt
 		}
 	}
 
+	// Reject cached/stale responses that have aged past MaxStaleness rather
+	// than serving Chainlink nodes indeterminate, overly-old data
+	age := time.Since(collectedAt)
+	if freshness.Tier() >= aggregate.FreshnessCached.Tier() && age > dynCfg.MaxStaleness {
+		s.errorResponse(w, http.StatusServiceUnavailable, fmt.Sprintf("Freshness %q is %s old, exceeding MaxStaleness %s", freshness, age.Round(time.Second), dynCfg.MaxStaleness))
+		return
+	}
+
+	if s.metrics != nil {
+		s.metrics.fallbackTier.WithLabelValues(string(freshness)).Inc()
+	}
+	s.tierStats.record(freshness)
+
 	// Track metric count in Prometheus
 	if s.metrics != nil {
 		s.metrics.metricCount.Set(float64(len(metrics)))
 	}
 
 	// Aggregate metrics based on configuration
-	result := s.aggregateMetrics(metrics)
+	result := s.aggregateMetrics(metrics, request)
+
+	// Keep the on-disk checkpoint fed from genuinely live data, never from
+	// a cached/stale response degrading back into itself
+	if freshness == aggregate.FreshnessLive || freshness == aggregate.FreshnessPartial {
+		s.setLastAggregate(result)
+	}
 
 	// Track aggregated values in Prometheus
 	if s.metrics != nil {
@@ -599,6 +1168,22 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 		s.metrics.aggregateTVL.Set(result.TVL)
 	}
 
+	// Shadow-run an active canary aggregation mode against the same metric
+	// set so operators can compare it against the primary without affecting
+	// the response, then record the divergence for /admin/canary/promote.
+	if canaryMode := s.canaryCtrl.Mode(); canaryMode != "" {
+		canaryResult := aggregateByMode(metrics, canaryMode)
+		canaryRecord := s.canaryCtrl.Record(result, canaryResult)
+
+		if s.enableEnterprise && s.dataIntegrity != nil {
+			if signed, err := s.dataIntegrity.SignPayload(canaryRecord); err != nil {
+				log.Warnf("Failed to sign canary result: %v", err)
+			} else {
+				s.canaryCtrl.SetSigned(signed)
+			}
+		}
+	}
+
 	// Format the Chainlink EA response
 	response := ChainlinkResponse{
 		JobRunID:   request.JobRunID,
@@ -612,6 +1197,8 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 			"provider":     result.Provider,
 			"collectedAt":  result.CollectedAt,
 			"timestamp":    time.Now().Unix(),
+			"freshness":    string(freshness),
+			"age_seconds":  age.Seconds(),
 		},
 	}
 
@@ -633,7 +1220,7 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	}
 	request.Meta["latencyMs"] = time.Since(start).Milliseconds()
 	request.Meta["metricCount"] = len(metrics)
-	request.Meta["aggregationMode"] = s.config.AggregationMode
+	request.Meta["aggregationMode"] = dynCfg.AggregationMode
 	
 	// Add enterprise-specific metadata if enabled
 	if s.enableEnterprise {
@@ -652,7 +1239,7 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	// Finish request timing in Prometheus
 	if s.metrics != nil {
 		s.metrics.requestDuration.WithLabelValues("success").Observe(time.Since(start).Seconds())
-		s.metrics.requestCounter.WithLabelValues("success", s.config.AggregationMode).Inc()
+		s.metrics.requestCounter.WithLabelValues("success", dynCfg.AggregationMode).Inc()
 	}
 	
 	// Apply data integrity signing if enabled
@@ -669,7 +1256,7 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 		})
 		
 		if err != nil {
-			logrus.Warnf("Failed to create tamper-proof data: %v", err)
+			logging.Warnf("Failed to create tamper-proof data: %v", err)
 		} else {
 			responseData = tamperProofData
 		}
@@ -687,11 +1274,11 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 
 // errorResponse returns a formatted error response for Chainlink nodes
 func (s *Server) errorResponse(w http.ResponseWriter, statusCode int, errorMsg string) {
-	logrus.Warn(errorMsg)
+	logging.Warn(errorMsg)
 
 	// Track errors in metrics
 	if s.metrics != nil {
-		s.metrics.requestCounter.WithLabelValues("error", s.config.AggregationMode).Inc()
+		s.metrics.requestCounter.WithLabelValues("error", s.currentDynamicConfig().AggregationMode).Inc()
 	}
 
 	response := ChainlinkResponse{
@@ -706,17 +1293,51 @@ func (s *Server) errorResponse(w http.ResponseWriter, statusCode int, errorMsg s
 	json.NewEncoder(w).Encode(response)
 }
 
-// aggregateMetrics combines metrics using the configured strategy
-func (s *Server) aggregateMetrics(metrics []model.Metric) model.Metric {
-	var result model.Metric
+// aggregateMetrics combines metrics using the configured strategy, or a
+// per-request aggregate.View when the Chainlink request payload carries a
+// "view" field (e.g. {"view": {"apy": "trimmed_mean", "pointsPerETH": "median"}}),
+// letting callers route individual metric fields through pluggable
+// aggregate.Aggregator strategies without changing server config.
+func (s *Server) aggregateMetrics(metrics []model.Metric, request ChainlinkRequest) model.Metric {
+	if view, ok := viewFromRequest(request); ok {
+		result, err := view.Apply(context.Background(), metrics)
+		if err != nil {
+			logging.Warnf("view aggregation failed, falling back to configured mode: %v", err)
+		} else {
+			result.Provider = "aggregated-view"
+			if result.CollectedAt == 0 {
+				result.CollectedAt = time.Now().Unix()
+			}
+			return result
+		}
+	}
+
+	aggregationMode := s.currentDynamicConfig().AggregationMode
+	result := aggregateByMode(metrics, aggregationMode)
+
+	// Add aggregator as provider name for transparency
+	result.Provider = "aggregated-" + aggregationMode
 	
-	switch s.config.AggregationMode {
+	// Ensure timestamp is current
+	if result.CollectedAt == 0 {
+		result.CollectedAt = time.Now().Unix()
+	}
+
+	return result
+}
+
+// aggregateByMode runs metrics through the named aggregation strategy,
+// falling back to weighted aggregation for an unrecognized mode. Shared by
+// aggregateMetrics for the primary result and by handleRequest to shadow-run
+// a canary mode against the same metric set (see internal/canary).
+func aggregateByMode(metrics []model.Metric, mode string) model.Metric {
+	switch mode {
 	case "weighted":
-		result = aggregate.Weighted(metrics)
+		return aggregate.Weighted(metrics)
 	case "median":
-		result = aggregate.MedianAggregation(metrics)
+		return aggregate.MedianAggregation(metrics)
 	case "trimmed":
-		result = aggregate.TrimmedMeanAggregation(metrics, 0.1) // 10% trimming
+		return aggregate.TrimmedMeanAggregation(metrics, 0.1) // 10% trimming
 	case "consensus":
 		// Apply confidence scoring
 		scored := validation.CalculateConfidenceScores(metrics)
@@ -732,29 +1353,53 @@ func (s *Server) aggregateMetrics(metrics []model.Metric) model.Metric {
 			highConfidence = scored
 		}
 		// Use weighted average on the filtered set
-		result = aggregate.Weighted(highConfidence)
+		return aggregate.Weighted(highConfidence)
 	default:
 		// Default to weighted aggregation
-		result = aggregate.Weighted(metrics)
+		return aggregate.Weighted(metrics)
 	}
-	
-	// Add aggregator as provider name for transparency
-	result.Provider = "aggregated-" + s.config.AggregationMode
-	
-	// Ensure timestamp is current
-	if result.CollectedAt == 0 {
-		result.CollectedAt = time.Now().Unix()
+}
+
+// viewFromRequest parses an optional "view" object out of the Chainlink
+// request payload's data into an aggregate.View, mapping field names to
+// registered aggregate.Aggregator kinds (see aggregate.Register). Unknown
+// or missing field entries fall back to View's own defaults.
+func viewFromRequest(request ChainlinkRequest) (aggregate.View, bool) {
+	raw, ok := request.Data["view"].(map[string]interface{})
+	if !ok {
+		return aggregate.View{}, false
 	}
-	
-	return result
+
+	view := aggregate.View{}
+	if name, ok := raw["apy"].(string); ok {
+		if agg, err := aggregate.NewAggregator(name, nil); err == nil {
+			view.APY = agg
+		}
+	}
+	if name, ok := raw["tvl"].(string); ok {
+		if agg, err := aggregate.NewAggregator(name, nil); err == nil {
+			view.TVL = agg
+		}
+	}
+	if name, ok := raw["pointsPerETH"].(string); ok {
+		if agg, err := aggregate.NewAggregator(name, nil); err == nil {
+			view.PointsPerETH = agg
+		}
+	}
+
+	return view, true
 }
 
-func (s *Server) fetchAllMetrics(ctx context.Context) ([]model.Metric, error) {
+// fetchAllMetrics fetches from every provider concurrently. A provider whose
+// live fetch fails is degraded to its cached last-known-good metrics (see
+// Server.providerCache) instead of being dropped outright; partial reports
+// whether any provider needed that substitution, for the "partial" freshness
+// tier.
+func (s *Server) fetchAllMetrics(ctx context.Context) (metrics []model.Metric, partial bool, err error) {
     var (
-        wg      sync.WaitGroup
-        mu      sync.Mutex
-        metrics []model.Metric
-        errs    []error
+        wg   sync.WaitGroup
+        mu   sync.Mutex
+        errs []error
     )
 
     for _, provider := range s.providers {
@@ -762,15 +1407,23 @@ func (s *Server) fetchAllMetrics(ctx context.Context) ([]model.Metric, error) {
         go func(p Provider) {
             defer wg.Done()
 
-            providerMetrics, err := p.Fetch(ctx)
+            providerMetrics, ferr := p.Fetch(ctx)
+
             mu.Lock()
             defer mu.Unlock()
 
-            if err != nil {
-                errs = append(errs, err)
+            if ferr != nil {
+                if cached, ok := s.cachedProviderMetrics(p.Name()); ok {
+                    logging.Warnf("Provider %s failed, using last-known-good: %v", p.Name(), ferr)
+                    metrics = append(metrics, cached...)
+                    partial = true
+                } else {
+                    errs = append(errs, ferr)
+                }
                 return
             }
 
+            s.cacheProviderMetrics(p.Name(), providerMetrics)
             metrics = append(metrics, providerMetrics...)
         }(provider)
     }
@@ -778,31 +1431,116 @@ func (s *Server) fetchAllMetrics(ctx context.Context) ([]model.Metric, error) {
     wg.Wait()
 
     if len(metrics) == 0 && len(errs) > 0 {
-        return nil, fmt.Errorf("all providers failed: %v", errs[0])
+        return nil, false, fmt.Errorf("all providers failed: %v", errs[0])
     }
 
-    return metrics, nil
+    return metrics, partial, nil
 }
 
-func getEnvOrDefault(key, defaultValue string) string {
-    value := os.Getenv(key)
-    if value == "" {
-        return defaultValue
+// cachedProviderMetrics returns the last successful metrics recorded for
+// provider name, if any.
+func (s *Server) cachedProviderMetrics(name string) ([]model.Metric, bool) {
+    s.providerCacheMu.RLock()
+    defer s.providerCacheMu.RUnlock()
+    metric, ok := s.providerCache[name]
+    if !ok {
+        return nil, false
     }
-    return value
+    return []model.Metric{metric}, true
 }
 
-func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
-    value := os.Getenv(key)
-    if value == "" {
-        return defaultValue
+// cacheProviderMetrics records the most recent metric from provider name as
+// its last-known-good, for substitution if a later fetch fails.
+func (s *Server) cacheProviderMetrics(name string, metrics []model.Metric) {
+    if len(metrics) == 0 {
+        return
     }
+    s.providerCacheMu.Lock()
+    defer s.providerCacheMu.Unlock()
+    s.providerCache[name] = metrics[len(metrics)-1]
+}
 
-    duration, err := time.ParseDuration(value)
-    if err != nil {
-        logrus.Printf("Warning: Invalid duration for %s, using default", key)
-        return defaultValue
-    }
+// resolveMetrics runs tiers 1-2 of the fallback pipeline: a live fetch,
+// degrading individual failed providers to their per-provider last-known-good
+// ("partial", see fetchAllMetrics). If nothing came back live at all, it
+// falls through degradeToFallback for tiers 3-4.
+func (s *Server) resolveMetrics(ctx context.Context, log *logging.Entry) ([]model.Metric, aggregate.Freshness, time.Time, error) {
+	var (
+		metrics []model.Metric
+		partial bool
+		err     error
+	)
+
+	if s.enableEnterprise && s.multiChainClient != nil {
+		log.Info("Using multi-chain client for data fetching")
+		metrics, err = s.multiChainClient.Fetch(ctx)
+	} else {
+		metrics, partial, err = s.fetchAllMetrics(ctx)
+	}
+
+	if err == nil && len(metrics) > 0 {
+		if partial {
+			return metrics, aggregate.FreshnessPartial, time.Now(), nil
+		}
+		return metrics, aggregate.FreshnessLive, time.Now(), nil
+	}
+
+	if fallback, freshness, collectedAt, ok := s.degradeToFallback(log, fmt.Sprintf("no providers responded: %v", err)); ok {
+		return fallback, freshness, collectedAt, nil
+	}
+
+	return nil, "", time.Time{}, fmt.Errorf("no providers responded and no fallback data available: %w", err)
+}
+
+// degradeToFallback tries tier 3 (the circuit breaker's in-memory
+// last-known-good aggregate, "cached") then tier 4 (the on-disk Checkpoint,
+// "stale"), in that order, logging reason as the trigger. It returns
+// ok=false only if neither tier has anything to offer.
+func (s *Server) degradeToFallback(log *logging.Entry, reason string) (metrics []model.Metric, freshness aggregate.Freshness, collectedAt time.Time, ok bool) {
+	log.Warnf("%s; degrading to in-memory last-known-good", reason)
+	if s.breaker != nil {
+		if lastGood := s.breaker.LastGoodMetrics(); len(lastGood) > 0 {
+			return lastGood, aggregate.FreshnessCached, newestCollectedAt(lastGood), true
+		}
+	}
+
+	log.Warn("In-memory last-known-good unavailable, degrading to on-disk checkpoint")
+	if cp, found := s.checkpointStore.Load(); found {
+		return []model.Metric{cp.Metric}, aggregate.FreshnessStale, cp.CollectedAt, true
+	}
+
+	return nil, "", time.Time{}, false
+}
+
+// newestCollectedAt returns the most recent CollectedAt across metrics, or
+// the zero Time if none is set.
+func newestCollectedAt(metrics []model.Metric) time.Time {
+	var newest int64
+	for _, m := range metrics {
+		if m.CollectedAt > newest {
+			newest = m.CollectedAt
+		}
+	}
+	if newest == 0 {
+		return time.Time{}
+	}
+	return time.Unix(newest, 0)
+}
+
+// setLastAggregate records result as the most recent live/partial aggregate,
+// for snapshotLastAggregate to persist into the on-disk Checkpoint.
+func (s *Server) setLastAggregate(result model.Metric) {
+	s.lastAggregateMu.Lock()
+	defer s.lastAggregateMu.Unlock()
+	s.lastAggregate = result
+	s.hasLastAggregate = true
+}
 
-    return duration
+// snapshotLastAggregate is the CheckpointStore.StartPeriodicSave callback:
+// it returns the last live/partial aggregate recorded via setLastAggregate,
+// or ok=false if none has been recorded yet.
+func (s *Server) snapshotLastAggregate() (model.Metric, bool) {
+	s.lastAggregateMu.RLock()
+	defer s.lastAggregateMu.RUnlock()
+	return s.lastAggregate, s.hasLastAggregate
 }
@@ -187,50 +187,139 @@ func ValidateMetric(m model.Metric) error {
 	return nil
 }
 
-// FilterOutliers entfernt Ausreißer aus den Metriken basierend auf statistischen Methoden
-// Verwendet den IQR (Interquartile Range) zur Erkennung von Ausreißern
+// hampelScaleFactor converts a Median Absolute Deviation into a robust
+// estimate of standard deviation under normality, matching
+// validation.madScaleFactor and the 1.4826 used throughout huber.go.
+const hampelScaleFactor = 1.4826
+
+// defaultHampelK is the default number of scaled-MAD units a value may
+// deviate from the median before FilterOutliers flags it as an outlier.
+const defaultHampelK = 3.0
+
+// defaultOutlierFields are the model.Metric fields FilterOutliers checks
+// when OutlierConfig.Fields is empty.
+var defaultOutlierFields = []string{"apy", "tvl", "points_per_eth"}
+
+// OutlierConfig tunes FilterOutliersWithConfig's Hampel identifier: K scales
+// the MAD-derived bound (<=0 uses defaultHampelK), and Fields selects which
+// of "apy", "tvl", "points_per_eth" are checked (empty checks all three).
+type OutlierConfig struct {
+	K      float64
+	Fields []string
+}
+
+// FilterOutliers entfernt Ausreißer aus den Metriken mittels des Hampel-
+// Identifiers: für jedes Feld in defaultOutlierFields wird der Median und
+// die auf Normalverteilung skalierte mediane absolute Abweichung (MAD *
+// 1.4826) berechnet, und ein Wert gilt als Ausreißer, wenn er mehr als
+// defaultHampelK skalierte MADs vom Median abweicht. Im Gegensatz zum
+// vorherigen festen IQR-Cutoff (nur auf APY) ist der Hampel-Identifier
+// robuster gegen mehrere gleichzeitige Ausreißer und wird hier zusätzlich
+// auf TVL und PointsPerETH angewendet; eine Metrik fällt raus, sobald ein
+// beliebiges Feld sie flaggt.
 func FilterOutliers(metrics []model.Metric) []model.Metric {
+	return FilterOutliersWithConfig(metrics, OutlierConfig{K: defaultHampelK})
+}
+
+// FilterOutliersWithConfig is FilterOutliers with a tunable K and field
+// subset, for callers that need a looser/tighter cutoff or want to skip a
+// field (e.g. PointsPerETH for providers that legitimately vary it a lot).
+func FilterOutliersWithConfig(metrics []model.Metric, cfg OutlierConfig) []model.Metric {
 	if len(metrics) < 4 {
 		return metrics
 	}
 
-	// Extrahiere APY-Werte
-	apyValues := make([]float64, 0, len(metrics))
-	for _, m := range metrics {
-		if m.TVL > 0 && m.APY >= 0 {
-			apyValues = append(apyValues, m.APY)
-		}
+	k := cfg.K
+	if k <= 0 {
+		k = defaultHampelK
 	}
-
-	if len(apyValues) < 4 {
-		return metrics
+	fields := cfg.Fields
+	if len(fields) == 0 {
+		fields = defaultOutlierFields
 	}
 
-	sort.Float64s(apyValues)
-	n := len(apyValues)
-
-	// Berechne Q1 (25. Perzentil) und Q3 (75. Perzentil)
-	q1Index := n / 4
-	q3Index := n * 3 / 4
-	q1 := apyValues[q1Index]
-	q3 := apyValues[q3Index]
+	flagged := make([]bool, len(metrics))
+	for _, field := range fields {
+		values := make([]float64, 0, len(metrics))
+		indices := make([]int, 0, len(metrics))
+		for i, m := range metrics {
+			if m.TVL <= 0 || m.APY < 0 {
+				continue
+			}
+			values = append(values, outlierFieldValue(m, field))
+			indices = append(indices, i)
+		}
+		if len(values) < 4 {
+			continue
+		}
 
-	// Berechne IQR und Grenzen für Ausreißer
-	iqr := q3 - q1
-	lowerBound := q1 - 1.5*iqr
-	upperBound := q3 + 1.5*iqr
+		lower, upper, ok := hampelBounds(values, k)
+		if !ok {
+			continue
+		}
+		for j, v := range values {
+			if v < lower || v > upper {
+				flagged[indices[j]] = true
+			}
+		}
+	}
 
-	// Filtere Ausreißer
 	filtered := make([]model.Metric, 0, len(metrics))
-	for _, m := range metrics {
-		if m.APY >= lowerBound && m.APY <= upperBound {
+	for i, m := range metrics {
+		if !flagged[i] {
 			filtered = append(filtered, m)
 		}
 	}
-
 	return filtered
 }
 
+// outlierFieldValue extracts the value of field ("apy", "tvl" or
+// "points_per_eth") from m for FilterOutliersWithConfig. Unknown field names
+// fall back to APY.
+func outlierFieldValue(m model.Metric, field string) float64 {
+	switch field {
+	case "tvl":
+		return m.TVL
+	case "points_per_eth":
+		return m.PointsPerETH
+	default:
+		return m.APY
+	}
+}
+
+// hampelBounds returns the [median-k*scaledMAD, median+k*scaledMAD] Hampel
+// bounds for values. When the scaled MAD collapses to zero (e.g. more than
+// half of values coincide), it falls back to the 1.5*IQR rule FilterOutliers
+// used before this rewrite; when IQR is also zero it reports ok=false so the
+// caller keeps every value for this field.
+func hampelBounds(values []float64, k float64) (lower, upper float64, ok bool) {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	med := medianOfSorted(sorted)
+
+	if mad := medianAbsDeviation(values, med) * hampelScaleFactor; mad > 0 {
+		return med - k*mad, med + k*mad, true
+	}
+
+	n := len(sorted)
+	q1 := sorted[n/4]
+	q3 := sorted[n*3/4]
+	iqr := q3 - q1
+	if iqr <= 0 {
+		return 0, 0, false
+	}
+	return q1 - 1.5*iqr, q3 + 1.5*iqr, true
+}
+
+// medianOfSorted returns the median of an already-sorted slice.
+func medianOfSorted(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return sorted[n/2]
+}
+
 // ValidateAndFilterMetrics kombiniert Validierung und Ausreißererkennung
 // Gibt nur gültige Metriken zurück, die keine Ausreißer sind
 func ValidateAndFilterMetrics(metrics []model.Metric) []model.Metric {
@@ -342,6 +431,134 @@ func MedianAggregation(metrics []model.Metric) model.Metric {
 	}
 }
 
+// defaultConfidenceZ ist der Standard-z-Score für ein 95%-Konfidenzintervall.
+const defaultConfidenceZ = 1.96
+
+// WeightedWithStats berechnet wie WeightedWithValidation einen
+// TVL-gewichteten Mittelwert, reichert das Ergebnis aber um gewichtete
+// Standardabweichung, Standardfehler und ein Konfidenzintervall bei z an.
+// Der Standardfehler verwendet Kishs effektive Stichprobengröße
+// (Σw)² / Σw² statt der rohen Anzahl Metriken, da ein einzelner
+// TVL-dominanter Provider die effektive Stichprobe verkleinert. Aufrufer
+// (z.B. Chainlink-Jobs) können Aggregationen verwerfen, deren CI-Breite
+// einen Schwellwert überschreitet.
+func WeightedWithStats(metrics []model.Metric, z float64) model.MetricWithCI {
+	if z <= 0 {
+		z = defaultConfidenceZ
+	}
+
+	validMetrics := ValidateAndFilterMetrics(metrics)
+	mean := Weighted(validMetrics)
+
+	var sumWeight, sumWeightSq float64
+	var apyVarNum, pointsVarNum float64
+
+	for _, m := range validMetrics {
+		if m.TVL <= 0 {
+			continue
+		}
+		w := m.TVL
+		sumWeight += w
+		sumWeightSq += w * w
+		apyDiff := m.APY - mean.APY
+		pointsDiff := m.PointsPerETH - mean.PointsPerETH
+		apyVarNum += w * apyDiff * apyDiff
+		pointsVarNum += w * pointsDiff * pointsDiff
+	}
+
+	result := model.MetricWithCI{Metric: mean, Z: z}
+	if sumWeight <= 0 {
+		return result
+	}
+
+	effectiveN := (sumWeight * sumWeight) / sumWeightSq
+	result.EffectiveN = effectiveN
+	if effectiveN <= 0 {
+		return result
+	}
+
+	result.APYStdDev = math.Sqrt(apyVarNum / sumWeight)
+	result.APYStdErr = result.APYStdDev / math.Sqrt(effectiveN)
+	result.APYCILow = mean.APY - z*result.APYStdErr
+	result.APYCIHigh = mean.APY + z*result.APYStdErr
+
+	result.PointsStdDev = math.Sqrt(pointsVarNum / sumWeight)
+	result.PointsStdErr = result.PointsStdDev / math.Sqrt(effectiveN)
+	result.PointsCILow = mean.PointsPerETH - z*result.PointsStdErr
+	result.PointsCIHigh = mean.PointsPerETH + z*result.PointsStdErr
+
+	return result
+}
+
+// AggKey identifiziert eine Gruppierungsdimension für gefilterte
+// Re-Aggregation, z.B. Chain, Asset oder Restaking-Protokoll.
+type AggKey string
+
+// AttributeFilter leitet aus einer Metrik den AggKey ab, nach dem Metriken
+// vor dem Weighted/Median-Rollup partitioniert werden.
+type AttributeFilter func(model.Metric) AggKey
+
+// GroupedWeighted partitioniert metrics anhand von key und wendet Weighted
+// je Gruppe an. Nützlich, um vor dem Rollup nach Chain, Asset oder
+// Restaking-Protokoll zu filtern, statt alle Metriken blind zu mischen.
+func GroupedWeighted(metrics []model.Metric, key func(model.Metric) string) map[string]model.Metric {
+	groups := make(map[string][]model.Metric)
+	for _, m := range metrics {
+		k := key(m)
+		groups[k] = append(groups[k], m)
+	}
+
+	result := make(map[string]model.Metric, len(groups))
+	for k, ms := range groups {
+		result[k] = Weighted(ms)
+	}
+	return result
+}
+
+// GroupedWeightedByAttribute ist das AggKey-Gegenstück zu GroupedWeighted für
+// Aufrufer, die bereits einen typisierten AttributeFilter verwenden.
+func GroupedWeightedByAttribute(metrics []model.Metric, filter AttributeFilter) map[AggKey]model.Metric {
+	groups := make(map[AggKey][]model.Metric)
+	for _, m := range metrics {
+		k := filter(m)
+		groups[k] = append(groups[k], m)
+	}
+
+	result := make(map[AggKey]model.Metric, len(groups))
+	for k, ms := range groups {
+		result[k] = Weighted(ms)
+	}
+	return result
+}
+
+// MergeAggregated kombiniert zwei bereits aggregierte Metriken (z.B. aus
+// unterschiedlichen Shards oder Zeitfenstern) korrekt: TVL wird addiert und
+// APY/PointsPerETH werden anhand der TVL-Gewichte neu hergeleitet, statt
+// einen der beiden Werte zu überschreiben. Ohne das würde ein naives
+// Re-Aggregieren von Vorsummen die TVL doppelt zählen oder den APY verzerren.
+func MergeAggregated(a, b model.Metric) model.Metric {
+	totalTVL := a.TVL + b.TVL
+	if totalTVL <= 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	weightedAPY := a.APY*a.TVL + b.APY*b.TVL
+	weightedPoints := a.PointsPerETH*a.TVL + b.PointsPerETH*b.TVL
+
+	latestTimestamp := a.CollectedAt
+	if b.CollectedAt > latestTimestamp {
+		latestTimestamp = b.CollectedAt
+	}
+
+	return model.Metric{
+		APY:          weightedAPY / totalTVL,
+		TVL:          totalTVL,
+		PointsPerETH: weightedPoints / totalTVL,
+		CollectedAt:  latestTimestamp,
+		Provider:     "aggregated",
+	}
+}
+
 // TrimmedMeanAggregation berechnet getrimmte Mittelwerte (ohne extreme Werte)
 // Entfernt einen bestimmten Prozentsatz der höchsten und niedrigsten Werte vor der Mittelwertbildung
 func TrimmedMeanAggregation(metrics []model.Metric, trimPercent float64) model.Metric {
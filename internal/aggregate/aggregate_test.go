@@ -647,6 +647,119 @@ func TestAverageMetrics(t *testing.T) {
     }
 }
 
+func TestGroupedWeighted(t *testing.T) {
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, PointsPerETH: 10},
+        {Provider: "b", APY: 10.0, TVL: 1000, PointsPerETH: 20},
+        {Provider: "c", APY: 20.0, TVL: 500, PointsPerETH: 5},
+    }
+
+    result := GroupedWeighted(metrics, func(m model.Metric) string {
+        if m.TVL >= 1000 {
+            return "large"
+        }
+        return "small"
+    })
+
+    if len(result) != 2 {
+        t.Fatalf("GroupedWeighted() returned %d groups, want 2", len(result))
+    }
+    if large := result["large"]; large.APY != 7.5 {
+        t.Errorf("large group APY = %v, want 7.5", large.APY)
+    }
+    if small := result["small"]; small.APY != 20.0 {
+        t.Errorf("small group APY = %v, want 20.0", small.APY)
+    }
+}
+
+func TestGroupedWeightedByAttribute(t *testing.T) {
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, PointsPerETH: 10},
+        {Provider: "b", APY: 10.0, TVL: 1000, PointsPerETH: 20},
+    }
+
+    result := GroupedWeightedByAttribute(metrics, func(m model.Metric) AggKey {
+        return AggKey(m.Provider)
+    })
+
+    if len(result) != 2 {
+        t.Fatalf("GroupedWeightedByAttribute() returned %d groups, want 2", len(result))
+    }
+    if got := result[AggKey("a")].APY; got != 5.0 {
+        t.Errorf("group a APY = %v, want 5.0", got)
+    }
+}
+
+func TestMergeAggregated(t *testing.T) {
+    a := model.Metric{APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: 100, Provider: "aggregated"}
+    b := model.Metric{APY: 10.0, TVL: 3000, PointsPerETH: 20, CollectedAt: 200, Provider: "aggregated"}
+
+    got := MergeAggregated(a, b)
+
+    if got.TVL != 4000 {
+        t.Errorf("TVL = %v, want 4000 (summed, not overwritten)", got.TVL)
+    }
+    wantAPY := (5.0*1000 + 10.0*3000) / 4000
+    if got.APY != wantAPY {
+        t.Errorf("APY = %v, want %v", got.APY, wantAPY)
+    }
+    if got.CollectedAt != 200 {
+        t.Errorf("CollectedAt = %v, want 200 (latest)", got.CollectedAt)
+    }
+}
+
+func TestMergeAggregatedZeroTVL(t *testing.T) {
+    got := MergeAggregated(model.Metric{}, model.Metric{})
+    if got.TVL != 0 || got.APY != 0 {
+        t.Errorf("MergeAggregated() of zero-TVL metrics = %+v, want zero-valued", got)
+    }
+}
+
+func TestWeightedWithStats(t *testing.T) {
+    now := time.Now().Unix()
+    metrics := []model.Metric{
+        {APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now},
+        {APY: 10.0, TVL: 1000, PointsPerETH: 20, CollectedAt: now},
+        {APY: 15.0, TVL: 1000, PointsPerETH: 30, CollectedAt: now},
+    }
+
+    got := WeightedWithStats(metrics, 0)
+
+    if got.Z != defaultConfidenceZ {
+        t.Errorf("Z = %v, want default %v", got.Z, defaultConfidenceZ)
+    }
+    if got.EffectiveN != 3 {
+        t.Errorf("EffectiveN = %v, want 3 (equal weights)", got.EffectiveN)
+    }
+    if got.APYStdDev <= 0 {
+        t.Errorf("APYStdDev = %v, want > 0 for dispersed inputs", got.APYStdDev)
+    }
+    if got.APYCILow >= got.APY || got.APYCIHigh <= got.APY {
+        t.Errorf("CI [%v, %v] does not straddle mean APY %v", got.APYCILow, got.APYCIHigh, got.APY)
+    }
+}
+
+func TestWeightedWithStatsDominantProvider(t *testing.T) {
+    now := time.Now().Unix()
+    metrics := []model.Metric{
+        {APY: 5.0, TVL: 1_000_000, PointsPerETH: 10, CollectedAt: now},
+        {APY: 50.0, TVL: 100, PointsPerETH: 10, CollectedAt: now},
+    }
+
+    got := WeightedWithStats(metrics, 1.96)
+
+    if got.EffectiveN >= 2 {
+        t.Errorf("EffectiveN = %v, want < 2 when one provider dominates TVL", got.EffectiveN)
+    }
+}
+
+func TestWeightedWithStatsEmpty(t *testing.T) {
+    got := WeightedWithStats(nil, 1.96)
+    if got.EffectiveN != 0 || got.APYStdDev != 0 {
+        t.Errorf("WeightedWithStats(nil) = %+v, want zero-valued stats", got)
+    }
+}
+
 func BenchmarkWeighted(b *testing.B) {
     metrics := make([]model.Metric, 100)
     for i := 0; i < 100; i++ {
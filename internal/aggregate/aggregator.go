@@ -0,0 +1,239 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// Aggregator ist die gemeinsame Schnittstelle jeder Aggregationsstrategie in
+// diesem Paket, damit View Metrikfelder strategieunabhängig durchreichen
+// kann und neue Strategien sich ohne Änderungen an den Aufrufern einhängen
+// lassen.
+type Aggregator interface {
+	// Aggregate rollt metrics zu einer einzelnen model.Metric auf.
+	Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error)
+
+	// Kind gibt den Registry-Namen der Strategie zurück.
+	Kind() string
+}
+
+// WeightedMeanAggregator bündelt WeightedWithValidation als Aggregator.
+type WeightedMeanAggregator struct{}
+
+func (WeightedMeanAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	return WeightedWithValidation(metrics), nil
+}
+
+func (WeightedMeanAggregator) Kind() string { return "weighted_mean" }
+
+// MedianAggregator bündelt MedianAggregation als Aggregator.
+type MedianAggregator struct{}
+
+func (MedianAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	return MedianAggregation(metrics), nil
+}
+
+func (MedianAggregator) Kind() string { return "median" }
+
+// TrimmedMeanAggregator bündelt TrimmedMeanAggregation mit konfigurierbarem
+// Trim-Anteil als Aggregator.
+type TrimmedMeanAggregator struct {
+	TrimPercent float64
+}
+
+func (a TrimmedMeanAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	return TrimmedMeanAggregation(metrics, a.TrimPercent), nil
+}
+
+func (TrimmedMeanAggregator) Kind() string { return "trimmed_mean" }
+
+// HuberAggregator bündelt HuberAggregation mit konfigurierbarer
+// Tuning-Konstante k als Aggregator.
+type HuberAggregator struct {
+	K float64
+}
+
+func (a HuberAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	return huberAggregate(metrics, a.K), nil
+}
+
+func (HuberAggregator) Kind() string { return "huber" }
+
+// QuantileAggregator bündelt QuantileAggregation für eine einzelne
+// angeforderte Quantilstufe als Aggregator.
+type QuantileAggregator struct {
+	Q float64
+}
+
+func (a QuantileAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	q := a.Q
+	if q <= 0 || q >= 1 {
+		q = 0.5
+	}
+	results := QuantileAggregation(metrics, []float64{q})
+	m, ok := results[q]
+	if !ok {
+		return model.Metric{Provider: "aggregated"}, nil
+	}
+	return m, nil
+}
+
+func (QuantileAggregator) Kind() string { return "quantile" }
+
+// HistogramAggregator baut ein frisches Histogram aus metrics und
+// rekonstruiert daraus eine APY-Schätzung über WeightedFromHistogram.
+type HistogramAggregator struct {
+	Scale      int
+	MaxBuckets int
+}
+
+func (a HistogramAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	scale := a.Scale
+	if scale == 0 {
+		scale = defaultHistogramScale
+	}
+	maxBuckets := a.MaxBuckets
+	if maxBuckets <= 0 {
+		maxBuckets = defaultMaxHistogramBuckets
+	}
+
+	h := NewHistogram(scale, maxBuckets)
+	for _, m := range metrics {
+		h.Record(m.APY)
+	}
+	return WeightedFromHistogram(h.Snapshot()), nil
+}
+
+func (HistogramAggregator) Kind() string { return "histogram" }
+
+// AggregatorFactory baut einen Aggregator aus einer deklarativen
+// Konfiguration, z.B. map[string]any{"trim_percent": 0.1}.
+type AggregatorFactory func(cfg map[string]any) Aggregator
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]AggregatorFactory{
+		"weighted_mean": func(cfg map[string]any) Aggregator { return WeightedMeanAggregator{} },
+		"median":        func(cfg map[string]any) Aggregator { return MedianAggregator{} },
+		"trimmed_mean": func(cfg map[string]any) Aggregator {
+			return TrimmedMeanAggregator{TrimPercent: floatConfig(cfg, "trim_percent", 0.1)}
+		},
+		"huber": func(cfg map[string]any) Aggregator {
+			return HuberAggregator{K: floatConfig(cfg, "k", 0)}
+		},
+		"quantile": func(cfg map[string]any) Aggregator {
+			return QuantileAggregator{Q: floatConfig(cfg, "q", 0.5)}
+		},
+		"histogram": func(cfg map[string]any) Aggregator {
+			return HistogramAggregator{
+				Scale:      intConfig(cfg, "scale", 0),
+				MaxBuckets: intConfig(cfg, "max_buckets", 0),
+			}
+		},
+	}
+)
+
+// Register hängt eine benannte Aggregator-Factory in die paketweite Registry
+// ein, sodass sich neue Strategien einklinken lassen, ohne dieses Paket zu
+// ändern.
+func Register(name string, factory AggregatorFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// NewAggregator baut den unter name registrierten Aggregator aus cfg auf,
+// oder gibt einen Fehler zurück, falls name nicht registriert ist. Dies ist
+// der Einhängepunkt, über den das bestehende EA-Request-Payload die
+// gewünschte Strategie auswählt.
+func NewAggregator(name string, cfg map[string]any) (Aggregator, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown aggregator kind: %s", name)
+	}
+	return factory(cfg), nil
+}
+
+func floatConfig(cfg map[string]any, key string, def float64) float64 {
+	if v, ok := cfg[key]; ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+	}
+	return def
+}
+
+func intConfig(cfg map[string]any, key string, def int) int {
+	if v, ok := cfg[key]; ok {
+		switch n := v.(type) {
+		case float64:
+			return int(n)
+		case int:
+			return n
+		}
+	}
+	return def
+}
+
+// View routet jedes Metrikfeld deklarativ durch seinen eigenen Aggregator,
+// z.B. TVL additiv summieren, APY per 10%-getrimmtem Mittelwert und
+// PointsPerETH per Median, statt für jede Kombination eigenen Go-Code zu
+// schreiben.
+type View struct {
+	APY          Aggregator
+	TVL          Aggregator
+	PointsPerETH Aggregator
+}
+
+// Apply führt den konfigurierten Aggregator je Feld über metrics aus und
+// setzt die Ergebnisse zu einer einzelnen model.Metric zusammen. Unbesetzte
+// Felder fallen auf WeightedMeanAggregator zurück, außer TVL: TVL ist über
+// Shards additiv statt gemittelt, daher summiert es standardmäßig.
+func (v View) Apply(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+	result := model.Metric{Provider: "aggregated"}
+
+	apyAgg := v.APY
+	if apyAgg == nil {
+		apyAgg = WeightedMeanAggregator{}
+	}
+	apyResult, err := apyAgg.Aggregate(ctx, metrics)
+	if err != nil {
+		return model.Metric{}, fmt.Errorf("aggregating APY: %w", err)
+	}
+	result.APY = apyResult.APY
+
+	if v.TVL != nil {
+		tvlResult, err := v.TVL.Aggregate(ctx, metrics)
+		if err != nil {
+			return model.Metric{}, fmt.Errorf("aggregating TVL: %w", err)
+		}
+		result.TVL = tvlResult.TVL
+	} else {
+		for _, m := range metrics {
+			result.TVL += m.TVL
+		}
+	}
+
+	pointsAgg := v.PointsPerETH
+	if pointsAgg == nil {
+		pointsAgg = WeightedMeanAggregator{}
+	}
+	pointsResult, err := pointsAgg.Aggregate(ctx, metrics)
+	if err != nil {
+		return model.Metric{}, fmt.Errorf("aggregating PointsPerETH: %w", err)
+	}
+	result.PointsPerETH = pointsResult.PointsPerETH
+
+	for _, m := range metrics {
+		if m.CollectedAt > result.CollectedAt {
+			result.CollectedAt = m.CollectedAt
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package aggregate
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+func TestWeightedMeanAggregatorKind(t *testing.T) {
+    if got := (WeightedMeanAggregator{}).Kind(); got != "weighted_mean" {
+        t.Errorf("Kind() = %v, want weighted_mean", got)
+    }
+}
+
+func TestAggregatorImplementations(t *testing.T) {
+    now := time.Now().Unix()
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now},
+        {Provider: "b", APY: 10.0, TVL: 1000, PointsPerETH: 20, CollectedAt: now},
+        {Provider: "c", APY: 15.0, TVL: 1000, PointsPerETH: 30, CollectedAt: now},
+    }
+    ctx := context.Background()
+
+    aggregators := []Aggregator{
+        WeightedMeanAggregator{},
+        MedianAggregator{},
+        TrimmedMeanAggregator{TrimPercent: 0.1},
+        HuberAggregator{},
+        QuantileAggregator{Q: 0.5},
+        HistogramAggregator{},
+    }
+
+    for _, agg := range aggregators {
+        t.Run(agg.Kind(), func(t *testing.T) {
+            result, err := agg.Aggregate(ctx, metrics)
+            if err != nil {
+                t.Fatalf("Aggregate() error = %v", err)
+            }
+            if result.APY <= 0 {
+                t.Errorf("APY = %v, want > 0", result.APY)
+            }
+        })
+    }
+}
+
+func TestNewAggregatorUnknownKind(t *testing.T) {
+    if _, err := NewAggregator("does-not-exist", nil); err == nil {
+        t.Error("NewAggregator() with unknown kind, want error")
+    }
+}
+
+func TestNewAggregatorTrimmedMeanConfig(t *testing.T) {
+    agg, err := NewAggregator("trimmed_mean", map[string]any{"trim_percent": 0.2})
+    if err != nil {
+        t.Fatalf("NewAggregator() error = %v", err)
+    }
+    trimmed, ok := agg.(TrimmedMeanAggregator)
+    if !ok {
+        t.Fatalf("NewAggregator(trimmed_mean) returned %T, want TrimmedMeanAggregator", agg)
+    }
+    if trimmed.TrimPercent != 0.2 {
+        t.Errorf("TrimPercent = %v, want 0.2", trimmed.TrimPercent)
+    }
+}
+
+func TestRegisterCustomAggregator(t *testing.T) {
+    Register("always_zero", func(cfg map[string]any) Aggregator { return zeroAggregator{} })
+
+    agg, err := NewAggregator("always_zero", nil)
+    if err != nil {
+        t.Fatalf("NewAggregator() error = %v", err)
+    }
+
+    result, err := agg.Aggregate(context.Background(), nil)
+    if err != nil {
+        t.Fatalf("Aggregate() error = %v", err)
+    }
+    if result.Provider != "zero" {
+        t.Errorf("Provider = %v, want zero", result.Provider)
+    }
+}
+
+type zeroAggregator struct{}
+
+func (zeroAggregator) Aggregate(ctx context.Context, metrics []model.Metric) (model.Metric, error) {
+    return model.Metric{Provider: "zero"}, nil
+}
+
+func (zeroAggregator) Kind() string { return "always_zero" }
+
+func TestViewApply(t *testing.T) {
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, PointsPerETH: 10},
+        {Provider: "b", APY: 10.0, TVL: 2000, PointsPerETH: 20},
+    }
+
+    view := View{
+        APY:          MedianAggregator{},
+        PointsPerETH: MedianAggregator{},
+    }
+
+    result, err := view.Apply(context.Background(), metrics)
+    if err != nil {
+        t.Fatalf("Apply() error = %v", err)
+    }
+    if result.TVL != 3000 {
+        t.Errorf("TVL = %v, want 3000 (additive default)", result.TVL)
+    }
+    if result.APY <= 0 {
+        t.Errorf("APY = %v, want > 0", result.APY)
+    }
+}
+
+func TestViewApplyDefaultsToWeightedMean(t *testing.T) {
+    now := time.Now().Unix()
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now},
+        {Provider: "b", APY: 15.0, TVL: 1000, PointsPerETH: 20, CollectedAt: now},
+    }
+
+    result, err := View{}.Apply(context.Background(), metrics)
+    if err != nil {
+        t.Fatalf("Apply() error = %v", err)
+    }
+    if result.APY != 10.0 {
+        t.Errorf("APY = %v, want 10.0 (weighted mean default)", result.APY)
+    }
+}
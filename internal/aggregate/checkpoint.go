@@ -0,0 +1,131 @@
+package aggregate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// checkpointRotations bounds how many rotated checkpoint files are kept
+// alongside the active one, mirroring the WAL-segment rotation used by
+// long-running metric storage systems' checkpoint loops - if the latest
+// write is corrupt or incomplete, Load falls back to the previous one.
+const checkpointRotations = 3
+
+// Checkpoint is a periodically-persisted snapshot of the last successfully
+// aggregated metric, used by the fallback pipeline's "stale" tier when no
+// provider responds and the in-memory last-known-good is also gone, e.g.
+// right after a restart.
+type Checkpoint struct {
+	Metric      model.Metric `json:"metric"`
+	CollectedAt time.Time    `json:"collectedAt"`
+}
+
+// CheckpointStore persists Checkpoints to a JSON file on disk, rotating the
+// existing file out before each write so a crash mid-write can't destroy
+// both the active and the most recent snapshot at once.
+type CheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewCheckpointStore creates a store backed by path.
+func NewCheckpointStore(path string) *CheckpointStore {
+	return &CheckpointStore{path: path}
+}
+
+// Save atomically writes metric as the current checkpoint, rotating any
+// existing checkpoint files up to checkpointRotations deep first.
+func (c *CheckpointStore) Save(metric model.Metric) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if dir := filepath.Dir(c.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating checkpoint directory: %w", err)
+		}
+	}
+
+	c.rotate()
+
+	data, err := json.Marshal(Checkpoint{Metric: metric, CollectedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("marshaling checkpoint: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// rotate shifts existing checkpoint.N files up by one, dropping the oldest.
+func (c *CheckpointStore) rotate() {
+	for n := checkpointRotations; n > 0; n-- {
+		from := c.rotatedPath(n - 1)
+		to := c.rotatedPath(n)
+		if _, err := os.Stat(from); err == nil {
+			_ = os.Rename(from, to)
+		}
+	}
+}
+
+func (c *CheckpointStore) rotatedPath(n int) string {
+	if n == 0 {
+		return c.path
+	}
+	return fmt.Sprintf("%s.%d", c.path, n)
+}
+
+// Load reads the most recent valid checkpoint, falling back to older
+// rotated files if the latest one is missing or corrupt.
+func (c *CheckpointStore) Load() (Checkpoint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for n := 0; n <= checkpointRotations; n++ {
+		data, err := os.ReadFile(c.rotatedPath(n))
+		if err != nil {
+			continue
+		}
+		var cp Checkpoint
+		if err := json.Unmarshal(data, &cp); err != nil {
+			continue
+		}
+		return cp, true
+	}
+	return Checkpoint{}, false
+}
+
+// StartPeriodicSave calls snapshot every interval and persists its result
+// until ctx is canceled, mirroring the periodic checkpoint loop used by
+// long-running metric storage systems. A false second return from snapshot
+// skips that tick rather than overwriting the checkpoint with nothing.
+func (c *CheckpointStore) StartPeriodicSave(ctx context.Context, interval time.Duration, snapshot func() (model.Metric, bool)) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				metric, ok := snapshot()
+				if !ok {
+					continue
+				}
+				if err := c.Save(metric); err != nil {
+					logging.Warnf("Failed to save aggregate checkpoint: %v", err)
+				}
+			}
+		}
+	}()
+}
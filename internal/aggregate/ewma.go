@@ -0,0 +1,143 @@
+package aggregate
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// EWMAAggregation kombiniert TVL-Gewichtung mit exponentiellem Zeitverfall:
+// das effektive Gewicht von Provider i ist TVL_i * exp(-ln(2) * age_i /
+// halfLife), wobei age_i = now - CollectedAt_i (auf >= 0 begrenzt). Eine
+// veraltete Meldung eines langsamen Providers zählt so weniger als eine
+// frische, statt wie bei Weighted gleich viel. halfLife <= 0 deaktiviert den
+// Zeitverfall (jedes Gewicht bleibt TVL_i, identisch zu Weighted).
+func EWMAAggregation(metrics []model.Metric, halfLife time.Duration, now time.Time) model.Metric {
+	if len(metrics) == 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	var totalWeight, weightedAPY, weightedPoints float64
+	validMetrics := 0
+	latestTimestamp := int64(0)
+
+	for _, m := range metrics {
+		if m.TVL <= 0 || m.APY < 0 || m.PointsPerETH < 0 {
+			continue
+		}
+
+		weight := m.TVL * ewmaDecay(m.CollectedAt, halfLife, now)
+		totalWeight += weight
+		weightedAPY += m.APY * weight
+		weightedPoints += m.PointsPerETH * weight
+		validMetrics++
+
+		if m.CollectedAt > latestTimestamp {
+			latestTimestamp = m.CollectedAt
+		}
+	}
+
+	if validMetrics == 0 || totalWeight <= 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	return model.Metric{
+		APY:          weightedAPY / totalWeight,
+		TVL:          totalWeight,
+		PointsPerETH: weightedPoints / totalWeight,
+		CollectedAt:  latestTimestamp,
+		Provider:     "aggregated",
+	}
+}
+
+// ewmaDecay returns exp(-ln(2) * age / halfLife) for a sample collected at
+// collectedAt, clamping age to >= 0 so a clock-skewed future timestamp can't
+// produce a weight above 1. halfLife <= 0 disables decay (returns 1).
+func ewmaDecay(collectedAt int64, halfLife time.Duration, now time.Time) float64 {
+	if halfLife <= 0 {
+		return 1
+	}
+
+	age := now.Sub(time.Unix(collectedAt, 0))
+	if age < 0 {
+		age = 0
+	}
+
+	return math.Exp(-math.Ln2 * age.Seconds() / halfLife.Seconds())
+}
+
+// WeightedParallelEWMA ist die ctx-fähige parallele Variante von
+// EWMAAggregation, analog zu WeightedParallel.
+func WeightedParallelEWMA(ctx context.Context, metrics []model.Metric, halfLife time.Duration, now time.Time) model.Metric {
+	if len(metrics) == 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	var (
+		mu              sync.Mutex
+		wg              sync.WaitGroup
+		totalWeight     float64
+		weightedAPY     float64
+		weightedPoints  float64
+		validMetrics    int
+		latestTimestamp int64
+	)
+
+	for i := range metrics {
+		wg.Add(1)
+		go func(m model.Metric) {
+			defer wg.Done()
+
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if m.TVL <= 0 || m.APY < 0 || m.PointsPerETH < 0 {
+					return
+				}
+				weight := m.TVL * ewmaDecay(m.CollectedAt, halfLife, now)
+
+				mu.Lock()
+				totalWeight += weight
+				weightedAPY += m.APY * weight
+				weightedPoints += m.PointsPerETH * weight
+				validMetrics++
+				if m.CollectedAt > latestTimestamp {
+					latestTimestamp = m.CollectedAt
+				}
+				mu.Unlock()
+			}
+		}(metrics[i])
+	}
+
+	wg.Wait()
+
+	if validMetrics == 0 || totalWeight <= 0 || math.IsNaN(weightedAPY) || math.IsNaN(weightedPoints) {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	return model.Metric{
+		APY:          weightedAPY / totalWeight,
+		TVL:          totalWeight,
+		PointsPerETH: weightedPoints / totalWeight,
+		CollectedAt:  latestTimestamp,
+		Provider:     "aggregated",
+	}
+}
+
+// EWMAWithValidation kombiniert Validierung, Ausreißererkennung und
+// zeitgewichtete Aggregation, analog zu WeightedWithValidation.
+func EWMAWithValidation(metrics []model.Metric, halfLife time.Duration, now time.Time) model.Metric {
+	validMetrics := ValidateAndFilterMetrics(metrics)
+	return EWMAAggregation(validMetrics, halfLife, now)
+}
+
+// WeightedParallelEWMAWithValidation ist die ctx-fähige, validierte Variante
+// von EWMAWithValidation, analog zu WeightedParallelWithValidation.
+func WeightedParallelEWMAWithValidation(ctx context.Context, metrics []model.Metric, halfLife time.Duration, now time.Time) model.Metric {
+	validMetrics := ValidateAndFilterMetrics(metrics)
+	return WeightedParallelEWMA(ctx, validMetrics, halfLife, now)
+}
@@ -0,0 +1,79 @@
+package aggregate
+
+import (
+    "context"
+    "math"
+    "testing"
+    "time"
+
+    "github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+func TestEWMAAggregationFreshDominatesStale(t *testing.T) {
+    now := time.Now()
+
+    metrics := []model.Metric{
+        {APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now.Add(-24 * time.Hour).Unix()},
+        {APY: 10.0, TVL: 1000, PointsPerETH: 20, CollectedAt: now.Unix()},
+    }
+
+    got := EWMAAggregation(metrics, time.Hour, now)
+
+    if math.Abs(got.APY-10.0) > 0.1 {
+        t.Errorf("EWMAAggregation() APY = %v, want close to 10.0 since the 24h-old metric's weight is decayed to near zero by a 1h half-life", got.APY)
+    }
+}
+
+func TestEWMAAggregationZeroHalfLifeMatchesWeighted(t *testing.T) {
+    now := time.Now()
+
+    metrics := []model.Metric{
+        {APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now.Add(-24 * time.Hour).Unix()},
+        {APY: 10.0, TVL: 2000, PointsPerETH: 20, CollectedAt: now.Unix()},
+    }
+
+    got := EWMAAggregation(metrics, 0, now)
+    want := Weighted(metrics)
+
+    if math.Abs(got.APY-want.APY) > 1e-9 {
+        t.Errorf("EWMAAggregation() with halfLife=0 APY = %v, want %v (same as Weighted)", got.APY, want.APY)
+    }
+}
+
+func TestEWMAAggregationEmpty(t *testing.T) {
+    got := EWMAAggregation(nil, time.Hour, time.Now())
+    if got.Provider != "aggregated" || got.TVL != 0 {
+        t.Errorf("EWMAAggregation(nil) = %+v, want zero-valued aggregated metric", got)
+    }
+}
+
+func TestWeightedParallelEWMAMatchesSerial(t *testing.T) {
+    now := time.Now()
+
+    metrics := []model.Metric{
+        {APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now.Add(-24 * time.Hour).Unix()},
+        {APY: 10.0, TVL: 1000, PointsPerETH: 20, CollectedAt: now.Unix()},
+    }
+
+    serial := EWMAAggregation(metrics, time.Hour, now)
+    parallel := WeightedParallelEWMA(context.Background(), metrics, time.Hour, now)
+
+    if math.Abs(serial.APY-parallel.APY) > 1e-9 {
+        t.Errorf("WeightedParallelEWMA() APY = %v, want %v (same as EWMAAggregation)", parallel.APY, serial.APY)
+    }
+}
+
+func TestEWMAWithValidationFiltersInvalid(t *testing.T) {
+    now := time.Now()
+
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now.Unix()},
+        {Provider: "b", APY: -1.0, TVL: 1000, PointsPerETH: 10, CollectedAt: now.Unix()}, // invalid: negative APY
+    }
+
+    got := EWMAWithValidation(metrics, time.Hour, now)
+
+    if math.Abs(got.APY-5.0) > 1e-9 {
+        t.Errorf("EWMAWithValidation() APY = %v, want 5.0 with the invalid metric filtered out", got.APY)
+    }
+}
@@ -0,0 +1,37 @@
+package aggregate
+
+// Freshness describes how far a response's underlying metrics are from a
+// direct, all-providers-responding fetch. It is ordered best-to-worst so
+// Server.handleRequest can reject anything at or below a configured floor.
+type Freshness string
+
+const (
+	// FreshnessLive means every configured provider answered this request.
+	FreshnessLive Freshness = "live"
+	// FreshnessPartial means at least one provider answered but one or more
+	// were substituted from the per-provider last-known-good cache.
+	FreshnessPartial Freshness = "partial"
+	// FreshnessCached means no provider answered and the response was
+	// rebuilt entirely from the in-memory last-known-good history.
+	FreshnessCached Freshness = "cached"
+	// FreshnessStale means even the in-memory history was unavailable and
+	// the response came from the on-disk Checkpoint.
+	FreshnessStale Freshness = "stale"
+)
+
+// Tier maps a Freshness to its position in the fallback pipeline, 1 (best)
+// through 4 (worst), for metrics labeling and MaxStaleness comparisons.
+func (f Freshness) Tier() int {
+	switch f {
+	case FreshnessLive:
+		return 1
+	case FreshnessPartial:
+		return 2
+	case FreshnessCached:
+		return 3
+	case FreshnessStale:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,433 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// defaultMaxHistogramBuckets bounds how many non-empty buckets a Histogram
+// keeps before downscaling, so long-running EAs have a fixed memory ceiling.
+const defaultMaxHistogramBuckets = 160
+
+// defaultHistogramScale ist die Start-Skala neuer Histogramme (höher =
+// feinere Auflösung); Histogram.downscale verringert sie bei Bedarf.
+const defaultHistogramScale = 11
+
+// Histogram ist ein OpenTelemetry-artiges Exponential-Bucket-Histogramm mit
+// Basis 2^(2^-scale). Positive und negative Werte werden in getrennten
+// Bucket-Maps verfolgt, Nullwerte im zeroCount. Überschreitet die
+// Bucket-Anzahl maxBuckets, werden benachbarte Bucket-Paare zusammengeführt
+// und scale um eins verringert (Downscaling), statt unbegrenzt zu wachsen.
+type Histogram struct {
+	mu sync.Mutex
+
+	scale      int
+	maxBuckets int
+
+	zeroCount int64
+	positive  map[int]int64
+	negative  map[int]int64
+
+	sum, min, max float64
+	count         int64
+}
+
+// HistogramData ist eine unveränderliche Momentaufnahme eines Histogram.
+type HistogramData struct {
+	Scale     int
+	ZeroCount int64
+	Positive  map[int]int64
+	Negative  map[int]int64
+	Sum       float64
+	Min       float64
+	Max       float64
+	Count     int64
+}
+
+// NewHistogram erstellt ein leeres Histogram. maxBuckets <= 0 verwendet
+// defaultMaxHistogramBuckets.
+func NewHistogram(scale, maxBuckets int) *Histogram {
+	if maxBuckets <= 0 {
+		maxBuckets = defaultMaxHistogramBuckets
+	}
+	return &Histogram{
+		scale:      scale,
+		maxBuckets: maxBuckets,
+		positive:   make(map[int]int64),
+		negative:   make(map[int]int64),
+		min:        math.Inf(1),
+		max:        math.Inf(-1),
+	}
+}
+
+// Record fügt einen einzelnen Beobachtungswert in das Histogramm ein.
+func (h *Histogram) Record(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.count++
+	h.sum += value
+	if value < h.min {
+		h.min = value
+	}
+	if value > h.max {
+		h.max = value
+	}
+
+	if value == 0 {
+		h.zeroCount++
+		return
+	}
+
+	buckets := h.positive
+	abs := value
+	if value < 0 {
+		buckets = h.negative
+		abs = -value
+	}
+
+	buckets[h.bucketIndex(abs)]++
+
+	for len(h.positive)+len(h.negative) > h.maxBuckets {
+		h.downscale()
+	}
+}
+
+// bucketIndex berechnet den Bucket-Index für einen positiven Betrag bei der
+// aktuellen Skala: der Bucket mit Index i deckt (base^(i-1), base^i] ab,
+// mit base = 2^(2^-scale).
+func (h *Histogram) bucketIndex(abs float64) int {
+	scaleFactor := math.Ldexp(math.Log2E, h.scale)
+	return int(math.Ceil(math.Log(abs) * scaleFactor))
+}
+
+// downscale halbiert die Auflösung durch Zusammenführen benachbarter
+// Bucket-Paare (index -> index/2 abgerundet) und verringert scale um eins.
+func (h *Histogram) downscale() {
+	h.scale--
+	h.positive = collapseBuckets(h.positive)
+	h.negative = collapseBuckets(h.negative)
+}
+
+func collapseBuckets(buckets map[int]int64) map[int]int64 {
+	collapsed := make(map[int]int64, len(buckets))
+	for idx, count := range buckets {
+		newIdx := idx / 2
+		if idx < 0 && idx%2 != 0 {
+			newIdx--
+		}
+		collapsed[newIdx] += count
+	}
+	return collapsed
+}
+
+// Snapshot gibt eine Kopie des aktuellen Histogram-Zustands zurück.
+func (h *Histogram) Snapshot() HistogramData {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	positive := make(map[int]int64, len(h.positive))
+	for k, v := range h.positive {
+		positive[k] = v
+	}
+	negative := make(map[int]int64, len(h.negative))
+	for k, v := range h.negative {
+		negative[k] = v
+	}
+
+	return HistogramData{
+		Scale:     h.scale,
+		ZeroCount: h.zeroCount,
+		Positive:  positive,
+		Negative:  negative,
+		Sum:       h.sum,
+		Min:       h.min,
+		Max:       h.max,
+		Count:     h.count,
+	}
+}
+
+// MetricHistograms bündelt je ein Histogram für APY, TVL und PointsPerETH,
+// damit Perzentil-Abfragen, Drift-Erkennung und Reporting jenseits des
+// skalaren Weighted-Outputs möglich werden.
+type MetricHistograms struct {
+	APY          *Histogram
+	TVL          *Histogram
+	PointsPerETH *Histogram
+}
+
+// NewMetricHistograms erstellt ein MetricHistograms-Bündel mit der
+// angegebenen Start-Skala und Bucket-Obergrenze je Feld.
+func NewMetricHistograms(scale, maxBuckets int) *MetricHistograms {
+	return &MetricHistograms{
+		APY:          NewHistogram(scale, maxBuckets),
+		TVL:          NewHistogram(scale, maxBuckets),
+		PointsPerETH: NewHistogram(scale, maxBuckets),
+	}
+}
+
+// RecordMetric trägt eine einzelne Metrik in alle drei Histogramme ein.
+func (m *MetricHistograms) RecordMetric(metric model.Metric) {
+	m.APY.Record(metric.APY)
+	m.TVL.Record(metric.TVL)
+	m.PointsPerETH.Record(metric.PointsPerETH)
+}
+
+// bucketMidpoint gibt den geometrischen Mittelpunkt des Buckets mit dem
+// angegebenen Index bei der angegebenen Skala zurück: Bucket i deckt
+// (base^(i-1), base^i] ab, der Mittelpunkt wird als base^(i-0.5) angenähert.
+func bucketMidpoint(index, scale int) float64 {
+	base := math.Pow(2, math.Pow(2, -float64(scale)))
+	return math.Pow(base, float64(index)-0.5)
+}
+
+// WeightedFromHistogram rekonstruiert eine APY-Schätzung aus den
+// Bucket-Mittelpunkten eines Histogramms, gewichtet mit der Beobachtungszahl
+// je Bucket. Das ist eine Approximation: Die Granularität ist durch scale
+// beschränkt, aber es liefert eine gute Schätzung, ohne die Rohwerte
+// vorzuhalten.
+func WeightedFromHistogram(h HistogramData) model.Metric {
+	if h.Count == 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	var weightedSum float64
+	var totalWeight int64
+
+	for idx, count := range h.Positive {
+		weightedSum += bucketMidpoint(idx, h.Scale) * float64(count)
+		totalWeight += count
+	}
+	for idx, count := range h.Negative {
+		weightedSum += -bucketMidpoint(idx, h.Scale) * float64(count)
+		totalWeight += count
+	}
+	totalWeight += h.ZeroCount
+
+	if totalWeight == 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	return model.Metric{
+		APY:      weightedSum / float64(totalWeight),
+		Provider: "aggregated",
+	}
+}
+
+// bucketRange returns the (lower, upper] value range a positive-magnitude
+// bucket index covers at the given scale, using the same base = 2^(2^-scale)
+// as Histogram.bucketIndex.
+func bucketRange(index, scale int) (lower, upper float64) {
+	base := math.Pow(2, math.Pow(2, -float64(scale)))
+	return math.Pow(base, float64(index-1)), math.Pow(base, float64(index))
+}
+
+// Quantile estimates the q-th quantile (0..1) of the distribution recorded
+// in h: it walks buckets in value order (most negative to most positive),
+// locates the one containing cumulative rank q*Count, and linearly
+// interpolates within that bucket's value range - the same approximation
+// Prometheus's histogram_quantile() uses for fixed-bucket histograms.
+// Returns 0 if h has no observations.
+func (h HistogramData) Quantile(q float64) float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return h.Min
+	}
+	if q >= 1 {
+		return h.Max
+	}
+
+	type bucket struct {
+		lower, upper float64
+		count        int64
+	}
+	buckets := make([]bucket, 0, len(h.Positive)+len(h.Negative)+1)
+
+	negIdx := make([]int, 0, len(h.Negative))
+	for idx := range h.Negative {
+		negIdx = append(negIdx, idx)
+	}
+	// Higher negative-bucket index means larger magnitude, i.e. a more
+	// negative value, so walk those buckets from highest index down.
+	sort.Sort(sort.Reverse(sort.IntSlice(negIdx)))
+	for _, idx := range negIdx {
+		lower, upper := bucketRange(idx, h.Scale)
+		buckets = append(buckets, bucket{lower: -upper, upper: -lower, count: h.Negative[idx]})
+	}
+
+	if h.ZeroCount > 0 {
+		buckets = append(buckets, bucket{lower: 0, upper: 0, count: h.ZeroCount})
+	}
+
+	posIdx := make([]int, 0, len(h.Positive))
+	for idx := range h.Positive {
+		posIdx = append(posIdx, idx)
+	}
+	sort.Ints(posIdx)
+	for _, idx := range posIdx {
+		lower, upper := bucketRange(idx, h.Scale)
+		buckets = append(buckets, bucket{lower: lower, upper: upper, count: h.Positive[idx]})
+	}
+
+	target := q * float64(h.Count)
+	var cumulative int64
+	for _, b := range buckets {
+		next := cumulative + b.count
+		if float64(next) >= target {
+			if b.count == 0 {
+				return b.lower
+			}
+			fraction := (target - float64(cumulative)) / float64(b.count)
+			return b.lower + fraction*(b.upper-b.lower)
+		}
+		cumulative = next
+	}
+	return h.Max
+}
+
+// defaultQuantileScale gives Histogram an exponential base of 2^(1/8)
+// (base = 2^(2^-scale)), HistogramQuantileAggregation's default bucket resolution.
+const defaultQuantileScale = 3
+
+// quantileLabel renders q (0..1) as the "pNN" suffix HistogramQuantileAggregation
+// uses for its Quantiles map keys, e.g. 0.9 -> "p90", 0.99 -> "p99".
+func quantileLabel(q float64) string {
+	return fmt.Sprintf("p%d", int(q*100))
+}
+
+// HistogramQuantileAggregation buckets metrics' APY and PointsPerETH into sparse
+// exponential histograms (base 2^(1/8) by default, via defaultQuantileScale)
+// and reports the requested quantiles of each field in the result's
+// Quantiles map, keyed "apy_pNN"/"points_per_eth_pNN". This answers
+// distributional questions ("what APY is at least 90% of providers
+// reporting?") that Weighted's single TVL-weighted mean can't. The
+// returned Metric's own APY/PointsPerETH fields are each field's median,
+// for callers that just want a single robust point estimate alongside the
+// full distribution.
+func HistogramQuantileAggregation(metrics []model.Metric, qs []float64) model.Metric {
+	if len(metrics) == 0 {
+		return model.Metric{
+			APY:          0,
+			TVL:          0,
+			PointsPerETH: 0,
+			CollectedAt:  0,
+			Provider:     "aggregated",
+		}
+	}
+
+	apyHist := NewHistogram(defaultQuantileScale, defaultMaxHistogramBuckets)
+	pointsHist := NewHistogram(defaultQuantileScale, defaultMaxHistogramBuckets)
+
+	latestTimestamp := int64(0)
+	for _, m := range metrics {
+		apyHist.Record(m.APY)
+		pointsHist.Record(m.PointsPerETH)
+		if m.CollectedAt > latestTimestamp {
+			latestTimestamp = m.CollectedAt
+		}
+	}
+
+	return quantileResult(apyHist.Snapshot(), pointsHist.Snapshot(), qs, latestTimestamp)
+}
+
+// HistogramQuantileAggregationParallel is HistogramQuantileAggregation with metrics recorded
+// into the histograms concurrently, mirroring WeightedParallel's use of
+// goroutines for large collections. Histogram.Record is already internally
+// synchronized, so this only needs to fan out the recording loop itself.
+func HistogramQuantileAggregationParallel(ctx context.Context, metrics []model.Metric, qs []float64) model.Metric {
+	if len(metrics) == 0 {
+		return model.Metric{
+			APY:          0,
+			TVL:          0,
+			PointsPerETH: 0,
+			CollectedAt:  0,
+			Provider:     "aggregated",
+		}
+	}
+
+	apyHist := NewHistogram(defaultQuantileScale, defaultMaxHistogramBuckets)
+	pointsHist := NewHistogram(defaultQuantileScale, defaultMaxHistogramBuckets)
+
+	var (
+		mu              sync.Mutex
+		wg              sync.WaitGroup
+		latestTimestamp int64
+	)
+
+	for i := range metrics {
+		wg.Add(1)
+		go func(m model.Metric) {
+			defer wg.Done()
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				apyHist.Record(m.APY)
+				pointsHist.Record(m.PointsPerETH)
+				mu.Lock()
+				if m.CollectedAt > latestTimestamp {
+					latestTimestamp = m.CollectedAt
+				}
+				mu.Unlock()
+			}
+		}(metrics[i])
+	}
+	wg.Wait()
+
+	return quantileResult(apyHist.Snapshot(), pointsHist.Snapshot(), qs, latestTimestamp)
+}
+
+// HistogramQuantileAggregationWithValidation validates and outlier-filters metrics
+// before handing them to HistogramQuantileAggregation, mirroring WeightedWithValidation.
+func HistogramQuantileAggregationWithValidation(metrics []model.Metric, qs []float64) model.Metric {
+	return HistogramQuantileAggregation(ValidateAndFilterMetrics(metrics), qs)
+}
+
+// HistogramQuantileAggregationParallelWithValidation is the parallel counterpart of
+// HistogramQuantileAggregationWithValidation, mirroring WeightedParallelWithValidation.
+func HistogramQuantileAggregationParallelWithValidation(ctx context.Context, metrics []model.Metric, qs []float64) model.Metric {
+	return HistogramQuantileAggregationParallel(ctx, ValidateAndFilterMetrics(metrics), qs)
+}
+
+// quantileResult builds HistogramQuantileAggregation's result Metric from the
+// already-populated APY/PointsPerETH histogram snapshots, shared by the
+// serial and parallel variants.
+func quantileResult(apyData, pointsData HistogramData, qs []float64, latestTimestamp int64) model.Metric {
+	quantiles := make(map[string]float64, len(qs)*2)
+	for _, q := range qs {
+		label := quantileLabel(q)
+		quantiles["apy_"+label] = apyData.Quantile(q)
+		quantiles["points_per_eth_"+label] = pointsData.Quantile(q)
+	}
+
+	return model.Metric{
+		APY:          apyData.Quantile(0.5),
+		PointsPerETH: pointsData.Quantile(0.5),
+		CollectedAt:  latestTimestamp,
+		Provider:     "aggregated",
+		Quantiles:    quantiles,
+	}
+}
+
+// WeightedWithValidationAndHistogram kombiniert WeightedWithValidation mit
+// einem optionalen APY-Histogramm als Nebenausgabe, sodass Aufrufer
+// Perzentile oder Drift über die gleiche validierte, ausreißerbereinigte
+// Eingabemenge berechnen können, ohne die Rohmetriken zweimal zu filtern.
+func WeightedWithValidationAndHistogram(metrics []model.Metric) (model.Metric, HistogramData) {
+	validMetrics := ValidateAndFilterMetrics(metrics)
+	result := Weighted(validMetrics)
+
+	hist := NewHistogram(defaultHistogramScale, defaultMaxHistogramBuckets)
+	for _, m := range validMetrics {
+		hist.Record(m.APY)
+	}
+
+	return result, hist.Snapshot()
+}
@@ -0,0 +1,122 @@
+package aggregate
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+func TestHistogramRecordAndSnapshot(t *testing.T) {
+    h := NewHistogram(defaultHistogramScale, defaultMaxHistogramBuckets)
+
+    for _, v := range []float64{1, 2, 4, 8, -1, -2, 0} {
+        h.Record(v)
+    }
+
+    snap := h.Snapshot()
+    if snap.Count != 7 {
+        t.Fatalf("Count = %d, want 7", snap.Count)
+    }
+    if snap.ZeroCount != 1 {
+        t.Errorf("ZeroCount = %d, want 1", snap.ZeroCount)
+    }
+    if snap.Sum != 1+2+4+8-1-2 {
+        t.Errorf("Sum = %v, want %v", snap.Sum, 1+2+4+8-1-2)
+    }
+    if snap.Max != 8 {
+        t.Errorf("Max = %v, want 8", snap.Max)
+    }
+    if snap.Min != -2 {
+        t.Errorf("Min = %v, want -2", snap.Min)
+    }
+    if len(snap.Positive) == 0 {
+        t.Error("Positive buckets = 0, want at least one populated bucket")
+    }
+    if len(snap.Negative) == 0 {
+        t.Error("Negative buckets = 0, want at least one populated bucket")
+    }
+}
+
+func TestHistogramDownscale(t *testing.T) {
+    h := NewHistogram(11, 4)
+
+    for i := 1; i <= 20; i++ {
+        h.Record(float64(i))
+    }
+
+    snap := h.Snapshot()
+    if len(snap.Positive) > 4 {
+        t.Errorf("Positive buckets = %d, want <= maxBuckets (4)", len(snap.Positive))
+    }
+    if snap.Scale >= 11 {
+        t.Errorf("Scale = %d, want < initial scale (11) after downscaling", snap.Scale)
+    }
+    if snap.Count != 20 {
+        t.Errorf("Count = %d, want 20", snap.Count)
+    }
+}
+
+func TestWeightedFromHistogramEmpty(t *testing.T) {
+    h := NewHistogram(defaultHistogramScale, defaultMaxHistogramBuckets)
+    got := WeightedFromHistogram(h.Snapshot())
+    if got.APY != 0 {
+        t.Errorf("APY = %v, want 0 for empty histogram", got.APY)
+    }
+}
+
+func TestWeightedFromHistogramApproximatesMean(t *testing.T) {
+    h := NewHistogram(defaultHistogramScale, defaultMaxHistogramBuckets)
+    values := []float64{5, 10, 15, 10, 5}
+    for _, v := range values {
+        h.Record(v)
+    }
+
+    got := WeightedFromHistogram(h.Snapshot())
+
+    var want float64
+    for _, v := range values {
+        want += v
+    }
+    want /= float64(len(values))
+
+    if math.Abs(got.APY-want) > want*0.1 {
+        t.Errorf("APY = %v, want within 10%% of mean %v", got.APY, want)
+    }
+}
+
+func TestMetricHistogramsRecordMetric(t *testing.T) {
+    mh := NewMetricHistograms(defaultHistogramScale, defaultMaxHistogramBuckets)
+
+    mh.RecordMetric(model.Metric{APY: 5.0, TVL: 1000, PointsPerETH: 2.0})
+    mh.RecordMetric(model.Metric{APY: 7.0, TVL: 2000, PointsPerETH: 3.0})
+
+    if mh.APY.Snapshot().Count != 2 {
+        t.Errorf("APY.Count = %d, want 2", mh.APY.Snapshot().Count)
+    }
+    if mh.TVL.Snapshot().Count != 2 {
+        t.Errorf("TVL.Count = %d, want 2", mh.TVL.Snapshot().Count)
+    }
+    if mh.PointsPerETH.Snapshot().Count != 2 {
+        t.Errorf("PointsPerETH.Count = %d, want 2", mh.PointsPerETH.Snapshot().Count)
+    }
+}
+
+func TestWeightedWithValidationAndHistogram(t *testing.T) {
+    now := time.Now().Unix()
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, TVL: 1000, CollectedAt: now},
+        {Provider: "b", APY: 10.0, TVL: 1000, CollectedAt: now},
+        {Provider: "c", APY: 15.0, TVL: 1000, CollectedAt: now},
+    }
+
+    result, hist := WeightedWithValidationAndHistogram(metrics)
+
+    if hist.Count != int64(len(metrics)) {
+        t.Errorf("hist.Count = %d, want %d", hist.Count, len(metrics))
+    }
+    if result.APY <= 0 {
+        t.Errorf("result.APY = %v, want > 0", result.APY)
+    }
+}
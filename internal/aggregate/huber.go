@@ -0,0 +1,158 @@
+package aggregate
+
+import (
+	"math"
+	"sort"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// defaultHuberK ist Huber's klassische Tuning-Konstante für 95% Effizienz
+// relativ zum Mittelwert bei normalverteilten Daten.
+const defaultHuberK = 1.345
+
+const (
+	huberMaxIterations  = 20
+	huberConvergenceEps = 1e-6
+)
+
+// HuberMean berechnet einen robusten gewichteten Lageschätzer mittels
+// iterativ neu gewichteter Huber-M-Schätzung: ausgehend vom gewichteten
+// Median werden je Iteration Residuen r_i = (x_i - μ) / (MAD * 1.4826)
+// gebildet, Huber-Gewichte w'_i = w_i * min(1, k/|r_i|) angewendet und
+// μ = Σw'_i x_i / Σw'_i neu berechnet, bis |Δμ| < 1e-6 oder nach 20
+// Iterationen. Im Gegensatz zu FilterOutliers' hartem IQR-Cutoff werden
+// Ausreißer so sanft herabgewichtet statt verworfen, was bei nur 3-5
+// meldenden Providern wichtig ist, da IQR dort zu viel verwirft. k <= 0
+// verwendet defaultHuberK.
+func HuberMean(values, weights []float64, k float64) float64 {
+	if k <= 0 {
+		k = defaultHuberK
+	}
+	if len(values) == 0 {
+		return 0
+	}
+
+	mu := weightedMedian(values, weights)
+
+	for iter := 0; iter < huberMaxIterations; iter++ {
+		mad := medianAbsDeviation(values, mu)
+		scale := mad * 1.4826
+		if scale == 0 {
+			break
+		}
+
+		var weightedSum, totalWeight float64
+		for i, v := range values {
+			w := weights[i]
+			if r := math.Abs((v - mu) / scale); r > 0 {
+				w *= math.Min(1, k/r)
+			}
+			weightedSum += w * v
+			totalWeight += w
+		}
+		if totalWeight <= 0 {
+			break
+		}
+
+		next := weightedSum / totalWeight
+		converged := math.Abs(next-mu) < huberConvergenceEps
+		mu = next
+		if converged {
+			break
+		}
+	}
+
+	return mu
+}
+
+// weightedMedian berechnet den gewichteten Median als Startschätzer für
+// HuberMean: robuster als der gewichtete Mittelwert gegenüber den
+// Ausreißern, die der Huber-Schätzer erst noch herabgewichten soll.
+func weightedMedian(values, weights []float64) float64 {
+	type pair struct {
+		value  float64
+		weight float64
+	}
+	pairs := make([]pair, len(values))
+	var totalWeight float64
+	for i := range values {
+		pairs[i] = pair{values[i], weights[i]}
+		totalWeight += weights[i]
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].value < pairs[j].value })
+
+	var cumulative float64
+	for _, p := range pairs {
+		cumulative += p.weight
+		if cumulative >= totalWeight/2 {
+			return p.value
+		}
+	}
+	return pairs[len(pairs)-1].value
+}
+
+// medianAbsDeviation berechnet die mediane absolute Abweichung der Werte von
+// center, die robuste Skalenschätzung für HuberMean.
+func medianAbsDeviation(values []float64, center float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - center)
+	}
+	sort.Float64s(deviations)
+	n := len(deviations)
+	if n%2 == 0 {
+		return (deviations[n/2-1] + deviations[n/2]) / 2
+	}
+	return deviations[n/2]
+}
+
+// HuberAggregation aggregiert metrics robust: APY und PointsPerETH werden
+// über HuberMean mit dem Standard-k geschätzt, TVL wird additiv summiert wie
+// bei Weighted. Dies ist die empfohlene Alternative zu
+// WeightedWithValidation, wenn nur wenige Provider melden und FilterOutliers'
+// IQR-Cutoff zu aggressiv wäre.
+func HuberAggregation(metrics []model.Metric) model.Metric {
+	return huberAggregate(metrics, 0)
+}
+
+// huberAggregate ist die gemeinsame Implementierung hinter HuberAggregation
+// und HuberAggregator: sie gruppiert metrics in Werte-/Gewichts-Slices je
+// Feld und ruft HuberMean mit dem gegebenen k auf.
+func huberAggregate(metrics []model.Metric, k float64) model.Metric {
+	if len(metrics) == 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	var totalTVL float64
+	latestTimestamp := int64(0)
+	apyValues := make([]float64, 0, len(metrics))
+	apyWeights := make([]float64, 0, len(metrics))
+	pointsValues := make([]float64, 0, len(metrics))
+	pointsWeights := make([]float64, 0, len(metrics))
+
+	for _, m := range metrics {
+		if m.TVL <= 0 {
+			continue
+		}
+		totalTVL += m.TVL
+		apyValues = append(apyValues, m.APY)
+		apyWeights = append(apyWeights, m.TVL)
+		pointsValues = append(pointsValues, m.PointsPerETH)
+		pointsWeights = append(pointsWeights, m.TVL)
+		if m.CollectedAt > latestTimestamp {
+			latestTimestamp = m.CollectedAt
+		}
+	}
+	if totalTVL <= 0 {
+		return model.Metric{Provider: "aggregated"}
+	}
+
+	return model.Metric{
+		APY:          HuberMean(apyValues, apyWeights, k),
+		TVL:          totalTVL,
+		PointsPerETH: HuberMean(pointsValues, pointsWeights, k),
+		CollectedAt:  latestTimestamp,
+		Provider:     "aggregated",
+	}
+}
@@ -0,0 +1,75 @@
+package aggregate
+
+import (
+    "math"
+    "testing"
+
+    "github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+func TestHuberMeanDownweightsOutlier(t *testing.T) {
+    values := []float64{5.0, 5.2, 4.8, 100.0} // letzter Wert ist ein Ausreißer
+    weights := []float64{1000, 1000, 1000, 1000}
+
+    got := HuberMean(values, weights, 0)
+
+    if got > 20 {
+        t.Errorf("HuberMean() = %v, want well below the raw mean (28.75) since the outlier is downweighted", got)
+    }
+}
+
+func TestHuberMeanUniformValues(t *testing.T) {
+    values := []float64{10.0, 10.0, 10.0}
+    weights := []float64{1000, 1000, 1000}
+
+    got := HuberMean(values, weights, 0)
+    if math.Abs(got-10.0) > 1e-9 {
+        t.Errorf("HuberMean() = %v, want 10.0 for uniform input", got)
+    }
+}
+
+func TestHuberMeanEmpty(t *testing.T) {
+    if got := HuberMean(nil, nil, 0); got != 0 {
+        t.Errorf("HuberMean(nil, nil, 0) = %v, want 0", got)
+    }
+}
+
+func TestHuberMeanCustomK(t *testing.T) {
+    values := []float64{5.0, 5.2, 4.8, 100.0}
+    weights := []float64{1000, 1000, 1000, 1000}
+
+    lowK := HuberMean(values, weights, 0.5)
+    highK := HuberMean(values, weights, 10)
+
+    if !(lowK < highK) {
+        t.Errorf("HuberMean() with k=0.5 (%v) should pull further from the outlier than k=10 (%v)", lowK, highK)
+    }
+}
+
+func TestHuberAggregation(t *testing.T) {
+    metrics := []model.Metric{
+        {APY: 5.0, TVL: 1000, PointsPerETH: 10},
+        {APY: 5.2, TVL: 1000, PointsPerETH: 10},
+        {APY: 4.8, TVL: 1000, PointsPerETH: 10},
+        {APY: 100.0, TVL: 1000, PointsPerETH: 10}, // Ausreißer
+    }
+
+    got := HuberAggregation(metrics)
+
+    if got.TVL != 4000 {
+        t.Errorf("TVL = %v, want 4000 (summed)", got.TVL)
+    }
+    if got.APY > 20 {
+        t.Errorf("APY = %v, want well below raw mean since the outlier is downweighted", got.APY)
+    }
+    if got.Provider != "aggregated" {
+        t.Errorf("Provider = %v, want aggregated", got.Provider)
+    }
+}
+
+func TestHuberAggregationEmpty(t *testing.T) {
+    got := HuberAggregation(nil)
+    if got.Provider != "aggregated" || got.APY != 0 {
+        t.Errorf("HuberAggregation(nil) = %+v, want zero-valued aggregated metric", got)
+    }
+}
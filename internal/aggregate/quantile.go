@@ -0,0 +1,220 @@
+package aggregate
+
+import (
+	"math"
+	"sort"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// quantileSample ist ein Tupel aus dem Greenwald-Khanna/CKM-Algorithmus:
+// value ist der beobachtete Wert, g die minimale Rangbreite seit dem
+// vorherigen Tupel und delta die maximale Unsicherheit des Rangs.
+type quantileSample struct {
+	value float64
+	g     int
+	delta int
+}
+
+// QuantileSketch berechnet approximative Quantile (p50/p90/p99) über einen
+// unbegrenzten Strom von Werten mit beschränktem Speicherbedarf, nach dem
+// biased-quantile Algorithmus von Cormode, Korn, Muthukrishnan und
+// Srivastava (wie beorn7/perks und Prometheus' Summary-Typ). Im Gegensatz
+// zu Median berechnet dieser Typ keine exakten Perzentile, bleibt aber
+// innerhalb der über targets konfigurierten Fehlertoleranz und benötigt nur
+// O(log(1/epsilon)/epsilon) Speicher statt aller Rohwerte.
+type QuantileSketch struct {
+	// targets bildet eine Quantilstufe (z.B. 0.99) auf ihre zulässige
+	// Fehlertoleranz epsilon ab.
+	targets map[float64]float64
+
+	samples    []quantileSample
+	n          int
+	insertions int
+}
+
+// NewQuantileSketch erstellt ein QuantileSketch für die übergebenen
+// Quantilstufen, z.B. map[float64]float64{0.5: 0.01, 0.9: 0.005, 0.99: 0.001}.
+func NewQuantileSketch(targets map[float64]float64) *QuantileSketch {
+	return &QuantileSketch{
+		targets: targets,
+		samples: make([]quantileSample, 0),
+	}
+}
+
+// Insert fügt einen neuen Beobachtungswert in das Sketch ein.
+func (s *QuantileSketch) Insert(value float64) {
+	idx := sort.Search(len(s.samples), func(i int) bool {
+		return s.samples[i].value >= value
+	})
+
+	delta := 0
+	if idx != 0 && idx != len(s.samples) {
+		delta = int(math.Floor(s.invariant(float64(idx)))) - 1
+		if delta < 0 {
+			delta = 0
+		}
+	}
+
+	sample := quantileSample{value: value, g: 1, delta: delta}
+	s.samples = append(s.samples, quantileSample{})
+	copy(s.samples[idx+1:], s.samples[idx:])
+	s.samples[idx] = sample
+
+	s.n++
+	s.insertions++
+
+	// Komprimiere periodisch benachbarte Tupel, statt bei jedem Insert, um
+	// den Amortisationsvorteil des Algorithmus zu erhalten.
+	if compressEvery := s.compressPeriod(); compressEvery > 0 && s.insertions%compressEvery == 0 {
+		s.compress()
+	}
+}
+
+// compressPeriod bestimmt, wie oft komprimiert wird: alle 1/(2*epsilon)
+// Einfügungen für das engste konfigurierte Ziel.
+func (s *QuantileSketch) compressPeriod() int {
+	minEpsilon := math.Inf(1)
+	for _, epsilon := range s.targets {
+		if epsilon < minEpsilon {
+			minEpsilon = epsilon
+		}
+	}
+	if math.IsInf(minEpsilon, 1) || minEpsilon <= 0 {
+		return 0
+	}
+	period := int(1 / (2 * minEpsilon))
+	if period < 1 {
+		period = 1
+	}
+	return period
+}
+
+// invariant ist f(rank) aus dem CKM-Paper: die maximal zulässige
+// Rangunschärfe an der gegebenen Rangposition über alle konfigurierten
+// Quantilstufen hinweg (das striktere Ziel gewinnt).
+func (s *QuantileSketch) invariant(rank float64) float64 {
+	n := float64(s.n)
+	if n == 0 {
+		n = rank
+	}
+
+	best := math.Inf(1)
+	for q, epsilon := range s.targets {
+		var f float64
+		if rank <= q*n {
+			f = 2 * epsilon * rank / q
+		} else {
+			f = 2 * epsilon * (n - rank) / (1 - q)
+		}
+		if f < best {
+			best = f
+		}
+	}
+	if math.IsInf(best, 1) {
+		return 0
+	}
+	return best
+}
+
+// compress verschmilzt benachbarte Tupel, wenn sie zusammen innerhalb der
+// Invariante für ihre Rangposition bleiben, und reduziert so den
+// Speicherbedarf des Sketches.
+func (s *QuantileSketch) compress() {
+	if len(s.samples) < 3 {
+		return
+	}
+
+	merged := make([]quantileSample, 0, len(s.samples))
+	merged = append(merged, s.samples[0])
+
+	rank := s.samples[0].g
+	for i := 1; i < len(s.samples)-1; i++ {
+		cur := s.samples[i]
+		prev := &merged[len(merged)-1]
+
+		if float64(prev.g+cur.g+cur.delta) <= s.invariant(float64(rank+cur.g)) {
+			prev.g += cur.g
+		} else {
+			merged = append(merged, cur)
+		}
+		rank += cur.g
+	}
+	merged = append(merged, s.samples[len(s.samples)-1])
+
+	s.samples = merged
+}
+
+// Query gibt den approximativen Wert für die Quantilstufe q (0 < q < 1) zurück.
+func (s *QuantileSketch) Query(q float64) float64 {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	if len(s.samples) == 1 {
+		return s.samples[0].value
+	}
+
+	targetRank := int(math.Ceil(q * float64(s.n)))
+	halfInvariant := s.invariant(float64(targetRank)) / 2
+
+	rank := 0
+	for i, sample := range s.samples {
+		rank += sample.g
+		if float64(rank+sample.delta) > float64(targetRank)+halfInvariant {
+			return sample.value
+		}
+		_ = i
+	}
+
+	return s.samples[len(s.samples)-1].value
+}
+
+// Merge kombiniert ein anderes Sketch in dieses hinein, ohne die
+// ursprünglichen Rohwerte neu zu sortieren. Damit lassen sich
+// Provider-spezifische Sketches zusammenführen, z.B. beim Aggregieren über
+// Regionen oder Shards hinweg.
+func (s *QuantileSketch) Merge(other *QuantileSketch) {
+	if other == nil || len(other.samples) == 0 {
+		return
+	}
+
+	for _, sample := range other.samples {
+		for i := 0; i < sample.g; i++ {
+			s.Insert(sample.value)
+		}
+	}
+}
+
+// QuantileAggregation berechnet für jede angeforderte Quantilstufe den
+// approximativen Wert der APY-Verteilung über alle Metriken hinweg und
+// verpackt das Ergebnis als model.Metric mit dem jeweiligen Quantil als APY.
+func QuantileAggregation(metrics []model.Metric, quantiles []float64) map[float64]model.Metric {
+	result := make(map[float64]model.Metric, len(quantiles))
+	if len(metrics) == 0 || len(quantiles) == 0 {
+		return result
+	}
+
+	targets := make(map[float64]float64, len(quantiles))
+	for _, q := range quantiles {
+		targets[q] = 0.01
+	}
+
+	sketch := NewQuantileSketch(targets)
+	latestTimestamp := int64(0)
+	for _, m := range metrics {
+		sketch.Insert(m.APY)
+		if m.CollectedAt > latestTimestamp {
+			latestTimestamp = m.CollectedAt
+		}
+	}
+
+	for _, q := range quantiles {
+		result[q] = model.Metric{
+			APY:         sketch.Query(q),
+			CollectedAt: latestTimestamp,
+			Provider:    "aggregated",
+		}
+	}
+
+	return result
+}
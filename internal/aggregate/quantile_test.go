@@ -0,0 +1,109 @@
+package aggregate
+
+import (
+    "math"
+    "math/rand"
+    "testing"
+
+    "github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+func TestQuantileSketchInsertQuery(t *testing.T) {
+    sketch := NewQuantileSketch(map[float64]float64{0.5: 0.01, 0.9: 0.01, 0.99: 0.01})
+
+    values := make([]float64, 0, 1000)
+    r := rand.New(rand.NewSource(42))
+    for i := 0; i < 1000; i++ {
+        v := r.Float64() * 100
+        values = append(values, v)
+        sketch.Insert(v)
+    }
+
+    exact := make([]float64, len(values))
+    copy(exact, values)
+    for i := range exact {
+        for j := i + 1; j < len(exact); j++ {
+            if exact[j] < exact[i] {
+                exact[i], exact[j] = exact[j], exact[i]
+            }
+        }
+    }
+
+    tests := []struct {
+        q     float64
+        exact float64
+    }{
+        {0.5, exact[len(exact)/2]},
+        {0.9, exact[int(float64(len(exact))*0.9)]},
+        {0.99, exact[int(float64(len(exact))*0.99)]},
+    }
+
+    for _, tt := range tests {
+        got := sketch.Query(tt.q)
+        tolerance := 0.05 * 100 // 5% of value range, generous given approximate guarantees
+        if math.Abs(got-tt.exact) > tolerance {
+            t.Errorf("Query(%v) = %v, want close to exact %v (tolerance %v)", tt.q, got, tt.exact, tolerance)
+        }
+    }
+}
+
+func TestQuantileSketchEmpty(t *testing.T) {
+    sketch := NewQuantileSketch(map[float64]float64{0.5: 0.01})
+    if got := sketch.Query(0.5); got != 0 {
+        t.Errorf("Query() on empty sketch = %v, want 0", got)
+    }
+}
+
+func TestQuantileSketchMerge(t *testing.T) {
+    a := NewQuantileSketch(map[float64]float64{0.5: 0.01})
+    b := NewQuantileSketch(map[float64]float64{0.5: 0.01})
+
+    for i := 1; i <= 50; i++ {
+        a.Insert(float64(i))
+    }
+    for i := 51; i <= 100; i++ {
+        b.Insert(float64(i))
+    }
+
+    a.Merge(b)
+
+    got := a.Query(0.5)
+    if got < 40 || got > 60 {
+        t.Errorf("Query(0.5) after merge = %v, want roughly 50", got)
+    }
+}
+
+func TestQuantileAggregation(t *testing.T) {
+    metrics := []model.Metric{
+        {Provider: "a", APY: 5.0, CollectedAt: 100},
+        {Provider: "b", APY: 10.0, CollectedAt: 200},
+        {Provider: "c", APY: 15.0, CollectedAt: 300},
+    }
+
+    result := QuantileAggregation(metrics, []float64{0.5, 0.99})
+
+    if len(result) != 2 {
+        t.Fatalf("QuantileAggregation() returned %d entries, want 2", len(result))
+    }
+
+    for _, q := range []float64{0.5, 0.99} {
+        m, ok := result[q]
+        if !ok {
+            t.Errorf("missing result for quantile %v", q)
+            continue
+        }
+        if m.CollectedAt != 300 {
+            t.Errorf("result[%v].CollectedAt = %v, want 300 (latest timestamp)", q, m.CollectedAt)
+        }
+        if m.APY < 5.0 || m.APY > 15.0 {
+            t.Errorf("result[%v].APY = %v, want within [5, 15]", q, m.APY)
+        }
+    }
+}
+
+func TestQuantileAggregationEmpty(t *testing.T) {
+    result := QuantileAggregation(nil, []float64{0.5})
+    if len(result) != 0 {
+        t.Errorf("QuantileAggregation(nil) = %v, want empty map", result)
+    }
+}
@@ -0,0 +1,165 @@
+package aggregate
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// providerRate is the result of rating a single provider's PointsPerETH
+// history within the aggregation window.
+type providerRate struct {
+	provider    string
+	rate        float64 // points_per_eth_per_day
+	tvl         float64 // latest known TVL, used as the aggregation weight
+	collectedAt int64
+	resetFound  bool
+}
+
+// RateAggregation treats PointsPerETH as a monotonically-increasing counter
+// rather than an instantaneous snapshot: for each provider it walks the
+// time-ordered samples in history that fall within the last window (relative
+// to time.Now()) and derives a points_per_eth_per_day rate using
+// Prometheus-style rate() semantics - when a sample is lower than the one
+// before it, that's treated as a counter reset and the sample's own value is
+// used as the delta instead of the (negative) difference, and the rate is
+// extrapolated to the edges of window rather than just averaged over the
+// span between the first and last sample.
+//
+// The returned model.Metric's PointsPerETHRate is the TVL-weighted mean of
+// the per-provider rates (TVL taken from each provider's most recent
+// sample); APY and PointsPerETH are left zero since this aggregator doesn't
+// produce point estimates for them. Providers where a reset was detected are
+// logged together as a single structured warning so the adapter layer can
+// surface them.
+func RateAggregation(ctx context.Context, history map[string][]model.Metric, window time.Duration) model.Metric {
+	result := model.Metric{Provider: "aggregated"}
+	if len(history) == 0 || window <= 0 {
+		return result
+	}
+
+	now := time.Now()
+	windowStart := now.Add(-window).Unix()
+
+	var rates []providerRate
+	var resetProviders []string
+
+	for provider, samples := range history {
+		select {
+		case <-ctx.Done():
+			return result
+		default:
+		}
+
+		pr, ok := rateForProvider(samples, windowStart, now.Unix())
+		if !ok {
+			continue
+		}
+		if pr.resetFound {
+			resetProviders = append(resetProviders, provider)
+		}
+		rates = append(rates, pr)
+	}
+
+	if len(resetProviders) > 0 {
+		sort.Strings(resetProviders)
+		logging.WithFields(logging.Fields{
+			"component": "aggregate",
+			"providers": resetProviders,
+		}).Warnf("counter reset detected in PointsPerETH history for %d provider(s)", len(resetProviders))
+	}
+
+	var totalTVL, weightedRate float64
+	var latestTimestamp int64
+	for _, pr := range rates {
+		if pr.tvl <= 0 {
+			continue
+		}
+		totalTVL += pr.tvl
+		weightedRate += pr.rate * pr.tvl
+		if pr.collectedAt > latestTimestamp {
+			latestTimestamp = pr.collectedAt
+		}
+	}
+	if totalTVL <= 0 {
+		return result
+	}
+
+	result.PointsPerETHRate = weightedRate / totalTVL
+	result.TVL = totalTVL
+	result.CollectedAt = latestTimestamp
+	return result
+}
+
+// rateForProvider computes one provider's points_per_eth_per_day rate from
+// its samples, restricted to [windowStart, now]. It reports ok=false when
+// fewer than two in-window samples are available to derive a rate from.
+func rateForProvider(samples []model.Metric, windowStart, now int64) (providerRate, bool) {
+	inWindow := make([]model.Metric, 0, len(samples))
+	for _, m := range samples {
+		if m.CollectedAt >= windowStart && m.CollectedAt <= now {
+			inWindow = append(inWindow, m)
+		}
+	}
+	if len(inWindow) < 2 {
+		return providerRate{}, false
+	}
+
+	sort.Slice(inWindow, func(i, j int) bool {
+		return inWindow[i].CollectedAt < inWindow[j].CollectedAt
+	})
+
+	var counterValue float64
+	resetFound := false
+	for i := 1; i < len(inWindow); i++ {
+		prev := inWindow[i-1].PointsPerETH
+		cur := inWindow[i].PointsPerETH
+		if cur < prev {
+			// Counter reset: the provider re-based its points program, so
+			// the delta is the post-reset value itself rather than the
+			// (negative) difference from the pre-reset value.
+			counterValue += cur
+			resetFound = true
+		} else {
+			counterValue += cur - prev
+		}
+	}
+
+	first := inWindow[0]
+	last := inWindow[len(inWindow)-1]
+	sampledSpan := float64(last.CollectedAt - first.CollectedAt)
+	if sampledSpan <= 0 {
+		return providerRate{}, false
+	}
+	averageInterval := sampledSpan / float64(len(inWindow)-1)
+
+	// Extrapolate the leading/trailing partial intervals up to the window
+	// edges like Prometheus's rate(), capping each extrapolation at half the
+	// average sample interval so a single stale sample near the edge can't
+	// blow up the rate.
+	durationToStart := float64(first.CollectedAt - windowStart)
+	if durationToStart > averageInterval/2 {
+		durationToStart = averageInterval / 2
+	}
+	durationToEnd := float64(now - last.CollectedAt)
+	if durationToEnd > averageInterval/2 {
+		durationToEnd = averageInterval / 2
+	}
+
+	extrapolatedSpan := sampledSpan + durationToStart + durationToEnd
+	if extrapolatedSpan <= 0 {
+		return providerRate{}, false
+	}
+
+	ratePerSecond := counterValue / extrapolatedSpan
+	return providerRate{
+		provider:    last.Provider,
+		rate:        ratePerSecond * 86400,
+		tvl:         last.TVL,
+		collectedAt: last.CollectedAt,
+		resetFound:  resetFound,
+	}, true
+}
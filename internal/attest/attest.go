@@ -0,0 +1,157 @@
+// Package attest provides chain-of-custody signing and Merkle batching for
+// yield metrics, so a downstream consumer (e.g. a Chainlink OCR report) can
+// verify both that a metric came from a trusted aggregator and that it was
+// included in a specific published batch root.
+package attest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// canonicalMetric is the subset of model.Metric that gets signed and
+// hashed into the Merkle tree. It deliberately excludes Signature,
+// SignerPubKey and MerkleProof themselves, so batching a metric (which only
+// sets MerkleProof) never invalidates its signature.
+type canonicalMetric struct {
+	Provider     string
+	APY          float64
+	TVL          float64
+	PointsPerETH float64
+	CollectedAt  int64
+	Protocol     string
+	Chain        string
+	Weight       float64
+	Version      string
+	VaultID      string
+	Asset        string
+}
+
+// canonicalBytes returns a deterministic encoding of m's signed fields.
+// json.Marshal of a struct always emits fields in declaration order, so this
+// is stable across calls and across processes.
+func canonicalBytes(m model.Metric) []byte {
+	c := canonicalMetric{
+		Provider:     m.Provider,
+		APY:          m.APY,
+		TVL:          m.TVL,
+		PointsPerETH: m.PointsPerETH,
+		CollectedAt:  m.CollectedAt,
+		Protocol:     m.Protocol,
+		Chain:        m.Chain,
+		Weight:       m.Weight,
+		Version:      m.Version,
+		VaultID:      m.VaultID,
+		Asset:        m.Asset,
+	}
+	// canonicalMetric has no fields that can fail to marshal (no maps,
+	// channels or functions), so the error is always nil.
+	b, _ := json.Marshal(c)
+	return b
+}
+
+// Signer signs metrics with an Ed25519 key so downstream consumers can
+// verify which aggregator instance produced them.
+type Signer struct {
+	priv ed25519.PrivateKey
+	pub  ed25519.PublicKey
+}
+
+// NewSigner generates a fresh Ed25519 keypair for signing metrics.
+func NewSigner() (*Signer, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("attest: generating signing key: %w", err)
+	}
+	return &Signer{priv: priv, pub: pub}, nil
+}
+
+// NewSignerFromKey wraps an existing Ed25519 private key, e.g. one loaded
+// from internal/security's keystore.
+func NewSignerFromKey(priv ed25519.PrivateKey) *Signer {
+	return &Signer{priv: priv, pub: priv.Public().(ed25519.PublicKey)}
+}
+
+// PublicKey returns the signer's public key, for distribution to verifiers.
+func (s *Signer) PublicKey() ed25519.PublicKey { return s.pub }
+
+// Sign returns a copy of m with Signature and SignerPubKey populated.
+func (s *Signer) Sign(m model.Metric) model.Metric {
+	m.Signature = ed25519.Sign(s.priv, canonicalBytes(m))
+	m.SignerPubKey = s.pub
+	return m
+}
+
+// Verifier checks metric signatures produced by a Signer.
+type Verifier struct{}
+
+// NewVerifier returns a Verifier. It holds no state: the signer's public key
+// travels with the metric (SignerPubKey), so Verify only checks that the
+// signature matches it - callers that need to trust a specific aggregator
+// should additionally check SignerPubKey against their own allowlist.
+func NewVerifier() *Verifier { return &Verifier{} }
+
+// Verify checks that m.Signature is a valid Ed25519 signature by
+// m.SignerPubKey over m's canonical fields.
+func (v *Verifier) Verify(m model.Metric) error {
+	if len(m.Signature) == 0 || len(m.SignerPubKey) == 0 {
+		return errors.New("attest: metric is not signed")
+	}
+	if len(m.SignerPubKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("attest: invalid public key size %d", len(m.SignerPubKey))
+	}
+	if !ed25519.Verify(ed25519.PublicKey(m.SignerPubKey), canonicalBytes(m), m.Signature) {
+		return errors.New("attest: signature verification failed")
+	}
+	return nil
+}
+
+// BuildBatch computes a binary Merkle tree (SHA-256, duplicate-last-on-odd)
+// over ms and returns its root, plus - for each metric in the same order -
+// the sibling-hash proof path from leaf to root as a flat concatenation of
+// 32-byte nodes. An empty batch returns a zero root and nil proofs.
+func BuildBatch(ms []model.Metric) (root [32]byte, proofs [][]byte) {
+	n := len(ms)
+	if n == 0 {
+		return [32]byte{}, nil
+	}
+
+	level := make([][32]byte, n)
+	for i, m := range ms {
+		level[i] = sha256.Sum256(canonicalBytes(m))
+	}
+
+	// pos tracks each original leaf's index within the current level as the
+	// tree is built bottom-up, so we know which node is its sibling.
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = i
+	}
+	proofs = make([][]byte, n)
+
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		for i := 0; i < n; i++ {
+			sibling := level[pos[i]^1]
+			proofs[i] = append(proofs[i], sibling[:]...)
+			pos[i] /= 2
+		}
+
+		next := make([][32]byte, len(level)/2)
+		for j := range next {
+			pair := append(append([]byte{}, level[2*j][:]...), level[2*j+1][:]...)
+			next[j] = sha256.Sum256(pair)
+		}
+		level = next
+	}
+
+	return level[0], proofs
+}
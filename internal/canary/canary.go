@@ -0,0 +1,164 @@
+// Package canary lets operators shadow-run a second aggregation mode
+// alongside the one serving production traffic, measure how far it
+// diverges, and gate promoting it to primary on that divergence staying
+// small over enough samples.
+package canary
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// maxSamples bounds the in-memory divergence history so a long-running
+// canary doesn't grow without bound.
+const maxSamples = 200
+
+// Result captures one shadow-run comparison between the primary and
+// candidate aggregation modes for a single request.
+type Result struct {
+	Mode        string  `json:"mode"`
+	PrimaryAPY  float64 `json:"primaryApy"`
+	CanaryAPY   float64 `json:"canaryApy"`
+	PrimaryTVL  float64 `json:"primaryTvl"`
+	CanaryTVL   float64 `json:"canaryTvl"`
+	APYDeltaPct float64 `json:"apyDeltaPct"`
+	TVLDeltaPct float64 `json:"tvlDeltaPct"`
+	CollectedAt int64   `json:"collectedAt"`
+}
+
+// Controller tracks a single shadow-run candidate aggregation mode and its
+// divergence history from the primary mode serving production traffic.
+type Controller struct {
+	mu         sync.RWMutex
+	mode       string
+	samples    []Result
+	lastSigned map[string]interface{}
+}
+
+// NewController creates an idle Controller with no candidate mode set.
+func NewController() *Controller {
+	return &Controller{}
+}
+
+// SetMode starts (or stops, with mode == "") shadow-running mode alongside
+// the primary aggregation mode, discarding any prior divergence history.
+func (c *Controller) SetMode(mode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mode = mode
+	c.samples = nil
+	c.lastSigned = nil
+}
+
+// Mode returns the candidate mode currently being shadow-run, or "" if
+// canarying is disabled.
+func (c *Controller) Mode() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mode
+}
+
+// Record compares primary against canary, updates the divergence metrics,
+// and appends the comparison to the sample history (trimmed to maxSamples).
+func (c *Controller) Record(primary, canary model.Metric) Result {
+	result := Result{
+		Mode:        c.Mode(),
+		PrimaryAPY:  primary.APY,
+		CanaryAPY:   canary.APY,
+		PrimaryTVL:  primary.TVL,
+		CanaryTVL:   canary.TVL,
+		APYDeltaPct: deltaPct(primary.APY, canary.APY),
+		TVLDeltaPct: deltaPct(primary.TVL, canary.TVL),
+		CollectedAt: time.Now().Unix(),
+	}
+
+	apyDelta.Set(result.APYDeltaPct)
+	tvlDelta.Set(result.TVLDeltaPct)
+	deltaHistogram.WithLabelValues("apy").Observe(math.Abs(result.APYDeltaPct))
+	deltaHistogram.WithLabelValues("tvl").Observe(math.Abs(result.TVLDeltaPct))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.samples = append(c.samples, result)
+	if len(c.samples) > maxSamples {
+		c.samples = c.samples[len(c.samples)-maxSamples:]
+	}
+
+	return result
+}
+
+// SetSigned attaches the data-integrity-signed form of the most recent
+// Result so it can be served back unchanged from Last.
+func (c *Controller) SetSigned(signed map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastSigned = signed
+}
+
+// Last returns the most recently signed canary result, if any request has
+// been shadow-run yet.
+func (c *Controller) Last() (map[string]interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.lastSigned == nil {
+		return nil, false
+	}
+	return c.lastSigned, true
+}
+
+// Passing reports whether the canary has collected at least minSamples
+// observations and its median absolute delta over that window is under
+// maxDeltaPct, gating promotion to primary. The reason string explains the
+// verdict either way, for surfacing to operators.
+func (c *Controller) Passing(minSamples int, maxDeltaPct float64) (bool, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.mode == "" {
+		return false, "no canary mode is active"
+	}
+	if len(c.samples) < minSamples {
+		return false, fmt.Sprintf("only %d/%d samples collected", len(c.samples), minSamples)
+	}
+
+	median := medianAbsDelta(c.samples)
+	if median > maxDeltaPct {
+		return false, fmt.Sprintf("median delta %.4f%% exceeds threshold %.4f%% over %d samples", median*100, maxDeltaPct*100, len(c.samples))
+	}
+	return true, fmt.Sprintf("median delta %.4f%% under threshold %.4f%% over %d samples", median*100, maxDeltaPct*100, len(c.samples))
+}
+
+func medianAbsDelta(samples []Result) float64 {
+	deltas := make([]float64, 0, len(samples)*2)
+	for _, s := range samples {
+		deltas = append(deltas, math.Abs(s.APYDeltaPct), math.Abs(s.TVLDeltaPct))
+	}
+	sort.Float64s(deltas)
+
+	n := len(deltas)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return deltas[n/2]
+	}
+	return (deltas[n/2-1] + deltas[n/2]) / 2
+}
+
+// deltaPct returns the relative change from primary to canary. A zero
+// primary is treated as a 100% delta if canary is non-zero, and no delta
+// if both are zero, rather than dividing by zero.
+func deltaPct(primary, canary float64) float64 {
+	if primary == 0 {
+		if canary == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (canary - primary) / primary
+}
@@ -0,0 +1,30 @@
+package canary
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Prometheus metrics for canary shadow-runs. Package-level since a process
+// runs at most one canary controller at a time.
+var (
+	apyDelta = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "restake_canary_apy_delta",
+		Help: "Relative APY delta between the canary and primary aggregation mode from the most recent shadow run.",
+	})
+
+	tvlDelta = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "restake_canary_tvl_delta",
+		Help: "Relative TVL delta between the canary and primary aggregation mode from the most recent shadow run.",
+	})
+
+	deltaHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "restake_canary_delta_abs",
+			Help:    "Absolute relative delta between canary and primary aggregation results, by field.",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1},
+		},
+		[]string{"field"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(apyDelta, tvlDelta, deltaHistogram)
+}
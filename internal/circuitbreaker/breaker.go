@@ -7,12 +7,23 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/yourorg/restake-yield-ea/internal/model"
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
+// Clock abstracts time so tests can advance the breaker's notion of "now"
+// without sleeping for real reset delays.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
 // State represents the current state of the circuit breaker
 type State int
 
@@ -52,8 +63,69 @@ type CircuitBreaker struct {
 	
 	// Event callback for monitoring/alerting
 	onTripCallback func(reason string, metrics []model.Metric)
+
+	// Pluggable statistical/adaptive anomaly detectors, checked in addition
+	// to the fixed Thresholds above.
+	detectors []Detector
+
+	// clock is used for all time-based decisions so tests can control it.
+	clock Clock
+
+	// maxHalfOpenProbes caps how many probe requests are allowed through
+	// concurrently while in half-open state.
+	maxHalfOpenProbes int
+
+	// halfOpenInFlight counts probes currently being evaluated in half-open
+	// state; accessed atomically.
+	halfOpenInFlight int32
+
+	// currentResetDelay is the active reset delay, doubling (capped at
+	// maxResetDelay) each time a half-open probe fails and re-opens the circuit.
+	currentResetDelay time.Duration
+
+	// maxResetDelay caps the exponential growth of currentResetDelay.
+	maxResetDelay time.Duration
+
+	// stateEnteredAt records when the breaker last changed state, for
+	// GetStats' TimeInState.
+	stateEnteredAt time.Time
+
+	// stats tracks lifetime counters surfaced via GetStats.
+	tripCount             int
+	successesInHalfOpen   int
+	lastTrips             []TripRecord
+
+	// providerStats holds the per-provider EWMA mean/variance/sample-count
+	// used by the EWMAAlpha anomaly check, and is what ProviderStats()
+	// exposes for observability.
+	providerStats map[string]*ProviderStat
+
+	// store, if set, persists a Snapshot on every state transition and
+	// after every successful Check, so a crash or redeploy doesn't reset
+	// protection. nil means state lives purely in memory, the original
+	// behavior.
+	store StateStore
+}
+
+// TripRecord captures a single trip event for GetStats' history.
+type TripRecord struct {
+	Reason string
+	Time   time.Time
 }
 
+// Stats is a snapshot of circuit breaker counters and timing, suitable for
+// Prometheus export or the enterprise MetricsExporter.
+type Stats struct {
+	State               State
+	TotalTrips          int
+	SuccessesInHalfOpen int
+	TimeInState         time.Duration
+	LastTrips           []TripRecord
+}
+
+// maxTripHistory bounds how many trip reasons GetStats retains.
+const maxTripHistory = 10
+
 // Thresholds defines the limits that will trigger the circuit breaker
 type Thresholds struct {
 	// Maximum allowed APY value (e.g., 10.0 for 1000%)
@@ -67,21 +139,108 @@ type Thresholds struct {
 	
 	// Maximum standard deviation for APY values as multiple of mean
 	MaxStdDevMultiple float64 `json:"max_std_dev_multiple,omitempty"`
+
+	// MADThreshold, if > 0, enables a median-absolute-deviation outlier
+	// filter across the batch's APY values: with med = median(APY) and
+	// mad = 1.4826 * median(|APY_i - med|), a batch trips if any point's
+	// |APY_i - med| / mad exceeds MADThreshold. 3.5 is a commonly used
+	// value; MAD is far more robust to a single bad quote or heavy-tailed
+	// APYs than comparing StdDev to the mean.
+	MADThreshold float64 `json:"mad_threshold,omitempty"`
+
+	// EWMAAlpha, if > 0, enables a per-provider EWMA z-score check: each
+	// provider's mean/variance are tracked with smoothing factor EWMAAlpha
+	// (0.1 is a reasonable default), and a batch trips if any provider's
+	// |x-mean|/sqrt(var) exceeds EWMAZThreshold (defaults to 4.0) once that
+	// provider has contributed at least EWMAWarmupSamples observations
+	// (defaults to 5).
+	EWMAAlpha         float64 `json:"ewma_alpha,omitempty"`
+	EWMAZThreshold    float64 `json:"ewma_z_threshold,omitempty"`
+	EWMAWarmupSamples int     `json:"ewma_warmup_samples,omitempty"`
 }
 
 // New creates a new CircuitBreaker with the provided thresholds
 func New(t Thresholds) *CircuitBreaker {
 	return &CircuitBreaker{
-		thresholds:       t,
-		state:           StateClosed,
-		resetDelay:      5 * time.Minute,
-		successThreshold: 3,
+		thresholds:        t,
+		state:             StateClosed,
+		resetDelay:        5 * time.Minute,
+		successThreshold:  3,
+		clock:             realClock{},
+		maxHalfOpenProbes: 1,
+		currentResetDelay: 5 * time.Minute,
+		maxResetDelay:     30 * time.Minute,
+		stateEnteredAt:    time.Now(),
+		providerStats:     make(map[string]*ProviderStat),
 	}
 }
 
+// NewWithStore creates a CircuitBreaker like New, then attempts to restore
+// its state from store.Load before returning, so a restart resumes an
+// open/half-open breaker instead of silently re-closing it. A Load failure
+// (including a snapshot that fails integrity verification) is logged and
+// the breaker starts fresh rather than refusing to start.
+func NewWithStore(t Thresholds, store StateStore) *CircuitBreaker {
+	cb := New(t)
+	cb.store = store
+
+	snap, err := store.Load()
+	if err != nil {
+		logging.Warnf("Failed to load circuit breaker state, starting fresh: %v", err)
+		return cb
+	}
+	if snap.Version == 0 {
+		// Zero Snapshot: no prior state was found (e.g. first startup).
+		return cb
+	}
+
+	cb.mu.Lock()
+	cb.state = snap.State
+	cb.lastTrip = snap.LastTrip
+	cb.metricsHistory = snap.MetricsHistory
+	cb.successCount = snap.SuccessCount
+	cb.stateEnteredAt = cb.clock.Now()
+	for provider, stat := range snap.ProviderStats {
+		s := stat
+		cb.providerStats[provider] = &s
+	}
+	cb.mu.Unlock()
+
+	logging.WithFields(logging.Fields{
+		"state":     cb.state,
+		"last_trip": snap.LastTrip,
+		"saved_at":  snap.SavedAt,
+	}).Info("restored circuit breaker state from store")
+
+	return cb
+}
+
+// WithClock overrides the clock used for reset-delay and time-in-state
+// calculations, primarily so tests don't have to sleep for real.
+func (cb *CircuitBreaker) WithClock(clock Clock) *CircuitBreaker {
+	cb.clock = clock
+	return cb
+}
+
+// WithMaxHalfOpenProbes caps how many concurrent probe requests are allowed
+// through while the circuit is half-open; extra callers are rejected until a
+// probe resolves.
+func (cb *CircuitBreaker) WithMaxHalfOpenProbes(n int) *CircuitBreaker {
+	cb.maxHalfOpenProbes = n
+	return cb
+}
+
+// WithMaxResetDelay caps the exponential growth of the reset delay applied
+// each time a half-open probe fails and re-opens the circuit.
+func (cb *CircuitBreaker) WithMaxResetDelay(d time.Duration) *CircuitBreaker {
+	cb.maxResetDelay = d
+	return cb
+}
+
 // WithResetDelay sets a custom reset delay and returns the circuit breaker
 func (cb *CircuitBreaker) WithResetDelay(delay time.Duration) *CircuitBreaker {
 	cb.resetDelay = delay
+	cb.currentResetDelay = delay
 	return cb
 }
 
@@ -97,6 +256,13 @@ func (cb *CircuitBreaker) WithTripCallback(callback func(reason string, metrics
 	return cb
 }
 
+// WithDetectors attaches one or more adaptive/statistical anomaly detectors.
+// Each is run on every Check call, in addition to the fixed Thresholds.
+func (cb *CircuitBreaker) WithDetectors(detectors ...Detector) *CircuitBreaker {
+	cb.detectors = append(cb.detectors, detectors...)
+	return cb
+}
+
 // Check evaluates the metrics against defined thresholds and determines if the operation should proceed.
 // If the circuit is open, it blocks operations and returns an error.
 // If the metrics violate thresholds, it trips the circuit and returns an error.
@@ -109,13 +275,26 @@ func (cb *CircuitBreaker) Check(metrics []model.Metric) error {
 
 	// If circuit is open, check if it's time for a reset attempt
 	if state == StateOpen {
-		if time.Since(lastTripTime) > cb.resetDelay {
+		if cb.clock.Now().Sub(lastTripTime) > cb.currentResetDelay {
 			cb.transitionToHalfOpen()
 		} else {
 			return errors.New("circuit breaker open: system protection engaged")
 		}
 	}
 
+	// In half-open state, only let a bounded number of probes through
+	// concurrently; the rest fail fast until a probe resolves.
+	cb.mu.RLock()
+	isHalfOpen := cb.state == StateHalfOpen
+	cb.mu.RUnlock()
+	if isHalfOpen {
+		if atomic.AddInt32(&cb.halfOpenInFlight, 1) > int32(cb.maxHalfOpenProbes) {
+			atomic.AddInt32(&cb.halfOpenInFlight, -1)
+			return errors.New("circuit breaker half-open: probe limit reached")
+		}
+		defer atomic.AddInt32(&cb.halfOpenInFlight, -1)
+	}
+
 	// Now get a write lock for the actual check and potential state modification
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
@@ -172,8 +351,33 @@ func (cb *CircuitBreaker) Check(metrics []model.Metric) error {
 		}
 	}
 
+	// Check for outliers via median absolute deviation, which tolerates
+	// heavy-tailed or occasionally-zero APYs far better than StdDev/mean
+	if cb.thresholds.MADThreshold > 0 && len(metrics) > 1 {
+		if reason, tripped := checkMAD(metrics, cb.thresholds.MADThreshold); tripped {
+			cb.trip(reason, metrics)
+			return errors.New(reason)
+		}
+	}
+
+	// Check each provider's EWMA z-score once it's past its warm-up period
+	if cb.thresholds.EWMAAlpha > 0 {
+		if reason, tripped := cb.checkEWMA(metrics); tripped {
+			cb.trip(reason, metrics)
+			return errors.New(reason)
+		}
+	}
+
+	// Run pluggable anomaly detectors on top of the fixed thresholds above
+	for _, detector := range cb.detectors {
+		if tripped, reason := detector.Detect(metrics); tripped {
+			cb.trip(reason, metrics)
+			return errors.New(reason)
+		}
+	}
+
 	// All checks passed, record metrics and update state
-	logrus.Debug("Circuit breaker checks passed")
+	logging.Debug("Circuit breaker checks passed")
 	
 	// Store these metrics for future comparison
 	cb.addToHistory(metrics)
@@ -181,13 +385,19 @@ func (cb *CircuitBreaker) Check(metrics []model.Metric) error {
 	// If we're in half-open state, increment success count and check if we can close
 	if cb.state == StateHalfOpen {
 		cb.successCount++
+		cb.successesInHalfOpen++
+		halfOpenSuccessesTotal.Inc()
 		if cb.successCount >= cb.successThreshold {
 			cb.state = StateClosed
 			cb.successCount = 0
-			logrus.Info("Circuit breaker closed: system has recovered")
+			cb.currentResetDelay = cb.resetDelay
+			cb.stateEnteredAt = cb.clock.Now()
+			logging.Info("Circuit breaker closed: system has recovered")
 		}
 	}
-	
+
+	cb.persistLocked()
+
 	return nil
 }
 
@@ -204,7 +414,10 @@ func (cb *CircuitBreaker) Reset() {
 	defer cb.mu.Unlock()
 	cb.state = StateClosed
 	cb.successCount = 0
-	logrus.Info("Circuit breaker manually reset to closed state")
+	cb.currentResetDelay = cb.resetDelay
+	cb.stateEnteredAt = cb.clock.Now()
+	logging.Info("Circuit breaker manually reset to closed state")
+	cb.persistLocked()
 }
 
 // LastGoodMetrics returns the most recent valid metrics stored in history
@@ -229,20 +442,107 @@ func (cb *CircuitBreaker) transitionToHalfOpen() {
 	if cb.state == StateOpen {
 		cb.state = StateHalfOpen
 		cb.successCount = 0
-		logrus.Info("Circuit breaker half-open: testing system recovery")
+		cb.stateEnteredAt = cb.clock.Now()
+		logging.Info("Circuit breaker half-open: testing system recovery")
+		cb.persistLocked()
 	}
 }
 
-// trip sets the circuit breaker to open state with the current time
+// trip sets the circuit breaker to open state with the current time. A
+// failure while half-open re-opens immediately and doubles the reset delay
+// (capped at maxResetDelay) so a flapping upstream backs off exponentially.
 func (cb *CircuitBreaker) trip(reason string, metrics []model.Metric) {
+	wasHalfOpen := cb.state == StateHalfOpen
+
 	cb.state = StateOpen
-	cb.lastTrip = time.Now()
-	logrus.Warnf("Circuit breaker tripped: %s", reason)
-	
+	cb.lastTrip = cb.clock.Now()
+	cb.stateEnteredAt = cb.lastTrip
+	cb.tripCount++
+	cb.successCount = 0
+
+	cb.lastTrips = append(cb.lastTrips, TripRecord{Reason: reason, Time: cb.lastTrip})
+	if len(cb.lastTrips) > maxTripHistory {
+		cb.lastTrips = cb.lastTrips[len(cb.lastTrips)-maxTripHistory:]
+	}
+
+	if wasHalfOpen {
+		cb.currentResetDelay *= 2
+		if cb.currentResetDelay > cb.maxResetDelay {
+			cb.currentResetDelay = cb.maxResetDelay
+		}
+	}
+
+	logging.Warnf("Circuit breaker tripped: %s", reason)
+
+	recordTrip(reason)
+
 	// Call the callback if registered
 	if cb.onTripCallback != nil {
 		go cb.onTripCallback(reason, metrics)
 	}
+
+	cb.persistLocked()
+}
+
+// GetStats returns a snapshot of trip counts, half-open successes, time
+// spent in the current state, and the last few trip reasons.
+func (cb *CircuitBreaker) GetStats() Stats {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	trips := make([]TripRecord, len(cb.lastTrips))
+	copy(trips, cb.lastTrips)
+
+	return Stats{
+		State:               cb.state,
+		TotalTrips:          cb.tripCount,
+		SuccessesInHalfOpen: cb.successesInHalfOpen,
+		TimeInState:         cb.clock.Now().Sub(cb.stateEnteredAt),
+		LastTrips:           trips,
+	}
+}
+
+// persistLocked saves the breaker's current state to cb.store, if any.
+// Callers must already hold cb.mu. Errors are logged, not returned: a
+// failed save shouldn't block the caller from tripping, resetting, or
+// returning a successful Check.
+func (cb *CircuitBreaker) persistLocked() {
+	if cb.store == nil {
+		return
+	}
+
+	providerStats := make(map[string]ProviderStat, len(cb.providerStats))
+	for provider, st := range cb.providerStats {
+		providerStats[provider] = *st
+	}
+
+	snap := Snapshot{
+		Version:        snapshotSchemaVersion,
+		State:          cb.state,
+		LastTrip:       cb.lastTrip,
+		MetricsHistory: append([]model.Metric(nil), cb.metricsHistory...),
+		ProviderStats:  providerStats,
+		SuccessCount:   cb.successCount,
+		SavedAt:        cb.clock.Now(),
+	}
+
+	if err := cb.store.Save(snap); err != nil {
+		logging.Warnf("Failed to persist circuit breaker state: %v", err)
+	}
+}
+
+// ProviderStats returns a snapshot of each provider's EWMA mean, variance,
+// and sample count tracked by the EWMAAlpha anomaly check, for dashboards
+// and debugging flapping providers.
+func (cb *CircuitBreaker) ProviderStats() map[string]ProviderStat {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	out := make(map[string]ProviderStat, len(cb.providerStats))
+	for provider, st := range cb.providerStats {
+		out[provider] = *st
+	}
+	return out
 }
 
 // addToHistory adds metrics to the history, maintaining a bounded size
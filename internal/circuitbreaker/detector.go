@@ -0,0 +1,190 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// Detector evaluates a batch of metrics against provider-specific history and
+// decides whether the batch looks anomalous enough to trip the circuit.
+// CircuitBreaker.Check runs every configured Detector in addition to its
+// fixed thresholds and trips on the first one that fires.
+type Detector interface {
+	// Detect returns a non-empty reason if the batch should trip the circuit.
+	Detect(metrics []model.Metric) (tripped bool, reason string)
+}
+
+// ZScoreDetector maintains an EWMA of mean and variance per provider for APY
+// and TVL and trips when the standardized deviation exceeds K sigma.
+type ZScoreDetector struct {
+	// Alpha is the EWMA smoothing factor (0,1]; higher reacts faster.
+	Alpha float64
+
+	// KSigma is the number of standard deviations that counts as anomalous.
+	KSigma float64
+
+	state map[string]*ewmaState
+}
+
+type ewmaState struct {
+	apyMean, apyVar float64
+	tvlMean, tvlVar float64
+	initialized     bool
+}
+
+// NewZScoreDetector creates a ZScoreDetector with the given smoothing factor
+// and sigma threshold.
+func NewZScoreDetector(alpha, kSigma float64) *ZScoreDetector {
+	return &ZScoreDetector{Alpha: alpha, KSigma: kSigma, state: make(map[string]*ewmaState)}
+}
+
+// Detect implements Detector.
+func (d *ZScoreDetector) Detect(metrics []model.Metric) (bool, string) {
+	for _, m := range metrics {
+		st, ok := d.state[m.Provider]
+		if !ok {
+			st = &ewmaState{}
+			d.state[m.Provider] = st
+		}
+
+		if !st.initialized {
+			st.apyMean, st.tvlMean = m.APY, m.TVL
+			st.apyVar, st.tvlVar = 0, 0
+			st.initialized = true
+			continue
+		}
+
+		apyZ := zScore(m.APY, st.apyMean, st.apyVar)
+		tvlZ := zScore(m.TVL, st.tvlMean, st.tvlVar)
+
+		// Update EWMA mean/variance regardless, so the detector adapts even
+		// when this observation wasn't flagged.
+		st.apyVar = ewmaUpdate(&st.apyMean, m.APY, d.Alpha, st.apyVar)
+		st.tvlVar = ewmaUpdate(&st.tvlMean, m.TVL, d.Alpha, st.tvlVar)
+
+		if math.Abs(apyZ) > d.KSigma {
+			return true, fmt.Sprintf("zscore: provider %s APY z=%.2f exceeds %.2f sigma", m.Provider, apyZ, d.KSigma)
+		}
+		if math.Abs(tvlZ) > d.KSigma {
+			return true, fmt.Sprintf("zscore: provider %s TVL z=%.2f exceeds %.2f sigma", m.Provider, tvlZ, d.KSigma)
+		}
+	}
+	return false, ""
+}
+
+func zScore(x, mean, variance float64) float64 {
+	stdDev := math.Sqrt(variance)
+	if stdDev == 0 {
+		return 0
+	}
+	return (x - mean) / stdDev
+}
+
+// ewmaUpdate advances an EWMA mean/variance pair in place and returns the new
+// variance. mean_t = alpha*x + (1-alpha)*mean_{t-1};
+// var_t = (1-alpha)*(var_{t-1} + alpha*(x-mean_{t-1})^2).
+func ewmaUpdate(mean *float64, x, alpha, variance float64) float64 {
+	diff := x - *mean
+	newVariance := (1 - alpha) * (variance + alpha*diff*diff)
+	*mean = alpha*x + (1-alpha)*(*mean)
+	return newVariance
+}
+
+// TukeyIQRDetector flags a batch as anomalous when cross-provider APY values
+// contain outliers outside the Tukey fence, after which StdDev/mean is
+// recomputed on the remaining "clean" values for the caller.
+type TukeyIQRDetector struct {
+	// Multiplier scales the IQR to define the fence (1.5 is the classic value).
+	Multiplier float64
+}
+
+// NewTukeyIQRDetector creates a TukeyIQRDetector with the given IQR multiplier.
+func NewTukeyIQRDetector(multiplier float64) *TukeyIQRDetector {
+	return &TukeyIQRDetector{Multiplier: multiplier}
+}
+
+// Detect implements Detector.
+func (d *TukeyIQRDetector) Detect(metrics []model.Metric) (bool, string) {
+	if len(metrics) < 4 {
+		return false, ""
+	}
+
+	apys := make([]float64, len(metrics))
+	for i, m := range metrics {
+		apys[i] = m.APY
+	}
+	sort.Float64s(apys)
+
+	q1 := apys[len(apys)/4]
+	q3 := apys[len(apys)*3/4]
+	iqr := q3 - q1
+	lower := q1 - d.Multiplier*iqr
+	upper := q3 + d.Multiplier*iqr
+
+	outliers := 0
+	for _, v := range apys {
+		if v < lower || v > upper {
+			outliers++
+		}
+	}
+
+	// A single outlier is normal noise; trip only once outliers make up a
+	// meaningful fraction of the batch.
+	if outliers > 0 && float64(outliers)/float64(len(apys)) > 0.25 {
+		return true, fmt.Sprintf("tukey-iqr: %d/%d providers outside [%.4f, %.4f]", outliers, len(apys), lower, upper)
+	}
+	return false, ""
+}
+
+// RateOfChangeDetector trips only when TVL drops persist across M consecutive
+// checks, so a single-point spike doesn't falsely trigger the circuit.
+type RateOfChangeDetector struct {
+	// MaxDrop is the maximum fractional TVL drop allowed between checks.
+	MaxDrop float64
+
+	// PersistFor is the number of consecutive violating checks required to trip.
+	PersistFor int
+
+	lastTVL         float64
+	consecutiveDrop int
+	initialized     bool
+}
+
+// NewRateOfChangeDetector creates a RateOfChangeDetector.
+func NewRateOfChangeDetector(maxDrop float64, persistFor int) *RateOfChangeDetector {
+	return &RateOfChangeDetector{MaxDrop: maxDrop, PersistFor: persistFor}
+}
+
+// Detect implements Detector.
+func (d *RateOfChangeDetector) Detect(metrics []model.Metric) (bool, string) {
+	var totalTVL float64
+	for _, m := range metrics {
+		totalTVL += m.TVL
+	}
+
+	if !d.initialized {
+		d.lastTVL = totalTVL
+		d.initialized = true
+		return false, ""
+	}
+
+	drop := 0.0
+	if d.lastTVL > 0 {
+		drop = (d.lastTVL - totalTVL) / d.lastTVL
+	}
+
+	if drop > d.MaxDrop {
+		d.consecutiveDrop++
+	} else {
+		d.consecutiveDrop = 0
+	}
+	d.lastTVL = totalTVL
+
+	if d.consecutiveDrop >= d.PersistFor {
+		return true, fmt.Sprintf("rate-of-change: TVL dropped >%.1f%% for %d consecutive checks", d.MaxDrop*100, d.consecutiveDrop)
+	}
+	return false, ""
+}
@@ -0,0 +1,50 @@
+package circuitbreaker
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Prometheus metrics for the circuit breaker. These are package-level since
+// a process typically runs a single breaker instance; New doesn't need to
+// thread a registry through every constructor call.
+var (
+	tripsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "restake_circuit_breaker_trips_total",
+		Help: "Total number of times the circuit breaker has tripped open.",
+	})
+
+	halfOpenSuccessesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "restake_circuit_breaker_half_open_successes_total",
+		Help: "Total number of successful probes recorded while half-open.",
+	})
+
+	stateGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "restake_circuit_breaker_state_value",
+		Help: "Current circuit breaker state (0=closed, 1=open, 2=half-open).",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(tripsTotal, halfOpenSuccessesTotal, stateGauge)
+}
+
+// recordTrip increments the trip counter; reason is accepted for symmetry
+// with onTripCallback even though it isn't currently used as a label to
+// avoid unbounded cardinality from free-form reason strings.
+func recordTrip(reason string) {
+	tripsTotal.Inc()
+}
+
+// StatsAsMetric converts GetStats into a plain map suitable for feeding into
+// enterprise.MetricsExporter.AddMetricBatch alongside yield metrics.
+func (cb *CircuitBreaker) StatsAsMetric() map[string]interface{} {
+	stats := cb.GetStats()
+
+	stateGauge.Set(float64(stats.State))
+
+	return map[string]interface{}{
+		"type":                  "circuit_breaker_stats",
+		"state":                 stats.State,
+		"total_trips":           stats.TotalTrips,
+		"successes_in_half_open": stats.SuccessesInHalfOpen,
+		"time_in_state_seconds": stats.TimeInState.Seconds(),
+	}
+}
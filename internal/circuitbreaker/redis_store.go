@@ -0,0 +1,57 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yourorg/restake-yield-ea/internal/security"
+)
+
+// RedisStateStore persists a Snapshot as a single Redis key, so every
+// instance behind a load balancer shares one view of whether the breaker is
+// open instead of each one tripping and recovering independently. Wrapping
+// and verification follow the same rules as FileStateStore.
+type RedisStateStore struct {
+	Key             string
+	Integrity       *security.DataIntegrityService
+	AllowUnverified bool
+
+	client *redis.Client
+}
+
+// NewRedisStateStore creates a RedisStateStore connected to addr (host:port),
+// storing its snapshot under key.
+func NewRedisStateStore(addr, key string) *RedisStateStore {
+	return &RedisStateStore{
+		Key:    key,
+		client: redis.NewClient(&redis.Options{Addr: addr}),
+	}
+}
+
+// Save writes snap to r.Key, with no expiry: the breaker's state is only
+// ever replaced by a newer Save, never allowed to silently disappear.
+func (r *RedisStateStore) Save(snap Snapshot) error {
+	data, err := encodeSnapshot(snap, r.Integrity)
+	if err != nil {
+		return err
+	}
+	if err := r.client.Set(context.Background(), r.Key, data, 0).Err(); err != nil {
+		return fmt.Errorf("redis set circuit breaker state: %w", err)
+	}
+	return nil
+}
+
+// Load reads r.Key. A missing key is not an error: it returns a zero
+// Snapshot, the expected case on first startup.
+func (r *RedisStateStore) Load() (Snapshot, error) {
+	data, err := r.client.Get(context.Background(), r.Key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Snapshot{}, nil
+	}
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("redis get circuit breaker state: %w", err)
+	}
+	return decodeSnapshot(data, r.Integrity, r.AllowUnverified)
+}
@@ -0,0 +1,109 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// ProviderStat is the EWMA mean/variance/sample-count CircuitBreaker tracks
+// per provider for the Thresholds.EWMAAlpha anomaly check.
+type ProviderStat struct {
+	Mean     float64
+	Variance float64
+	Samples  int
+}
+
+// checkMAD flags a batch as anomalous when any provider's APY deviates from
+// the batch median by more than k median absolute deviations (scaled by
+// 1.4826 so MAD is a consistent estimator of StdDev under normality). Unlike
+// comparing StdDev to the mean, a single wild quote can't drag the
+// threshold along with it.
+func checkMAD(metrics []model.Metric, k float64) (reason string, tripped bool) {
+	apys := make([]float64, len(metrics))
+	for i, m := range metrics {
+		apys[i] = m.APY
+	}
+	med := median(apys)
+
+	deviations := make([]float64, len(apys))
+	for i, v := range apys {
+		deviations[i] = math.Abs(v - med)
+	}
+	mad := 1.4826 * median(deviations)
+	if mad == 0 {
+		// Every value equals the median: nothing to flag.
+		return "", false
+	}
+
+	for i, v := range apys {
+		score := math.Abs(v-med) / mad
+		if score > k {
+			return fmt.Sprintf("mad: provider %s APY=%.6f deviates %.2f MADs from median %.6f (threshold %.2f)",
+				metrics[i].Provider, v, score, med, k), true
+		}
+	}
+	return "", false
+}
+
+func median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// checkEWMA updates each provider's EWMA mean/variance in cb.providerStats
+// and flags the batch once a provider past its warm-up period deviates more
+// than EWMAZThreshold standard deviations from its running mean. Called
+// with cb.mu already held for writing (from Check).
+func (cb *CircuitBreaker) checkEWMA(metrics []model.Metric) (reason string, tripped bool) {
+	alpha := cb.thresholds.EWMAAlpha
+	zThreshold := cb.thresholds.EWMAZThreshold
+	if zThreshold <= 0 {
+		zThreshold = 4.0
+	}
+	warmup := cb.thresholds.EWMAWarmupSamples
+	if warmup <= 0 {
+		warmup = 5
+	}
+
+	for _, m := range metrics {
+		st, ok := cb.providerStats[m.Provider]
+		if !ok {
+			st = &ProviderStat{Mean: m.APY}
+			cb.providerStats[m.Provider] = st
+		}
+
+		var flagged string
+		if st.Samples >= warmup && st.Variance > 0 {
+			z := math.Abs(m.APY-st.Mean) / math.Sqrt(st.Variance)
+			if z > zThreshold {
+				flagged = fmt.Sprintf("ewma: provider %s APY z-score %.2f exceeds %.2f (mean=%.6f, samples=%d)",
+					m.Provider, z, zThreshold, st.Mean, st.Samples)
+			}
+		}
+
+		// Update the EWMA state regardless of whether this point was
+		// flagged, so the detector keeps adapting rather than getting
+		// stuck comparing against a stale mean.
+		diff := m.APY - st.Mean
+		st.Variance = (1 - alpha) * (st.Variance + alpha*diff*diff)
+		st.Mean = (1-alpha)*st.Mean + alpha*m.APY
+		st.Samples++
+
+		if flagged != "" {
+			return flagged, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,171 @@
+package circuitbreaker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+	"github.com/yourorg/restake-yield-ea/internal/security"
+)
+
+// snapshotSchemaVersion is bumped whenever Snapshot's shape changes in a way
+// that isn't backwards compatible, so a StateStore can reject (or migrate)
+// a file written by an older build instead of loading it half-populated.
+const snapshotSchemaVersion = 1
+
+// Snapshot captures everything CircuitBreaker needs to resume exactly where
+// it left off across a restart: its state machine position, the metrics
+// history used for TVL-change detection, and the per-provider EWMA stats
+// used by the anomaly checks.
+type Snapshot struct {
+	Version        int                     `json:"version"`
+	State          State                   `json:"state"`
+	LastTrip       time.Time               `json:"lastTrip"`
+	MetricsHistory []model.Metric          `json:"metricsHistory"`
+	ProviderStats  map[string]ProviderStat `json:"providerStats"`
+	SuccessCount   int                     `json:"successCount"`
+	SavedAt        time.Time               `json:"savedAt"`
+}
+
+// StateStore persists and restores a CircuitBreaker's Snapshot. Save is
+// called on every state transition and after every successful Check; Load
+// is called once, by NewWithStore.
+type StateStore interface {
+	Save(Snapshot) error
+	Load() (Snapshot, error)
+}
+
+// FileStateStore persists a Snapshot as a single JSON file, atomically
+// replaced on every Save so a crash mid-write never corrupts the last good
+// snapshot. When Integrity is set, the snapshot is wrapped with
+// DataIntegrityService.CreateTamperProofWrapper before being written, and
+// Load refuses a snapshot that fails VerifyIntegrity unless AllowUnverified
+// is set - otherwise an operator (or attacker) editing the file on disk
+// could silently re-close an open breaker.
+type FileStateStore struct {
+	Path            string
+	Integrity       *security.DataIntegrityService
+	AllowUnverified bool
+
+	mu sync.Mutex
+}
+
+// NewFileStateStore creates a FileStateStore backed by path, with no
+// integrity verification. Use the struct literal directly to also set
+// Integrity/AllowUnverified.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{Path: path}
+}
+
+// Save atomically writes snap to f.Path.
+func (f *FileStateStore) Save(snap Snapshot) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if dir := filepath.Dir(f.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating state store directory: %w", err)
+		}
+	}
+
+	data, err := encodeSnapshot(snap, f.Integrity)
+	if err != nil {
+		return err
+	}
+
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing circuit breaker state: %w", err)
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+// Load reads and decodes the snapshot at f.Path. A missing file is not an
+// error: it returns a zero Snapshot, the expected case on first startup.
+func (f *FileStateStore) Load() (Snapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Snapshot{}, nil
+		}
+		return Snapshot{}, fmt.Errorf("reading circuit breaker state: %w", err)
+	}
+
+	return decodeSnapshot(data, f.Integrity, f.AllowUnverified)
+}
+
+// encodeSnapshot marshals snap directly, or, when integrity is set, wraps
+// and signs it via CreateTamperProofWrapper first.
+func encodeSnapshot(snap Snapshot, integrity *security.DataIntegrityService) ([]byte, error) {
+	if integrity == nil {
+		data, err := json.Marshal(snap)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling circuit breaker state: %w", err)
+		}
+		return data, nil
+	}
+
+	wrapped, err := integrity.CreateTamperProofWrapper(snap, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping circuit breaker state: %w", err)
+	}
+	data, err := json.Marshal(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling wrapped circuit breaker state: %w", err)
+	}
+	return data, nil
+}
+
+// decodeSnapshot reverses encodeSnapshot. When integrity is set it requires
+// the stored snapshot to pass VerifyIntegrity unless allowUnverified is set.
+func decodeSnapshot(data []byte, integrity *security.DataIntegrityService, allowUnverified bool) (Snapshot, error) {
+	if integrity == nil {
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return Snapshot{}, fmt.Errorf("parsing circuit breaker state: %w", err)
+		}
+		return snap, nil
+	}
+
+	var wrapped map[string]interface{}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return Snapshot{}, fmt.Errorf("parsing wrapped circuit breaker state: %w", err)
+	}
+
+	valid, unwrapped, verifyErr := integrity.VerifyIntegrity(wrapped)
+	if !valid || verifyErr != nil {
+		if !allowUnverified {
+			if verifyErr == nil {
+				verifyErr = fmt.Errorf("snapshot failed integrity verification")
+			}
+			return Snapshot{}, fmt.Errorf("refusing unverified circuit breaker state: %w", verifyErr)
+		}
+	}
+
+	var payload interface{}
+	if unwrapped != nil {
+		payload = unwrapped["payload"]
+	}
+	if payload == nil {
+		// Verification failed and AllowUnverified let us continue; fall
+		// back to the raw wrapper's payload field since unwrapped is nil.
+		payload = wrapped["payload"]
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("re-marshaling circuit breaker state payload: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(payloadBytes, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("parsing circuit breaker state payload: %w", err)
+	}
+	return snap, nil
+}
@@ -0,0 +1,33 @@
+package binder
+
+import "os"
+
+// Bind applies every "env"-tagged field from the process environment, then
+// CLI flag overrides from os.Args[1:], onto cfg - using the "env" and
+// "description" struct tags on its fields (and, recursively, on
+// ChainConfig) to know which env vars and --flags exist. It's meant to run
+// after JSON-file loading, as a final override layer, and returns an error
+// listing every unrecognized --flag rather than failing on the first one.
+//
+// Bind overlaps with loadFromEnv/applyEnvOverrides by design: those predate
+// the struct-tag convention and stay in place for backward compatibility,
+// while Bind additionally picks up any field whose "env" tag was added
+// later and handles CLI flags, which loadFromEnv never supported.
+func Bind(cfg interface{}) error {
+	b := newBinder(cfg)
+	if err := b.applyEnv(os.LookupEnv); err != nil {
+		return err
+	}
+	if err := b.applyCLI(os.Args[1:]); err != nil {
+		return err
+	}
+	b.commitChains()
+	return nil
+}
+
+// Help returns a --help-style listing of every field Bind understands,
+// including the dynamic chains.<name>.<field> paths, for wiring into a
+// --help flag.
+func Help(cfg interface{}) string {
+	return newBinder(cfg).Help()
+}
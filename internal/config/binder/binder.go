@@ -0,0 +1,242 @@
+// Package binder binds environment variables and CLI flags onto an
+// EnterpriseConfig using the "env" and "description" struct tags declared
+// on its fields, instead of the hand-written os.Getenv calls in
+// loadFromEnv. It complements, rather than replaces, config.LoadEnterpriseConfig's
+// existing JSON-file and env-var loading: Bind is invoked afterwards to
+// apply CLI flag overrides and to surface --help.
+package binder
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listSeparators splits slice-valued fields (e.g. Kafka brokers, chain
+// providers) on a comma or semicolon, trimming surrounding whitespace.
+var listSeparators = regexp.MustCompile(`\s*[,;]\s*`)
+
+// splitList parses a single string into a slice using listSeparators,
+// dropping empty elements so "a,,b" and trailing separators don't produce
+// blank entries.
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	parts := listSeparators.Split(strings.TrimSpace(value), -1)
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// field describes one bindable leaf discovered by reflection: a dotted CLI
+// path, its static env var (empty for dynamic per-chain fields), and its
+// description tag, used both to apply values and to render --help.
+type field struct {
+	path        string
+	env         string
+	description string
+	value       reflect.Value
+}
+
+// Binder walks an EnterpriseConfig's fields via their "env"/"description"
+// struct tags and applies environment and CLI overrides on top of whatever
+// JSON/env loading already populated.
+type Binder struct {
+	fields     []field
+	chainPaths map[string]reflect.Kind // per-chain field path -> kind, e.g. "rpc-endpoint" -> String
+	chainEnv   map[string]string       // per-chain field path -> env suffix, e.g. "rpc-endpoint" -> "RPC_ENDPOINT"
+	chains     reflect.Value           // cfg.ChainConfigs, for setting chains.<name>.<field> CLI overrides
+
+	// pendingChains accumulates ChainConfig copies mutated by CLI flags,
+	// written back to the chains map by commitChains once all flags are
+	// applied (map values aren't directly addressable).
+	pendingChains map[string]reflect.Value
+}
+
+// kebab converts a json struct tag name ("rpc_endpoint") into a CLI flag
+// path segment ("rpc-endpoint").
+func kebab(jsonTag string) string {
+	name, _, _ := strings.Cut(jsonTag, ",")
+	return strings.ReplaceAll(name, "_", "-")
+}
+
+// newBinder walks cfg's struct tags, recording every leaf field with an
+// "env" or "description" tag plus the dynamic per-chain fields under
+// ChainConfigs.
+func newBinder(cfg interface{}) *Binder {
+	b := &Binder{
+		chainPaths: make(map[string]reflect.Kind),
+		chainEnv:   make(map[string]string),
+	}
+	b.walk(reflect.ValueOf(cfg).Elem(), "")
+	return b
+}
+
+func (b *Binder) walk(v reflect.Value, prefix string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue // unexported, e.g. EnterpriseConfig.secretsResolver
+		}
+		fv := v.Field(i)
+		jsonTag := sf.Tag.Get("json")
+		path := kebab(jsonTag)
+		if prefix != "" && path != "" {
+			path = prefix + "." + path
+		} else if prefix != "" {
+			path = prefix
+		}
+
+		env := sf.Tag.Get("env")
+		desc := sf.Tag.Get("description")
+
+		switch {
+		case sf.Name == "ChainConfigs":
+			if env != "" || desc != "" {
+				b.fields = append(b.fields, field{path: path, env: env, description: desc})
+			}
+			b.chains = fv
+			b.recordChainFields(fv.Type().Elem())
+			continue
+		case fv.Kind() == reflect.Struct:
+			b.walk(fv, path)
+			continue
+		}
+
+		if env == "" && desc == "" {
+			continue
+		}
+		b.fields = append(b.fields, field{path: path, env: env, description: desc, value: fv})
+	}
+}
+
+// recordChainFields registers the per-chain leaf fields of ChainConfig
+// (and its embedded types.ChainConfig) so chains.<name>.<field> CLI paths
+// and CHAIN_<NAME>_<SUFFIX> env vars can be validated and parsed.
+func (b *Binder) recordChainFields(chainConfigType reflect.Type) {
+	var walkType func(t reflect.Type)
+	walkType = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				walkType(sf.Type)
+				continue
+			}
+			jsonTag := sf.Tag.Get("json")
+			name, _, _ := strings.Cut(jsonTag, ",")
+			if name == "" {
+				continue
+			}
+			b.chainPaths[kebab(jsonTag)] = sf.Type.Kind()
+			b.chainEnv[kebab(jsonTag)] = strings.ToUpper(name)
+		}
+	}
+	walkType(chainConfigType)
+}
+
+// applyEnv applies every static env-tagged field whose variable is set,
+// and every CHAIN_<NAME>_<SUFFIX> variable for chains already present in
+// cfg.ChainConfigs (new chain names must come from SUPPORTED_CHAINS, which
+// loadFromEnv already handles - Binder only overrides existing entries).
+func (b *Binder) applyEnv(lookup func(string) (string, bool)) error {
+	for _, f := range b.fields {
+		if f.env == "" || !f.value.IsValid() {
+			continue
+		}
+		raw, ok := lookup(f.env)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := setValue(f.value, raw); err != nil {
+			return fmt.Errorf("binding %s (%s): %w", f.path, f.env, err)
+		}
+	}
+	return nil
+}
+
+// setValue assigns raw into v, parsing according to v's kind. []string
+// fields are split on comma/semicolon via splitList.
+func setValue(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice element type %s", v.Type().Elem())
+		}
+		v.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("unsupported field kind %s", v.Kind())
+	}
+	return nil
+}
+
+// Help renders a --help-style listing of every bindable field: its CLI
+// path, env var (if any), and description, sorted by path.
+func (b *Binder) Help() string {
+	type row struct{ path, env, desc string }
+	rows := make([]row, 0, len(b.fields)+len(b.chainPaths))
+	for _, f := range b.fields {
+		rows = append(rows, row{f.path, f.env, f.description})
+	}
+	for path := range b.chainPaths {
+		rows = append(rows, row{
+			path: "chains.<name>." + path,
+			env:  "CHAIN_<NAME>_" + b.chainEnv[path],
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].path < rows[j].path })
+
+	var sb strings.Builder
+	for _, r := range rows {
+		sb.WriteString("--")
+		sb.WriteString(r.path)
+		if r.env != "" {
+			sb.WriteString(" (env ")
+			sb.WriteString(r.env)
+			sb.WriteString(")")
+		}
+		if r.desc != "" {
+			sb.WriteString("\n\t")
+			sb.WriteString(r.desc)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
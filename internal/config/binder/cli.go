@@ -0,0 +1,132 @@
+package binder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// applyCLI parses args (as from os.Args[1:]) of the form --path=value,
+// applying each onto the matching field. Unrecognized flags are collected
+// rather than failing fast on the first one, so callers can report the
+// full diff of unknown keys at once.
+func (b *Binder) applyCLI(args []string) error {
+	var unknown []string
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			continue // positional argument, not a flag this binder owns
+		}
+		trimmed := strings.TrimPrefix(arg, "--")
+		path, value, hasValue := strings.Cut(trimmed, "=")
+
+		target, ok := b.lookup(path)
+		if !ok {
+			unknown = append(unknown, arg)
+			continue
+		}
+
+		if !hasValue {
+			if target.Kind() != reflect.Bool {
+				unknown = append(unknown, arg)
+				continue
+			}
+			value = "true"
+		}
+
+		if err := setValue(target, value); err != nil {
+			return fmt.Errorf("binding --%s: %w", path, err)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown flag(s): %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// lookup resolves a dotted CLI path to the reflect.Value it should set:
+// either a statically-tagged field, or a chains.<name>.<field> path into
+// an existing entry of cfg.ChainConfigs.
+func (b *Binder) lookup(path string) (reflect.Value, bool) {
+	for _, f := range b.fields {
+		if f.path == path && f.value.IsValid() {
+			return f.value, true
+		}
+	}
+
+	if !strings.HasPrefix(path, "chains.") {
+		return reflect.Value{}, false
+	}
+	rest := strings.TrimPrefix(path, "chains.")
+	name, chainField, found := strings.Cut(rest, ".")
+	if !found {
+		return reflect.Value{}, false
+	}
+	if _, ok := b.chainPaths[chainField]; !ok {
+		return reflect.Value{}, false
+	}
+	return b.lookupChainField(name, chainField)
+}
+
+// lookupChainField returns a settable reflect.Value for chainField on the
+// named chain's ChainConfig. Since map values aren't addressable, it reads
+// the entry out, returns a pointer into a copy, and relies on applyCLI's
+// caller (Bind) to write the copy back via commitChains after all flags
+// are applied.
+func (b *Binder) lookupChainField(name, chainField string) (reflect.Value, bool) {
+	if !b.chains.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	if b.pendingChains == nil {
+		b.pendingChains = make(map[string]reflect.Value)
+	}
+
+	// Reuse the pending copy across multiple flags for the same chain, so
+	// earlier edits in this Bind call aren't discarded by a fresh copy.
+	pending, inFlight := b.pendingChains[name]
+	if !inFlight {
+		entry := b.chains.MapIndex(reflect.ValueOf(name))
+		if !entry.IsValid() {
+			return reflect.Value{}, false // only overrides existing chains, like applyEnv
+		}
+		copyPtr := reflect.New(entry.Type())
+		copyPtr.Elem().Set(entry)
+		pending = copyPtr.Elem()
+		b.pendingChains[name] = pending
+	}
+
+	target := fieldByKebabPath(pending, chainField)
+	if !target.IsValid() {
+		return reflect.Value{}, false
+	}
+	return target, true
+}
+
+// fieldByKebabPath finds the struct field (recursing into embedded
+// structs) whose kebab-cased json tag matches path.
+func fieldByKebabPath(v reflect.Value, path string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			if found := fieldByKebabPath(v.Field(i), path); found.IsValid() {
+				return found
+			}
+			continue
+		}
+		if kebab(sf.Tag.Get("json")) == path {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// commitChains writes back every ChainConfig touched by lookupChainField
+// into cfg.ChainConfigs, since map entries can't be mutated in place.
+func (b *Binder) commitChains() {
+	for name, chainConfig := range b.pendingChains {
+		b.chains.SetMapIndex(reflect.ValueOf(name), chainConfig)
+	}
+}
@@ -3,10 +3,14 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
 	"time"
 	"strconv"
+
+	"github.com/yourorg/restake-yield-ea/internal/config/secrets"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // Config holds all application configuration
@@ -22,18 +26,117 @@ type Config struct {
 	KarakURL     string
 	SymbioticURL string
 
+	// KarakAggregationMode selects how KarakClient.Fetch turns multiple
+	// vaults into metrics: "per_vault" (default) emits one model.Metric
+	// per vault; "tvl_weighted" emits a single TVL-weighted aggregate
+	// across all vaults instead.
+	KarakAggregationMode string
+
 	// OpenTelemetry endpoint for observability
 	OtelEndpoint string
 
-	// API keys for various services
+	// OtelProtocol selects the OTLP exporter transport: "http/protobuf"
+	// (the default) or "grpc".
+	OtelProtocol string
+
+	// OtelTLSEnabled enables TLS on the OTLP connection, for managed
+	// backends that don't accept plaintext traffic.
+	OtelTLSEnabled bool
+
+	// OtelBearerToken, if set, is sent as "Authorization: Bearer <token>"
+	// on every OTLP export request. OTEL_EXPORTER_OTLP_HEADERS (the
+	// standard OTel env var for arbitrary exporter headers) is honored
+	// alongside it.
+	OtelBearerToken string
+
+	// OtelServiceVersion and OtelDeploymentEnv populate the service.version
+	// and deployment.environment resource attributes on every exported span.
+	OtelServiceVersion string
+	OtelDeploymentEnv  string
+
+	// API keys for various services. A value of the form
+	// "secret://<provider>/<path>" is resolved lazily by getAPIKey against
+	// SecretsResolver instead of being used as a literal key.
 	APIKeys map[string]string
 
+	// HTTPCacheBackend selects the persistent store httpcache-backed
+	// clients (currently KarakClient) use for conditional-request
+	// revalidation: "memory" (default, non-persistent), "file" (one JSON
+	// file per entry under HTTPCacheDir) or "bolt" (a single bbolt
+	// database file at HTTPCacheDir).
+	HTTPCacheBackend string
+
+	// HTTPCacheDir is the directory (file backend) or database file path
+	// (bolt backend) used when HTTPCacheBackend isn't "memory".
+	HTTPCacheDir string
+
+	// HTTPCacheRefreshInterval, if non-zero, starts a background
+	// Refresher that proactively revalidates cached responses on this
+	// interval instead of only on the next Fetch call.
+	HTTPCacheRefreshInterval time.Duration
+
+	// SecretsBackend configures the secret:// providers used to resolve
+	// APIKeys entries, keyed by provider name ("vault", "aws", "gcp",
+	// "azure", "file") with that provider's own config, e.g.
+	// {"vault": {"address": "...", "role_id": "...", "secret_id": "..."}}.
+	// Populated from the SECRETS_BACKEND JSON env var; empty disables
+	// secret:// resolution entirely (APIKeys values are used as-is).
+	SecretsBackend map[string]map[string]string
+
+	// SecretsCacheTTL controls how long a resolved secret:// API key is
+	// cached before being re-fetched, and how often the background
+	// refresh loop re-resolves it so a rotated key reaches long-running
+	// fetchers like KarakClient.Fetch without a restart.
+	SecretsCacheTTL time.Duration
+
+	// SecretsResolver is built from SecretsBackend when non-empty; nil
+	// otherwise. getAPIKey uses it to resolve secret:// references.
+	SecretsResolver *secrets.Resolver
+
 	// Timeouts and circuit breaker settings
 	RequestTimeout    time.Duration
 	MaxAPY            float64
 	MaxTVLChange      float64
 	MinProviderCount  int
 	CircuitResetDelay time.Duration
+
+	// ProviderBreakers configures the per-provider GraphQL circuit breaker
+	// (see fetch.graphqlBreaker) that trips on transport failures rather
+	// than metric content, keyed by provider name (e.g. "karak"). A
+	// provider absent from this map uses DefaultProviderBreakerConfig.
+	// Populated from the PROVIDER_BREAKERS JSON env var.
+	ProviderBreakers map[string]ProviderBreakerConfig
+}
+
+// ProviderBreakerConfig configures one provider's GraphQL circuit breaker:
+// when it trips open, and how long it stays open before a half-open probe.
+type ProviderBreakerConfig struct {
+	// ConsecutiveFailures trips the breaker after this many failed requests
+	// in a row.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	// ErrorRateThreshold trips the breaker when the fraction of failures
+	// over the last ErrorRateWindow requests exceeds this (0..1), even
+	// without ConsecutiveFailures consecutive failures. 0 disables this
+	// check.
+	ErrorRateThreshold float64 `json:"error_rate_threshold"`
+
+	// ErrorRateWindow is how many of the most recent requests the rolling
+	// error rate is computed over.
+	ErrorRateWindow int `json:"error_rate_window"`
+
+	// OpenDuration is how long the breaker stays open before letting a
+	// single half-open probe request through.
+	OpenDuration time.Duration `json:"open_duration"`
+}
+
+// DefaultProviderBreakerConfig is used for any provider not present in
+// ProviderBreakers.
+var DefaultProviderBreakerConfig = ProviderBreakerConfig{
+	ConsecutiveFailures: 5,
+	ErrorRateThreshold:  0.5,
+	ErrorRateWindow:     20,
+	OpenDuration:        30 * time.Second,
 }
 
 // Load creates a new Config from environment variables
@@ -43,20 +146,74 @@ func Load() Config {
 		_ = json.Unmarshal([]byte(raw), &apiKeys)
 	}
 
-	return Config{
-		Port:             GetEnvOrDefault("PORT", "8080"),
-		PrimaryProvider:  strings.ToLower(GetEnvOrDefault("PRIMARY_PROVIDER", "eigenlayer")),
-		EigenURL:         GetEnvOrDefault("EIGEN_URL", "https://api.eigenlayer.xyz/yield"),
-		KarakURL:         GetEnvOrDefault("KARAK_URL", "https://karak.network/graphql"),
-		SymbioticURL:     GetEnvOrDefault("SYMBIOTIC_URL", "https://api.symbiotic.finance/yield"),
-		OtelEndpoint:     GetEnvOrDefault("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
-		APIKeys:          apiKeys,
-		RequestTimeout:   GetEnvAsDuration("REQUEST_TIMEOUT", 10*time.Second),
-		MaxAPY:           GetEnvAsFloat("MAX_APY", 10.0), // 1000% max APY
-		MaxTVLChange:     GetEnvAsFloat("MAX_TVL_CHANGE", 0.5), // 50% max TVL change
-		MinProviderCount: GetEnvAsInt("MIN_PROVIDER_COUNT", 2),
-		CircuitResetDelay: GetEnvAsDuration("CIRCUIT_RESET_DELAY", 5*time.Minute),
+	secretsBackend := map[string]map[string]string{}
+	if raw := os.Getenv("SECRETS_BACKEND"); raw != "" {
+		_ = json.Unmarshal([]byte(raw), &secretsBackend)
+	}
+	secretsCacheTTL := GetEnvAsDuration("SECRETS_CACHE_TTL", 5*time.Minute)
+
+	providerBreakers := map[string]ProviderBreakerConfig{}
+	if raw := os.Getenv("PROVIDER_BREAKERS"); raw != "" {
+		_ = json.Unmarshal([]byte(raw), &providerBreakers)
+	}
+
+	cfg := Config{
+		Port:                     GetEnvOrDefault("PORT", "8080"),
+		PrimaryProvider:          strings.ToLower(GetEnvOrDefault("PRIMARY_PROVIDER", "eigenlayer")),
+		EigenURL:                 GetEnvOrDefault("EIGEN_URL", "https://api.eigenlayer.xyz/yield"),
+		KarakURL:                 GetEnvOrDefault("KARAK_URL", "https://karak.network/graphql"),
+		SymbioticURL:             GetEnvOrDefault("SYMBIOTIC_URL", "https://api.symbiotic.finance/yield"),
+		KarakAggregationMode:     strings.ToLower(GetEnvOrDefault("KARAK_AGGREGATION_MODE", "per_vault")),
+		OtelEndpoint:             GetEnvOrDefault("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		OtelProtocol:             GetEnvOrDefault("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf"),
+		OtelTLSEnabled:           GetEnvOrDefault("OTEL_EXPORTER_OTLP_INSECURE", "true") != "true",
+		OtelBearerToken:          GetEnvOrDefault("OTEL_EXPORTER_OTLP_BEARER_TOKEN", ""),
+		OtelServiceVersion:       GetEnvOrDefault("OTEL_SERVICE_VERSION", "dev"),
+		OtelDeploymentEnv:        GetEnvOrDefault("DEPLOYMENT_ENVIRONMENT", "development"),
+		APIKeys:                  apiKeys,
+		SecretsBackend:           secretsBackend,
+		SecretsCacheTTL:          secretsCacheTTL,
+		HTTPCacheBackend:         strings.ToLower(GetEnvOrDefault("HTTP_CACHE_BACKEND", "memory")),
+		HTTPCacheDir:             GetEnvOrDefault("HTTP_CACHE_DIR", ""),
+		HTTPCacheRefreshInterval: GetEnvAsDuration("HTTP_CACHE_REFRESH_INTERVAL", 0),
+		RequestTimeout:           GetEnvAsDuration("REQUEST_TIMEOUT", 10*time.Second),
+		MaxAPY:                   GetEnvAsFloat("MAX_APY", 10.0),       // 1000% max APY
+		MaxTVLChange:             GetEnvAsFloat("MAX_TVL_CHANGE", 0.5), // 50% max TVL change
+		MinProviderCount:         GetEnvAsInt("MIN_PROVIDER_COUNT", 2),
+		CircuitResetDelay:        GetEnvAsDuration("CIRCUIT_RESET_DELAY", 5*time.Minute),
+		ProviderBreakers:         providerBreakers,
+	}
+
+	if len(secretsBackend) > 0 {
+		resolver, err := buildSecretsResolver(secretsBackend, secretsCacheTTL)
+		if err != nil {
+			logging.WithField("component", "config").Warnf("secrets backend configuration failed, API keys will be used as literal values: %v", err)
+		} else {
+			cfg.SecretsResolver = resolver
+		}
+	}
+
+	return cfg
+}
+
+// buildSecretsResolver constructs the named secret providers in backend and
+// wraps them in a Resolver cached for ttl, starting its background refresh
+// loop when ttl is non-zero. Shared with EnterpriseConfig's resolveSecrets.
+func buildSecretsResolver(backend map[string]map[string]string, ttl time.Duration) (*secrets.Resolver, error) {
+	providers := make(map[string]secrets.SecretProvider, len(backend))
+	for name, providerCfg := range backend {
+		provider, err := secrets.New(name, providerCfg)
+		if err != nil {
+			return nil, fmt.Errorf("configuring secret provider %q: %w", name, err)
+		}
+		providers[name] = provider
+	}
+
+	resolver := secrets.NewResolver(providers, ttl)
+	if ttl > 0 {
+		resolver.StartBackgroundRefresh(ttl)
 	}
+	return resolver, nil
 }
 
 // GetEnv retrieves an environment variable and whether it exists
@@ -93,10 +250,13 @@ func GetEnvAsFloat(key string, defaultValue float64) float64 {
 	return defaultValue
 }
 
-// GetEnvAsDuration retrieves an environment variable as a duration with a default value
+// GetEnvAsDuration retrieves an environment variable as a duration with a
+// default value. In addition to time.ParseDuration's s/m/h, it accepts the
+// extended d/w/y units understood by ParseExtendedDuration (e.g. "21d" for
+// an unbonding period).
 func GetEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value, exists := GetEnv(key); exists {
-		if duration, err := time.ParseDuration(value); err == nil {
+		if duration, err := ParseExtendedDuration(value); err == nil {
 			return duration
 		}
 	}
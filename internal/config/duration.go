@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// extendedDurationUnits maps the unit suffixes ParseExtendedDuration accepts
+// beyond what time.ParseDuration already understands (s, m, h) to their
+// equivalent hour count, for restaking schedules like reward-claim cadences
+// and unbonding windows that are naturally expressed in days/weeks/years.
+var extendedDurationUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+	'y': 365 * 24 * time.Hour,
+}
+
+// ParseExtendedDuration parses a duration string, first trying
+// time.ParseDuration and, on failure, a signed sequence of decimal numbers
+// each followed by a unit suffix of s, m, h, d (=24h), w (=168h) or y
+// (=8760h), e.g. "1w3d12h" or "-21d".
+func ParseExtendedDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	orig := s
+	var total time.Duration
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+
+	for len(s) > 0 {
+		i := 0
+		for i < len(s) && (s[i] == '.' || (s[i] >= '0' && s[i] <= '9')) {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q: expected number", orig)
+		}
+		number := s[:i]
+		s = s[i:]
+
+		if len(s) == 0 {
+			return 0, fmt.Errorf("invalid duration %q: missing unit after %q", orig, number)
+		}
+		unit := s[0]
+		s = s[1:]
+
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", orig, err)
+		}
+
+		switch unit {
+		case 's':
+			total += time.Duration(value * float64(time.Second))
+		case 'm':
+			total += time.Duration(value * float64(time.Minute))
+		case 'h':
+			total += time.Duration(value * float64(time.Hour))
+		default:
+			perUnit, ok := extendedDurationUnits[unit]
+			if !ok {
+				return 0, fmt.Errorf("invalid duration %q: unknown unit %q", orig, string(unit))
+			}
+			total += time.Duration(value * float64(perUnit))
+		}
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+    "testing"
+    "time"
+)
+
+func TestParseExtendedDurationRoundTrip(t *testing.T) {
+    tests := []struct {
+        in   string
+        want time.Duration
+    }{
+        {"7d", 7 * 24 * time.Hour},
+        {"21d", 21 * 24 * time.Hour},
+        {"1w", 7 * 24 * time.Hour},
+        {"1y", 365 * 24 * time.Hour},
+        {"1w3d12h", 7*24*time.Hour + 3*24*time.Hour + 12*time.Hour},
+        {"-21d", -21 * 24 * time.Hour},
+        {"30s", 30 * time.Second},
+        {"5m", 5 * time.Minute},
+        {"2h", 2 * time.Hour},
+    }
+
+    for _, tt := range tests {
+        got, err := ParseExtendedDuration(tt.in)
+        if err != nil {
+            t.Errorf("ParseExtendedDuration(%q) returned error: %v", tt.in, err)
+            continue
+        }
+        if got != tt.want {
+            t.Errorf("ParseExtendedDuration(%q) = %v, want %v", tt.in, got, tt.want)
+        }
+    }
+}
+
+func TestParseExtendedDurationInvalid(t *testing.T) {
+    for _, in := range []string{"", "7", "7x", "d7"} {
+        if _, err := ParseExtendedDuration(in); err == nil {
+            t.Errorf("ParseExtendedDuration(%q) expected error, got nil", in)
+        }
+    }
+}
@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/config/binder"
+	"github.com/yourorg/restake-yield-ea/internal/config/secrets"
+	"github.com/yourorg/restake-yield-ea/internal/config/validate"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 	"github.com/yourorg/restake-yield-ea/internal/security"
 	"github.com/yourorg/restake-yield-ea/internal/types"
 )
@@ -16,130 +20,185 @@ import (
 type EnterpriseConfig struct {
 	// Base configuration
 	BaseConfig Config `json:"base"`
-	
+
 	// Multi-chain support
-	ChainConfigs map[string]ChainConfig `json:"chains"`
-	
+	ChainConfigs map[string]ChainConfig `json:"chains" env:"SUPPORTED_CHAINS" description:"Comma/semicolon-separated list of chain names to configure from CHAIN_<NAME>_* env vars"`
+
 	// Enterprise metrics export
 	MetricsExport ExporterConfig `json:"metrics_export"`
-	
+
 	// Data integrity and cryptographic verification
 	DataIntegrity VerificationConfig `json:"data_integrity"`
-	
+
 	// Advanced rate limiting and quotas
 	RateLimiting RateLimitConfig `json:"rate_limiting"`
-	
+
 	// Chainlink OCR support
 	OCR OCRConfig `json:"ocr"`
+
+	// SecretsBackend configures the secret:// providers used to resolve
+	// references in ChainConfigs[*].APIKey, MetricsExport.AWSAccessKey,
+	// MetricsExport.AWSSecretKey, MetricsExport.WebhookAPIKey and
+	// MetricsExport.KafkaPassword at load time, keyed by provider name
+	// ("aws", "vault", "gcp", "azure", "file") with that provider's own
+	// config (e.g. {"vault": {"address": "...", "token": "..."}}).
+	SecretsBackend map[string]map[string]string `json:"secrets_backend,omitempty"`
+
+	// SecretsCacheTTL is an extended-duration string (see
+	// ParseExtendedDuration) controlling how long a resolved secret is
+	// cached, and how often the background refresh loop re-resolves it.
+	// Empty or zero disables caching - every use re-hits the backend.
+	SecretsCacheTTL string `json:"secrets_cache_ttl,omitempty" env:"SECRETS_CACHE_TTL" description:"How long a resolved secret:// value is cached before being re-fetched"`
+
+	// secretsResolver is populated by resolveSecrets once SecretsBackend is
+	// configured, so a future config reload can re-resolve secret://
+	// references without rebuilding every provider from scratch.
+	secretsResolver *secrets.Resolver
 }
 
 // ChainConfig is an alias for types.ChainConfig with an additional Providers field
 type ChainConfig struct {
 	types.ChainConfig
-	Providers []string `json:"providers"`
+	Providers []string `json:"providers" description:"Ordered list of data provider names queried for this chain"`
 }
 
 // ExporterConfig defines settings for enterprise metrics export
 type ExporterConfig struct {
-	Enabled         bool     `json:"enabled"`
-	BatchSize       int      `json:"batch_size"`
-	ExportInterval  string   `json:"export_interval"`
-	DashboardURL    string   `json:"dashboard_url"`
-	
+	Enabled         bool     `json:"enabled" env:"METRICS_EXPORT_ENABLED" description:"Enable enterprise metrics export"`
+	BatchSize       int      `json:"batch_size" env:"METRICS_EXPORT_BATCH_SIZE" description:"Number of observations per export batch"`
+	ExportInterval  string   `json:"export_interval" env:"METRICS_EXPORT_INTERVAL" description:"Duration between periodic exports"`
+	DashboardURL    string   `json:"dashboard_url" env:"METRICS_EXPORT_DASHBOARD_URL" description:"URL of a dashboard to link from exported metrics"`
+
 	// AWS settings
-	AWSEnabled      bool     `json:"aws_enabled"`
-	AWSRegion       string   `json:"aws_region"`
-	AWSAccessKey    string   `json:"aws_access_key,omitempty"`
-	AWSSecretKey    string   `json:"aws_secret_key,omitempty"`
-	CloudwatchGroup string   `json:"cloudwatch_group"`
-	S3Bucket        string   `json:"s3_bucket"`
-	S3KeyPrefix     string   `json:"s3_key_prefix"`
-	
+	AWSEnabled      bool     `json:"aws_enabled" env:"AWS_ENABLED" description:"Enable the CloudWatch/S3 exporter"`
+	AWSRegion       string   `json:"aws_region" env:"AWS_REGION" description:"AWS region for CloudWatch and S3"`
+	AWSAccessKey    string   `json:"aws_access_key,omitempty" env:"AWS_ACCESS_KEY" description:"AWS access key, or a secret:// reference"`
+	AWSSecretKey    string   `json:"aws_secret_key,omitempty" env:"AWS_SECRET_KEY" description:"AWS secret key, or a secret:// reference"`
+	CloudwatchGroup string   `json:"cloudwatch_group" env:"CLOUDWATCH_GROUP" description:"CloudWatch log group for exported metrics"`
+	S3Bucket        string   `json:"s3_bucket" env:"S3_BUCKET" description:"S3 bucket for archived metrics batches"`
+	S3KeyPrefix     string   `json:"s3_key_prefix" env:"S3_KEY_PREFIX" description:"Key prefix for S3-archived metrics batches"`
+
 	// Webhook settings
-	WebhookEnabled  bool     `json:"webhook_enabled"`
-	WebhookURL      string   `json:"webhook_url"`
-	WebhookAPIKey   string   `json:"webhook_api_key,omitempty"`
-	WebhookFormat   string   `json:"webhook_format"`
-	
+	WebhookEnabled  bool     `json:"webhook_enabled" env:"WEBHOOK_ENABLED" description:"Enable the webhook exporter"`
+	WebhookURL      string   `json:"webhook_url" env:"WEBHOOK_URL" description:"URL the webhook exporter POSTs metrics batches to"`
+	WebhookAPIKey   string   `json:"webhook_api_key,omitempty" env:"WEBHOOK_API_KEY" description:"Bearer token for the webhook exporter, or a secret:// reference"`
+	WebhookFormat   string   `json:"webhook_format" env:"WEBHOOK_FORMAT" description:"Payload format sent to the webhook (e.g. json)"`
+
 	// Kafka settings
-	KafkaEnabled    bool     `json:"kafka_enabled"`
-	KafkaBrokers    []string `json:"kafka_brokers"`
-	KafkaTopic      string   `json:"kafka_topic"`
-	KafkaUsername   string   `json:"kafka_username,omitempty"`
-	KafkaPassword   string   `json:"kafka_password,omitempty"`
+	KafkaEnabled       bool     `json:"kafka_enabled" env:"KAFKA_ENABLED" description:"Enable the raw-JSON Kafka exporter"`
+	KafkaBrokers       []string `json:"kafka_brokers" env:"KAFKA_BROKERS" description:"Comma/semicolon-separated list of Kafka broker addresses"`
+	KafkaTopic         string   `json:"kafka_topic" env:"KAFKA_TOPIC" description:"Kafka topic for raw-JSON metrics"`
+	KafkaUsername      string   `json:"kafka_username,omitempty" env:"KAFKA_USERNAME" description:"SASL username for Kafka"`
+	KafkaPassword      string   `json:"kafka_password,omitempty" env:"KAFKA_PASSWORD" description:"SASL password for Kafka, or a secret:// reference"`
+	KafkaSASLMechanism string   `json:"kafka_sasl_mechanism,omitempty" env:"KAFKA_SASL_MECHANISM" description:"SASL mechanism (e.g. PLAIN, SCRAM-SHA-512)"`
+	KafkaTLSEnabled    bool     `json:"kafka_tls_enabled" env:"KAFKA_TLS_ENABLED" description:"Enable TLS for the Kafka connection"`
+	KafkaTLSMinVersion string   `json:"kafka_tls_min_version,omitempty" env:"KAFKA_TLS_MIN_VERSION" description:"Minimum TLS version for the Kafka connection"`
+	KafkaRequiredAcks  string   `json:"kafka_required_acks,omitempty" env:"KAFKA_REQUIRED_ACKS" description:"Required ack level (none, leader, all)"`
+	KafkaRetryMax      int      `json:"kafka_retry_max,omitempty" env:"KAFKA_RETRY_MAX" description:"Maximum produce retries"`
+	KafkaCompression   string   `json:"kafka_compression,omitempty" env:"KAFKA_COMPRESSION" description:"Producer compression codec"`
+	KafkaQueueCapacity int      `json:"kafka_queue_capacity,omitempty" env:"KAFKA_QUEUE_CAPACITY" description:"Producer internal queue capacity"`
+
+	// Kafka OTLP export settings. Separate from KafkaEnabled's raw JSON
+	// export above: this streams yield observations, provider health and
+	// OTEL spans OTLP-protobuf-encoded, for pipelines that consume OTLP
+	// directly (e.g. Grafana Alloy's Kafka receiver) instead of plain JSON.
+	// Connection settings (brokers, SASL, TLS) are shared with KafkaEnabled.
+	KafkaOTLPEnabled      bool   `json:"kafka_otlp_enabled" env:"KAFKA_OTLP_ENABLED" description:"Enable the OTLP-protobuf Kafka exporter"`
+	KafkaOTLPMetricsTopic string `json:"kafka_otlp_metrics_topic,omitempty" env:"KAFKA_OTLP_METRICS_TOPIC" description:"Kafka topic for OTLP-encoded metrics"`
+	KafkaOTLPLogsTopic    string `json:"kafka_otlp_logs_topic,omitempty" env:"KAFKA_OTLP_LOGS_TOPIC" description:"Kafka topic for OTLP-encoded logs"`
+	KafkaOTLPTracesTopic  string `json:"kafka_otlp_traces_topic,omitempty" env:"KAFKA_OTLP_TRACES_TOPIC" description:"Kafka topic for OTLP-encoded traces"`
 }
 
 // VerificationConfig defines settings for data integrity and verification
 type VerificationConfig struct {
-	SignatureEnabled     bool   `json:"signature_enabled"`
-	VerificationRequired bool   `json:"verification_required"`
-	SignatureValidity    string `json:"signature_validity"`
-	StrictMode           bool   `json:"strict_mode"`
-	BlockchainVerification bool  `json:"blockchain_verification"`
-	VerificationContract string `json:"verification_contract,omitempty"`
+	SignatureEnabled     bool   `json:"signature_enabled" env:"SIGNATURE_ENABLED" description:"Sign exported payloads"`
+	VerificationRequired bool   `json:"verification_required" env:"VERIFICATION_REQUIRED" description:"Reject payloads that fail signature verification"`
+	SignatureValidity    string `json:"signature_validity" env:"SIGNATURE_VALIDITY" description:"Duration a signature remains valid for"`
+	StrictMode           bool   `json:"strict_mode" env:"STRICT_MODE" description:"Reject payloads with any verification warning, not just failures"`
+	BlockchainVerification bool  `json:"blockchain_verification" env:"BLOCKCHAIN_VERIFICATION" description:"Verify signatures against an on-chain public key registry"`
+	VerificationContract string `json:"verification_contract,omitempty" env:"VERIFICATION_CONTRACT" description:"Address of the on-chain verification contract"`
 }
 
 // RateLimitConfig defines settings for rate limiting and quotas
 type RateLimitConfig struct {
-	Enabled         bool   `json:"enabled"`
-	RequestsPerMin  int    `json:"requests_per_min"`
-	BurstSize       int    `json:"burst_size"`
-	QuotaPerDay     int    `json:"quota_per_day"`
-	APIKeyRequired  bool   `json:"api_key_required"`
-	APIKeysFilePath string `json:"api_keys_file_path,omitempty"`
+	Enabled         bool   `json:"enabled" env:"RATE_LIMIT_ENABLED" description:"Enable request rate limiting"`
+	RequestsPerMin  int    `json:"requests_per_min" env:"REQUESTS_PER_MIN" description:"Sustained requests allowed per minute per key"`
+	BurstSize       int    `json:"burst_size" env:"RATE_LIMIT_BURST_SIZE" description:"Burst size allowed above the sustained rate"`
+	QuotaPerDay     int    `json:"quota_per_day" env:"RATE_LIMIT_QUOTA_PER_DAY" description:"Daily request quota per key"`
+	APIKeyRequired  bool   `json:"api_key_required" env:"API_KEY_REQUIRED" description:"Require an API key on every request"`
+	APIKeysFilePath string `json:"api_keys_file_path,omitempty" env:"API_KEYS_FILE_PATH" description:"Path to the file listing valid API keys"`
 }
 
 // OCRConfig defines settings for Chainlink Off-Chain Reporting
 type OCRConfig struct {
-	Enabled               bool   `json:"enabled"`
-	ContractAddress       string `json:"contract_address,omitempty"`
-	TransmitterAddress    string `json:"transmitter_address,omitempty"`
-	KeyBundleID           string `json:"key_bundle_id,omitempty"`
-	MonitoringEndpoint    string `json:"monitoring_endpoint,omitempty"`
-	ObservationTimeout    string `json:"observation_timeout"`
-	BlockchainTimeout     string `json:"blockchain_timeout"`
-	ContractTransmitCount uint64 `json:"contract_transmit_count"`
-	ObservationGracePeriod string `json:"observation_grace_period"`
+	Enabled               bool   `json:"enabled" env:"OCR_ENABLED" description:"Enable Chainlink Off-Chain Reporting support"`
+	ContractAddress       string `json:"contract_address,omitempty" env:"OCR_CONTRACT_ADDRESS" description:"Address of the OCR aggregator contract"`
+	TransmitterAddress    string `json:"transmitter_address,omitempty" env:"OCR_TRANSMITTER_ADDRESS" description:"Address used to transmit OCR observations"`
+	KeyBundleID           string `json:"key_bundle_id,omitempty" env:"OCR_KEY_BUNDLE_ID" description:"Chainlink node key bundle ID for OCR signing"`
+	MonitoringEndpoint    string `json:"monitoring_endpoint,omitempty" env:"OCR_MONITORING_ENDPOINT" description:"Endpoint OCR telemetry is sent to"`
+	ObservationTimeout    string `json:"observation_timeout" env:"OCR_OBSERVATION_TIMEOUT" description:"Duration allowed to gather an observation"`
+	BlockchainTimeout     string `json:"blockchain_timeout" env:"OCR_BLOCKCHAIN_TIMEOUT" description:"Duration allowed for blockchain interactions"`
+	ContractTransmitCount uint64 `json:"contract_transmit_count" env:"OCR_CONTRACT_TRANSMIT_COUNT" description:"Number of on-chain transmissions observed"`
+	ObservationGracePeriod string `json:"observation_grace_period" env:"OCR_OBSERVATION_GRACE_PERIOD" description:"Extra time allowed before an observation is considered late"`
 }
 
 // LoadEnterpriseConfig loads the enterprise configuration from JSON file
 func LoadEnterpriseConfig(configPath string) (*EnterpriseConfig, error) {
 	// Default configuration
 	config := DefaultEnterpriseConfig()
-	
-	// If no path is specified, use environment variables
+
+	var err error
+	var fileData []byte
 	if configPath == "" {
-		return loadFromEnv(config)
+		// No path specified, use environment variables
+		config, err = loadFromEnv(config)
+	} else {
+		fileData, err = os.ReadFile(configPath)
+		if err == nil {
+			if err = json.Unmarshal(fileData, config); err == nil {
+				// Apply any environment variable overrides
+				config = applyEnvOverrides(config)
+				logging.Infof("Loaded enterprise configuration from %s", configPath)
+			}
+		}
 	}
-	
-	// Load from file
-	fileData, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, fmt.Errorf("failed to load enterprise config: %w", err)
 	}
-	
-	if err := json.Unmarshal(fileData, config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+
+	if err := binder.Bind(config); err != nil {
+		return nil, fmt.Errorf("failed to bind CLI/env overrides: %w", err)
 	}
-	
-	// Apply any environment variable overrides
-	config = applyEnvOverrides(config)
-	
-	logrus.Infof("Loaded enterprise configuration from %s", configPath)
+
+	if fileData != nil {
+		err = validate.ValidateJSON(fileData, config)
+	} else {
+		err = validate.Validate(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid enterprise config: %w", err)
+	}
+
+	if err := resolveSecrets(config); err != nil {
+		return nil, fmt.Errorf("failed to resolve secret:// references: %w", err)
+	}
+
 	return config, nil
 }
 
 // DefaultEnterpriseConfig returns a default enterprise configuration
 func DefaultEnterpriseConfig() *EnterpriseConfig {
 	return &EnterpriseConfig{
-		BaseConfig: DefaultConfig(),
+		BaseConfig: Load(),
 		ChainConfigs: map[string]ChainConfig{
 			"ethereum": {
-				Enabled:     true,
-				RPCEndpoint: "https://mainnet.infura.io/v3/YOUR_INFURA_KEY",
-				APIEndpoint: "https://api.eigenlayer.xyz",
-				Weight:      1.0,
-				Providers:   []string{"eigenlayer", "stakewise"},
+				ChainConfig: types.ChainConfig{
+					Enabled:     true,
+					RPCEndpoint: "https://mainnet.infura.io/v3/YOUR_INFURA_KEY",
+					APIEndpoint: "https://api.eigenlayer.xyz",
+					Weight:      1.0,
+				},
+				Providers: []string{"eigenlayer", "stakewise"},
 			},
 		},
 		MetricsExport: ExporterConfig{
@@ -172,12 +231,8 @@ func DefaultEnterpriseConfig() *EnterpriseConfig {
 // loadFromEnv loads configuration from environment variables
 func loadFromEnv(config *EnterpriseConfig) (*EnterpriseConfig, error) {
 	// Load base config from environment
-	baseConfig, err := LoadConfigFromEnv()
-	if err != nil {
-		return nil, err
-	}
-	config.BaseConfig = *baseConfig
-	
+	config.BaseConfig = Load()
+
 	// Load chain configurations
 	chains := os.Getenv("SUPPORTED_CHAINS")
 	if chains != "" {
@@ -185,22 +240,24 @@ func loadFromEnv(config *EnterpriseConfig) (*EnterpriseConfig, error) {
 		for _, chain := range chainNames {
 			chain = strings.TrimSpace(chain)
 			envPrefix := "CHAIN_" + strings.ToUpper(chain) + "_"
-			
+
 			config.ChainConfigs[chain] = ChainConfig{
-				Enabled:       getEnvBool(envPrefix+"ENABLED", true),
-				RPCEndpoint:   os.Getenv(envPrefix+"RPC_ENDPOINT"),
-				APIEndpoint:   os.Getenv(envPrefix+"API_ENDPOINT"),
-				APIKey:        os.Getenv(envPrefix+"API_KEY"),
-				Weight:        getEnvFloat(envPrefix+"WEIGHT", 1.0),
-				GasMultiplier: getEnvFloat(envPrefix+"GAS_MULTIPLE", 1.0),
-				Providers:     strings.Split(os.Getenv(envPrefix+"PROVIDERS"), ","),
+				ChainConfig: types.ChainConfig{
+					Enabled:       getEnvBool(envPrefix+"ENABLED", true),
+					RPCEndpoint:   os.Getenv(envPrefix+"RPC_ENDPOINT"),
+					APIEndpoint:   os.Getenv(envPrefix+"API_ENDPOINT"),
+					APIKey:        os.Getenv(envPrefix+"API_KEY"),
+					Weight:        GetEnvAsFloat(envPrefix+"WEIGHT", 1.0),
+					GasMultiplier: GetEnvAsFloat(envPrefix+"GAS_MULTIPLE", 1.0),
+				},
+				Providers: strings.Split(os.Getenv(envPrefix+"PROVIDERS"), ","),
 			}
 		}
 	}
-	
+
 	// Load metrics export config
 	config.MetricsExport.Enabled = getEnvBool("METRICS_EXPORT_ENABLED", false)
-	config.MetricsExport.BatchSize = getEnvInt("METRICS_EXPORT_BATCH_SIZE", 100)
+	config.MetricsExport.BatchSize = GetEnvAsInt("METRICS_EXPORT_BATCH_SIZE", 100)
 	config.MetricsExport.ExportInterval = os.Getenv("METRICS_EXPORT_INTERVAL")
 	
 	// AWS settings
@@ -222,7 +279,13 @@ func loadFromEnv(config *EnterpriseConfig) (*EnterpriseConfig, error) {
 		config.MetricsExport.KafkaBrokers = strings.Split(kafkaBrokers, ",")
 	}
 	config.MetricsExport.KafkaTopic = os.Getenv("KAFKA_TOPIC")
-	
+
+	// Kafka OTLP settings
+	config.MetricsExport.KafkaOTLPEnabled = getEnvBool("KAFKA_OTLP_ENABLED", false)
+	config.MetricsExport.KafkaOTLPMetricsTopic = os.Getenv("KAFKA_OTLP_METRICS_TOPIC")
+	config.MetricsExport.KafkaOTLPLogsTopic = os.Getenv("KAFKA_OTLP_LOGS_TOPIC")
+	config.MetricsExport.KafkaOTLPTracesTopic = os.Getenv("KAFKA_OTLP_TRACES_TOPIC")
+
 	// Data integrity settings
 	config.DataIntegrity.SignatureEnabled = getEnvBool("SIGNATURE_ENABLED", true)
 	config.DataIntegrity.VerificationRequired = getEnvBool("VERIFICATION_REQUIRED", true)
@@ -231,7 +294,7 @@ func loadFromEnv(config *EnterpriseConfig) (*EnterpriseConfig, error) {
 	
 	// Rate limiting settings
 	config.RateLimiting.Enabled = getEnvBool("RATE_LIMIT_ENABLED", true)
-	config.RateLimiting.RequestsPerMin = getEnvInt("REQUESTS_PER_MIN", 60)
+	config.RateLimiting.RequestsPerMin = GetEnvAsInt("REQUESTS_PER_MIN", 60)
 	config.RateLimiting.APIKeyRequired = getEnvBool("API_KEY_REQUIRED", false)
 	
 	// OCR settings
@@ -242,21 +305,25 @@ func loadFromEnv(config *EnterpriseConfig) (*EnterpriseConfig, error) {
 	return config, nil
 }
 
+// getEnvBool retrieves an environment variable as a bool with a default
+// value, mirroring config.go's GetEnvAsInt/GetEnvAsFloat for the bool-typed
+// env vars (*_ENABLED, *_REQUIRED) this file reads.
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := GetEnv(key); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 // applyEnvOverrides applies environment variable overrides to the loaded configuration
 func applyEnvOverrides(config *EnterpriseConfig) *EnterpriseConfig {
 	// Override base config
 	if port := os.Getenv("PORT"); port != "" {
 		config.BaseConfig.Port = port
 	}
-	
-	if timeout := os.Getenv("TIMEOUT"); timeout != "" {
-		config.BaseConfig.TimeoutStr = timeout
-	}
-	
-	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
-		config.BaseConfig.LogLevel = logLevel
-	}
-	
+
 	// Override any sensitive information
 	for chainName, chainConfig := range config.ChainConfigs {
 		envPrefix := "CHAIN_" + strings.ToUpper(chainName) + "_"
@@ -297,7 +364,7 @@ func (c *EnterpriseConfig) CreateMultiChainMapping() map[types.SupportedChain]ty
 
 // CreateDataIntegrityService creates a data integrity service from the configuration
 func (c *EnterpriseConfig) CreateDataIntegrityService() (*security.DataIntegrityService, error) {
-	validityDuration, err := time.ParseDuration(c.DataIntegrity.SignatureValidity)
+	validityDuration, err := ParseExtendedDuration(c.DataIntegrity.SignatureValidity)
 	if err != nil {
 		validityDuration = 24 * time.Hour // Default to 24 hours
 	}
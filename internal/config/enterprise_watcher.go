@@ -0,0 +1,274 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+)
+
+// redactedConfigKeys are JSON field names whose values are never logged in
+// a reload diff, even when they change - credentials and API keys, wherever
+// they appear in the tree.
+var redactedConfigKeys = map[string]bool{
+	"aws_access_key":  true,
+	"aws_secret_key":  true,
+	"webhook_api_key": true,
+	"kafka_password":  true,
+	"api_key":         true,
+}
+
+// ChangeFunc is notified with the previous and newly active EnterpriseConfig
+// after a successful hot-reload, so a consumer (the OTEL tracer, the Kafka
+// or webhook exporter, the OCR subsystem) can rebuild whatever it built from
+// the old config without a process restart.
+type ChangeFunc func(old, new *EnterpriseConfig)
+
+// EnterpriseConfigWatcher keeps a live *EnterpriseConfig behind an
+// atomic.Pointer, re-running LoadEnterpriseConfig whenever configPath or the
+// RateLimiting.APIKeysFilePath it references changes on disk, or on SIGHUP.
+// A reload that fails validation leaves the previously active config in
+// place - Current never briefly observes a broken config.
+type EnterpriseConfigWatcher struct {
+	configPath string
+
+	current atomic.Pointer[EnterpriseConfig]
+
+	fsw  *fsnotify.Watcher
+	sigs chan os.Signal
+	done chan struct{}
+
+	subMu       sync.Mutex
+	subscribers []ChangeFunc
+}
+
+// NewEnterpriseConfigWatcher performs an initial LoadEnterpriseConfig(configPath)
+// and arms an fsnotify watch on its containing directory (and, if set, the
+// directory containing RateLimiting.APIKeysFilePath).
+func NewEnterpriseConfigWatcher(configPath string) (*EnterpriseConfigWatcher, error) {
+	initial, err := LoadEnterpriseConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("initial enterprise config load: %w", err)
+	}
+
+	w := &EnterpriseConfigWatcher{
+		configPath: configPath,
+		sigs:       make(chan os.Signal, 1),
+		done:       make(chan struct{}),
+	}
+	w.current.Store(initial)
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating fsnotify watcher: %w", err)
+	}
+	watchedDirs := map[string]bool{filepath.Dir(configPath): true}
+	if apiKeysPath := initial.RateLimiting.APIKeysFilePath; apiKeysPath != "" {
+		watchedDirs[filepath.Dir(apiKeysPath)] = true
+	}
+	for dir := range watchedDirs {
+		if err := fsw.Add(dir); err != nil {
+			fsw.Close()
+			return nil, fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+	w.fsw = fsw
+
+	return w, nil
+}
+
+// Current returns the currently active config. Safe for concurrent use.
+func (w *EnterpriseConfigWatcher) Current() *EnterpriseConfig {
+	return w.current.Load()
+}
+
+// Subscribe registers fn to be called, with the old and new config, after
+// every successful reload. fn is not called for the initial load.
+func (w *EnterpriseConfigWatcher) Subscribe(fn ChangeFunc) {
+	w.subMu.Lock()
+	defer w.subMu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Start runs the fsnotify and SIGHUP watch loops in the background until ctx
+// is cancelled or Stop is called.
+func (w *EnterpriseConfigWatcher) Start(ctx context.Context) {
+	signal.Notify(w.sigs, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.done:
+				return
+			case <-w.sigs:
+				logging.Info("received SIGHUP, reloading enterprise config")
+				w.reload()
+			case event, ok := <-w.fsw.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if !w.relevantEvent(event.Name) {
+					continue
+				}
+				w.reload()
+			case err, ok := <-w.fsw.Errors:
+				if !ok {
+					return
+				}
+				logging.Warnf("enterprise config watcher error: %v", err)
+			}
+		}
+	}()
+}
+
+// relevantEvent reports whether path is the config file itself or the
+// current RateLimiting.APIKeysFilePath - fsnotify watches whole directories,
+// so unrelated files created alongside them would otherwise trigger reloads.
+func (w *EnterpriseConfigWatcher) relevantEvent(path string) bool {
+	clean := filepath.Clean(path)
+	if clean == filepath.Clean(w.configPath) {
+		return true
+	}
+	if apiKeysPath := w.Current().RateLimiting.APIKeysFilePath; apiKeysPath != "" {
+		return clean == filepath.Clean(apiKeysPath)
+	}
+	return false
+}
+
+// Stop terminates the watch loops and releases the fsnotify watcher.
+func (w *EnterpriseConfigWatcher) Stop() {
+	close(w.done)
+	signal.Stop(w.sigs)
+	if w.fsw != nil {
+		w.fsw.Close()
+	}
+}
+
+// reload re-runs LoadEnterpriseConfig, validates the result, and - only if
+// that succeeds - atomically swaps it in and notifies subscribers. A
+// failure at either step is logged and leaves the active config untouched,
+// which is the rollback path: there's nothing to roll back because the bad
+// config was never made active.
+func (w *EnterpriseConfigWatcher) reload() {
+	next, err := LoadEnterpriseConfig(w.configPath)
+	if err != nil {
+		logging.WithError(err).Warn("enterprise config reload failed, keeping previous config")
+		return
+	}
+	if err := validateEnterpriseConfig(next); err != nil {
+		logging.WithError(err).Warn("enterprise config reload failed validation, keeping previous config")
+		return
+	}
+
+	old := w.current.Swap(next)
+	for _, line := range diffEnterpriseConfig(old, next) {
+		logging.Infof("enterprise config reload: %s", line)
+	}
+	logging.Info("enterprise config reloaded successfully")
+
+	w.subMu.Lock()
+	subscribers := append([]ChangeFunc(nil), w.subscribers...)
+	w.subMu.Unlock()
+	for _, fn := range subscribers {
+		fn(old, next)
+	}
+}
+
+// validateEnterpriseConfig rejects a reloaded config that would otherwise
+// silently break the running server - an empty listen port, a metrics
+// exporter enabled with a non-positive batch size, or a chain left enabled
+// with no RPC endpoint to query.
+func validateEnterpriseConfig(cfg *EnterpriseConfig) error {
+	if cfg.BaseConfig.Port == "" {
+		return fmt.Errorf("base.port must not be empty")
+	}
+	if cfg.MetricsExport.Enabled && cfg.MetricsExport.BatchSize <= 0 {
+		return fmt.Errorf("metrics_export.batch_size must be positive when metrics export is enabled")
+	}
+	for name, chain := range cfg.ChainConfigs {
+		if chain.Enabled && chain.RPCEndpoint == "" && chain.APIEndpoint == "" {
+			return fmt.Errorf("chain %q is enabled but has neither an rpc_endpoint nor an api_endpoint", name)
+		}
+	}
+	return nil
+}
+
+// diffEnterpriseConfig renders one line per top-level JSON field that
+// changed between old and new, redacting any value whose JSON key is in
+// redactedConfigKeys so credentials never reach the logs.
+func diffEnterpriseConfig(old, new *EnterpriseConfig) []string {
+	oldFields, err1 := toJSONMap(old)
+	newFields, err2 := toJSONMap(new)
+	if err1 != nil || err2 != nil {
+		return []string{"(diff unavailable: failed to marshal config for comparison)"}
+	}
+
+	var lines []string
+	diffJSONMaps("", oldFields, newFields, &lines)
+	return lines
+}
+
+func toJSONMap(cfg *EnterpriseConfig) (map[string]interface{}, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// diffJSONMaps recursively compares two decoded JSON objects, appending one
+// line per changed leaf to lines. redactedConfigKeys is checked against the
+// final path segment, so "chains.ethereum.api_key" redacts the same as
+// "metrics_export.aws_access_key".
+func diffJSONMaps(prefix string, old, new map[string]interface{}, lines *[]string) {
+	keys := make(map[string]bool, len(old)+len(new))
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range new {
+		keys[k] = true
+	}
+
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		oldVal, oldOK := old[key]
+		newVal, newOK := new[key]
+
+		oldChild, oldIsMap := oldVal.(map[string]interface{})
+		newChild, newIsMap := newVal.(map[string]interface{})
+		if oldIsMap && newIsMap {
+			diffJSONMaps(path, oldChild, newChild, lines)
+			continue
+		}
+
+		if oldOK && newOK && fmt.Sprint(oldVal) == fmt.Sprint(newVal) {
+			continue
+		}
+
+		if redactedConfigKeys[key] {
+			*lines = append(*lines, fmt.Sprintf("%s changed (redacted)", path))
+			continue
+		}
+		*lines = append(*lines, fmt.Sprintf("%s: %v -> %v", path, oldVal, newVal))
+	}
+}
@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// azureSecretGetter is the subset of the Key Vault client this provider
+// needs.
+type azureSecretGetter interface {
+	GetSecret(ctx context.Context, name, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error)
+}
+
+// azureKeyVaultProvider resolves secret://azure/<name>[/<version>] against
+// an Azure Key Vault. version defaults to the latest version when omitted.
+type azureKeyVaultProvider struct {
+	client azureSecretGetter
+}
+
+// newAzureKeyVaultProvider builds the provider from cfg["vault_url"] using
+// the SDK's default Azure credential chain (environment, managed identity,
+// Azure CLI).
+func newAzureKeyVaultProvider(cfg map[string]string) (SecretProvider, error) {
+	vaultURL := cfg["vault_url"]
+	if vaultURL == "" {
+		return nil, fmt.Errorf("azure secret provider requires a vault_url")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating Azure credential: %w", err)
+	}
+
+	client, err := azsecrets.NewClient(vaultURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating Azure Key Vault client: %w", err)
+	}
+
+	return &azureKeyVaultProvider{client: client}, nil
+}
+
+func (p *azureKeyVaultProvider) Resolve(ctx context.Context, path string) (string, error) {
+	name, version, _ := strings.Cut(path, "/")
+
+	resp, err := p.client.GetSecret(ctx, name, version, nil)
+	if err != nil {
+		return "", fmt.Errorf("fetching Azure secret %s: %w", name, err)
+	}
+	if resp.Value == nil {
+		return "", fmt.Errorf("Azure secret %s has no value", name)
+	}
+	return *resp.Value, nil
+}
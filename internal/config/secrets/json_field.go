@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// splitJSONKeySuffix splits a path of the form "<secret-id>#<json-key>"
+// into its two parts, so a single secret stored as a JSON blob can back
+// more than one secret:// reference (e.g. a Kafka credentials secret with
+// both a username and a password field).
+func splitJSONKeySuffix(path string) (id, jsonKey string) {
+	id, jsonKey, _ = strings.Cut(path, "#")
+	return id, jsonKey
+}
+
+// extractJSONField parses raw as a flat JSON object and returns the string
+// value at key.
+func extractJSONField(raw, key string) (string, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return "", fmt.Errorf("secret value is not a JSON object: %w", err)
+	}
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("JSON key %q not found in secret", key)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("JSON key %q is not a string", key)
+	}
+	return str, nil
+}
@@ -0,0 +1,208 @@
+// Package secrets resolves secret://<provider>/<path> references found in
+// config fields (chain API keys, exporter credentials, etc.) against a
+// pluggable set of backends, modeled on the "many-providers" registry
+// pattern used by ACME DNS-01 challenge integrations: each backend
+// self-registers a factory under a short name, and callers select one by
+// name instead of the config package needing to know every backend.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretProvider resolves a single secret by its backend-specific path (the
+// part of a secret:// reference after the provider name), e.g. for the
+// "aws" provider, "prod/chain-rpc-key".
+type SecretProvider interface {
+	Resolve(ctx context.Context, path string) (string, error)
+}
+
+// ProviderFactory builds a SecretProvider from its backend-specific
+// configuration (e.g. a Vault address and token, an AWS region).
+type ProviderFactory func(cfg map[string]string) (SecretProvider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ProviderFactory{
+		"aws":   newAWSSecretsManagerProvider,
+		"vault": newVaultProvider,
+		"gcp":   newGCPSecretManagerProvider,
+		"azure": newAzureKeyVaultProvider,
+		"file":  newFileProvider,
+	}
+)
+
+// Register adds a named provider factory to the package-wide registry, so
+// external code can plug in a backend (or override a built-in one) without
+// modifying this package.
+func Register(name string, factory ProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New builds the provider registered under name from cfg, or returns an
+// error if name isn't registered.
+func New(name string, cfg map[string]string) (SecretProvider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown secret provider: %s", name)
+	}
+	return factory(cfg)
+}
+
+// reference is a parsed secret://<provider>/<path> string.
+type reference struct {
+	provider string
+	path     string
+}
+
+// parseReference splits a secret:// value into its provider and path, or
+// reports ok=false for anything that isn't a secret:// reference (so
+// callers can leave plain values untouched).
+func parseReference(value string) (ref reference, ok bool) {
+	const scheme = "secret://"
+	if !strings.HasPrefix(value, scheme) {
+		return reference{}, false
+	}
+	rest := strings.TrimPrefix(value, scheme)
+	provider, path, found := strings.Cut(rest, "/")
+	if !found || provider == "" || path == "" {
+		return reference{}, false
+	}
+	return reference{provider: provider, path: path}, true
+}
+
+// cacheEntry holds a resolved secret and when it was fetched, so Resolver
+// can serve repeated lookups without hitting the backend every time.
+type cacheEntry struct {
+	value     string
+	fetchedAt time.Time
+}
+
+// Resolver resolves secret:// references against a fixed set of named
+// providers, caching results for ttl so a hot-reload of the surrounding
+// config doesn't re-hit every backend on every reload.
+type Resolver struct {
+	mu        sync.RWMutex
+	providers map[string]SecretProvider
+	cache     map[string]cacheEntry
+	ttl       time.Duration
+
+	refreshCancel context.CancelFunc
+}
+
+// NewResolver builds a Resolver backed by providers (keyed by provider
+// name, matching the scheme of the secret:// references it will see). A
+// ttl of zero disables caching - every Resolve call hits the backend.
+func NewResolver(providers map[string]SecretProvider, ttl time.Duration) *Resolver {
+	return &Resolver{
+		providers: providers,
+		cache:     make(map[string]cacheEntry),
+		ttl:       ttl,
+	}
+}
+
+// Resolve looks up value: if it's a secret:// reference it resolves (and
+// caches) it against the matching provider; otherwise it's returned as-is,
+// so callers can run every config field through Resolve unconditionally.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := parseReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	if r.ttl > 0 {
+		r.mu.RLock()
+		entry, cached := r.cache[value]
+		r.mu.RUnlock()
+		if cached && time.Since(entry.fetchedAt) < r.ttl {
+			return entry.value, nil
+		}
+	}
+
+	r.mu.RLock()
+	provider, ok := r.providers[ref.provider]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("no secret provider configured for %q", ref.provider)
+	}
+
+	resolved, err := provider.Resolve(ctx, ref.path)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", value, err)
+	}
+
+	if r.ttl > 0 {
+		r.mu.Lock()
+		r.cache[value] = cacheEntry{value: resolved, fetchedAt: time.Now()}
+		r.mu.Unlock()
+	}
+
+	return resolved, nil
+}
+
+// StartBackgroundRefresh periodically re-resolves every cached reference
+// every interval, so a secret rotated at the backend reaches the adapter
+// without waiting for the next cache miss (which, with a long ttl, might
+// never come on its own). Call Stop to end it.
+func (r *Resolver) StartBackgroundRefresh(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.refreshCancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.refreshAll(ctx)
+			}
+		}
+	}()
+}
+
+// Stop ends the background refresh loop started by StartBackgroundRefresh.
+// It is a no-op if no refresh loop is running.
+func (r *Resolver) Stop() {
+	if r.refreshCancel != nil {
+		r.refreshCancel()
+	}
+}
+
+func (r *Resolver) refreshAll(ctx context.Context) {
+	r.mu.RLock()
+	refs := make([]string, 0, len(r.cache))
+	for ref := range r.cache {
+		refs = append(refs, ref)
+	}
+	r.mu.RUnlock()
+
+	for _, ref := range refs {
+		parsed, ok := parseReference(ref)
+		if !ok {
+			continue
+		}
+		r.mu.RLock()
+		provider, ok := r.providers[parsed.provider]
+		r.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		resolved, err := provider.Resolve(ctx, parsed.path)
+		if err != nil {
+			continue
+		}
+		r.mu.Lock()
+		r.cache[ref] = cacheEntry{value: resolved, fetchedAt: time.Now()}
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+)
+
+// vaultLogical is the subset of the Vault client this provider needs.
+type vaultLogical interface {
+	ReadWithContext(ctx context.Context, path string) (*vaultapi.Secret, error)
+}
+
+// vaultProvider resolves secret://vault/<mount>/<path>#<field> against a
+// HashiCorp Vault KV engine (v1 or v2, depending on how the mount is
+// configured at cfg["address"]).
+type vaultProvider struct {
+	logical vaultLogical
+
+	// appRole is set when cfg["role_id"]/cfg["secret_id"] configure AppRole
+	// auth instead of a static cfg["token"]; it keeps the client's token
+	// renewed as the issued lease approaches expiry.
+	appRole *vaultAppRole
+}
+
+// vaultAppRole holds the state needed to log in via Vault's AppRole auth
+// method and periodically re-authenticate before the resulting token's
+// lease runs out.
+type vaultAppRole struct {
+	mu sync.Mutex
+
+	client   *vaultapi.Client
+	roleID   string
+	secretID string
+	mount    string
+}
+
+// newVaultProvider builds the provider from cfg["address"] plus either
+// cfg["token"] or cfg["role_id"]/cfg["secret_id"] (AppRole, optionally with
+// cfg["approle_mount"], default "approle"). With neither set, it falls back
+// to the client library's own environment-variable defaults (VAULT_ADDR,
+// VAULT_TOKEN).
+func newVaultProvider(cfg map[string]string) (SecretProvider, error) {
+	vaultCfg := vaultapi.DefaultConfig()
+	if address := cfg["address"]; address != "" {
+		vaultCfg.Address = address
+	}
+
+	client, err := vaultapi.NewClient(vaultCfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating Vault client: %w", err)
+	}
+
+	p := &vaultProvider{logical: client.Logical()}
+
+	roleID, secretID := cfg["role_id"], cfg["secret_id"]
+	switch {
+	case roleID != "" && secretID != "":
+		mount := cfg["approle_mount"]
+		if mount == "" {
+			mount = "approle"
+		}
+		p.appRole = &vaultAppRole{client: client, roleID: roleID, secretID: secretID, mount: mount}
+		if err := p.appRole.login(context.Background()); err != nil {
+			return nil, fmt.Errorf("AppRole login: %w", err)
+		}
+	case cfg["token"] != "":
+		client.SetToken(cfg["token"])
+	}
+
+	return p, nil
+}
+
+// login authenticates via AppRole and, if Vault granted a renewable lease,
+// schedules a background re-login at half its duration - the same
+// half-life convention Vault's own agent uses - so a long-running fetcher
+// never has a request rejected by an expired token.
+func (a *vaultAppRole) login(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	secret, err := a.client.Logical().WriteWithContext(ctx, fmt.Sprintf("auth/%s/login", a.mount), map[string]interface{}{
+		"role_id":   a.roleID,
+		"secret_id": a.secretID,
+	})
+	if err != nil {
+		return fmt.Errorf("AppRole login at auth/%s/login: %w", a.mount, err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return fmt.Errorf("AppRole login at auth/%s/login returned no auth info", a.mount)
+	}
+	a.client.SetToken(secret.Auth.ClientToken)
+
+	if leaseDuration := time.Duration(secret.Auth.LeaseDuration) * time.Second; leaseDuration > 0 {
+		go a.scheduleRenewal(leaseDuration)
+	}
+	return nil
+}
+
+func (a *vaultAppRole) scheduleRenewal(leaseDuration time.Duration) {
+	timer := time.NewTimer(leaseDuration / 2)
+	defer timer.Stop()
+	<-timer.C
+
+	if err := a.login(context.Background()); err != nil {
+		logging.WithField("component", "secrets.vault").Warnf("AppRole token renewal failed, will retry next lease: %v", err)
+	}
+}
+
+func (p *vaultProvider) Resolve(ctx context.Context, path string) (string, error) {
+	vaultPath, jsonKey := splitJSONKeySuffix(path)
+
+	secret, err := p.logical.ReadWithContext(ctx, vaultPath)
+	if err != nil {
+		return "", fmt.Errorf("reading Vault secret %s: %w", vaultPath, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("Vault secret %s not found", vaultPath)
+	}
+
+	data := secret.Data
+	// KV v2 nests the actual fields under a "data" key; fall back to the
+	// top-level map for KV v1 mounts.
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	if jsonKey == "" {
+		jsonKey = "value"
+	}
+	value, ok := data[jsonKey]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in Vault secret %s", jsonKey, vaultPath)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q in Vault secret %s is not a string", jsonKey, vaultPath)
+	}
+	return str, nil
+}
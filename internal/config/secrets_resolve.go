@@ -0,0 +1,56 @@
+package config
+
+import (
+	"context"
+	"fmt"
+)
+
+// resolveSecrets resolves every secret://<provider>/<path> reference in
+// cfg's sensitive fields (chain API keys, exporter credentials) against the
+// providers configured in cfg.SecretsBackend, replacing each field with
+// its resolved value in place. It's a no-op when SecretsBackend is empty,
+// so plain env-var/JSON config keeps working unchanged.
+func resolveSecrets(cfg *EnterpriseConfig) error {
+	if len(cfg.SecretsBackend) == 0 {
+		return nil
+	}
+
+	ttl, _ := ParseExtendedDuration(cfg.SecretsCacheTTL)
+	resolver, err := buildSecretsResolver(cfg.SecretsBackend, ttl)
+	if err != nil {
+		return err
+	}
+	cfg.secretsResolver = resolver
+
+	ctx := context.Background()
+	resolve := func(field *string) error {
+		resolved, err := resolver.Resolve(ctx, *field)
+		if err != nil {
+			return err
+		}
+		*field = resolved
+		return nil
+	}
+
+	for _, field := range []*string{
+		&cfg.MetricsExport.AWSAccessKey,
+		&cfg.MetricsExport.AWSSecretKey,
+		&cfg.MetricsExport.WebhookAPIKey,
+		&cfg.MetricsExport.KafkaPassword,
+	} {
+		if err := resolve(field); err != nil {
+			return err
+		}
+	}
+
+	for name, chainCfg := range cfg.ChainConfigs {
+		resolved, err := resolver.Resolve(ctx, chainCfg.APIKey)
+		if err != nil {
+			return fmt.Errorf("resolving API key for chain %q: %w", name, err)
+		}
+		chainCfg.APIKey = resolved
+		cfg.ChainConfigs[name] = chainCfg
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package validate
+
+import "strings"
+
+// Errors aggregates every problem found while validating a config, so an
+// operator sees the full list of what's wrong in one boot attempt instead
+// of fixing issues one at a time across repeated restarts.
+type Errors []error
+
+// Error joins every collected message with "; ".
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ErrorOrNil returns e as an error, or nil if e is empty - the usual shape
+// for returning an accumulated Errors from a function that otherwise
+// returns a plain error.
+func (e Errors) ErrorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+// GenerateSchema builds a JSON-Schema-shaped map[string]interface{} for
+// cfg's type from its "json" and "description" struct tags - the same tags
+// internal/config/binder already reads to drive CLI/env binding, reused
+// here so the two stay in lockstep without a second source of truth.
+func GenerateSchema(cfg interface{}) map[string]interface{} {
+	return schemaForType(reflect.TypeOf(cfg).Elem())
+}
+
+func schemaForType(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaForType(t.Elem()),
+		}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaForType(t.Elem()),
+		}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema builds an "object" schema node, inlining anonymous
+// (embedded) struct fields at the same level - matching how encoding/json
+// marshals them - and marking a field required when its json tag has no
+// "omitempty".
+func structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue // unexported
+		}
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			embedded := structSchema(sf.Type)
+			for name, propSchema := range embedded["properties"].(map[string]interface{}) {
+				properties[name] = propSchema
+			}
+			required = append(required, embedded["required"].([]string)...)
+			continue
+		}
+
+		jsonTag := sf.Tag.Get("json")
+		name, opts, _ := strings.Cut(jsonTag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		propSchema := schemaForType(sf.Type)
+		if desc := sf.Tag.Get("description"); desc != "" {
+			propSchema["description"] = desc
+		}
+		properties[name] = propSchema
+
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
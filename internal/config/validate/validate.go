@@ -0,0 +1,250 @@
+// Package validate checks a loaded EnterpriseConfig (and, when available,
+// its raw JSON) against a schema generated from its struct tags plus a set
+// of semantic rules that the JSON shape alone can't express - required
+// fields that only apply when a feature is enabled, durations that must
+// parse, and weight sums that must be sane. It takes cfg as interface{}
+// rather than importing internal/config directly, purely so
+// LoadEnterpriseConfig can call into this package without an import cycle;
+// it's written against EnterpriseConfig's specific shape, not arbitrary
+// structs.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ValidateJSON checks raw against the schema generated from cfg's type,
+// then runs Validate's semantic checks against cfg itself. Use this when
+// the config was loaded from a JSON file, so a typo'd field name or wrong
+// JSON type is caught before it's silently dropped by json.Unmarshal.
+func ValidateJSON(raw []byte, cfg interface{}) error {
+	var errs Errors
+
+	var instance map[string]interface{}
+	if err := json.Unmarshal(raw, &instance); err != nil {
+		return Errors{fmt.Errorf("parsing config JSON: %w", err)}
+	}
+	schema := GenerateSchema(cfg)
+	checkSchema("", schema, instance, &errs)
+
+	if err := Validate(cfg); err != nil {
+		if asErrors, ok := err.(Errors); ok {
+			errs = append(errs, asErrors...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// checkSchema recursively verifies that instance satisfies schema's
+// "required" and "type" constraints, appending one error per violation
+// found so multiple problems in the same document are all reported.
+func checkSchema(path string, schema map[string]interface{}, instance interface{}, errs *Errors) {
+	schemaType, _ := schema["type"].(string)
+
+	switch schemaType {
+	case "object":
+		obj, ok := instance.(map[string]interface{})
+		if !ok {
+			if instance != nil {
+				*errs = append(*errs, fmt.Errorf("%s: expected an object", fieldPath(path)))
+			}
+			return
+		}
+		for _, req := range schema["required"].([]string) {
+			if _, present := obj[req]; !present {
+				*errs = append(*errs, fmt.Errorf("%s: missing required field %q", fieldPath(path), req))
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, value := range obj {
+			propSchema, known := properties[name].(map[string]interface{})
+			if !known {
+				continue // additional properties are tolerated, not just unknown chain names
+			}
+			checkSchema(joinPath(path, name), propSchema, value, errs)
+		}
+	case "array":
+		arr, ok := instance.([]interface{})
+		if !ok {
+			if instance != nil {
+				*errs = append(*errs, fmt.Errorf("%s: expected an array", fieldPath(path)))
+			}
+			return
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		for i, elem := range arr {
+			checkSchema(fmt.Sprintf("%s[%d]", path, i), items, elem, errs)
+		}
+	case "boolean":
+		if _, ok := instance.(bool); !ok && instance != nil {
+			*errs = append(*errs, fmt.Errorf("%s: expected a boolean", fieldPath(path)))
+		}
+	case "string":
+		if _, ok := instance.(string); !ok && instance != nil {
+			*errs = append(*errs, fmt.Errorf("%s: expected a string", fieldPath(path)))
+		}
+	case "integer", "number":
+		if _, ok := instance.(float64); !ok && instance != nil {
+			*errs = append(*errs, fmt.Errorf("%s: expected a number", fieldPath(path)))
+		}
+	}
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// Validate runs semantic checks on cfg that the JSON schema can't express:
+// durations that must parse, fields that are only required once a feature
+// is enabled, and chain weights that must sum to something usable.
+func Validate(cfg interface{}) error {
+	var errs Errors
+
+	root := reflect.ValueOf(cfg)
+	if root.Kind() == reflect.Ptr {
+		root = root.Elem()
+	}
+
+	checkDurationFields(root, "", &errs)
+	checkChainConfigs(root.FieldByName("ChainConfigs"), &errs)
+	checkKafka(root.FieldByName("MetricsExport"), &errs)
+	checkOCR(root.FieldByName("OCR"), &errs)
+
+	return errs.ErrorOrNil()
+}
+
+// checkDurationFields recursively parses every string field whose name
+// ends in Timeout, Interval or Validity with time.ParseDuration, so a
+// malformed value is caught at load time instead of silently falling back
+// to a hardcoded default deep inside whatever first tries to use it.
+func checkDurationFields(v reflect.Value, path string, errs *Errors) {
+	if !v.IsValid() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			checkDurationFields(v.Elem(), path, errs)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			fv := v.Field(i)
+			fieldPath := joinPath(path, sf.Name)
+
+			if fv.Kind() == reflect.String && hasDurationSuffix(sf.Name) {
+				if raw := fv.String(); raw != "" {
+					if _, err := time.ParseDuration(raw); err != nil {
+						*errs = append(*errs, fmt.Errorf("%s: invalid duration %q: %w", fieldPath, raw, err))
+					}
+				}
+				continue
+			}
+			checkDurationFields(fv, fieldPath, errs)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			checkDurationFields(v.MapIndex(key), joinPath(path, fmt.Sprint(key)), errs)
+		}
+	}
+}
+
+func hasDurationSuffix(name string) bool {
+	for _, suffix := range []string{"Timeout", "Interval", "Validity"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkChainConfigs requires an RPCEndpoint on every enabled chain, and
+// requires the sum of enabled chains' Weight to land in (0, +Inf) - zero
+// or negative weights would make aggregation's weighted averages
+// meaningless, and a NaN/Inf weight propagates silently otherwise.
+func checkChainConfigs(chains reflect.Value, errs *Errors) {
+	if !chains.IsValid() || chains.Kind() != reflect.Map {
+		return
+	}
+
+	var weightSum float64
+	for _, key := range chains.MapKeys() {
+		chain := chains.MapIndex(key)
+		name := fmt.Sprint(key)
+
+		enabled := chain.FieldByName("Enabled")
+		if !enabled.IsValid() || !enabled.Bool() {
+			continue
+		}
+
+		if rpc := chain.FieldByName("RPCEndpoint"); rpc.IsValid() && rpc.String() == "" {
+			*errs = append(*errs, fmt.Errorf("chains.%s: rpc_endpoint is required when enabled is true", name))
+		}
+
+		if weight := chain.FieldByName("Weight"); weight.IsValid() {
+			weightSum += weight.Float()
+		}
+	}
+
+	if weightSum <= 0 || math.IsInf(weightSum, 0) || math.IsNaN(weightSum) {
+		*errs = append(*errs, fmt.Errorf("chains: enabled chain weights must sum to a finite value greater than 0, got %v", weightSum))
+	}
+}
+
+// checkKafka requires at least one broker address whenever the Kafka
+// exporter is enabled - the zero-value empty slice would otherwise reach
+// the producer and fail far from where the misconfiguration was made.
+func checkKafka(metricsExport reflect.Value, errs *Errors) {
+	if !metricsExport.IsValid() {
+		return
+	}
+	enabled := metricsExport.FieldByName("KafkaEnabled")
+	if !enabled.IsValid() || !enabled.Bool() {
+		return
+	}
+	brokers := metricsExport.FieldByName("KafkaBrokers")
+	if !brokers.IsValid() || brokers.Len() == 0 {
+		*errs = append(*errs, fmt.Errorf("metrics_export.kafka_brokers: required when kafka_enabled is true"))
+	}
+}
+
+// checkOCR requires a contract and transmitter address whenever OCR
+// support is enabled - without both, the OCR subsystem has nothing to
+// transmit to or sign as.
+func checkOCR(ocr reflect.Value, errs *Errors) {
+	if !ocr.IsValid() {
+		return
+	}
+	enabled := ocr.FieldByName("Enabled")
+	if !enabled.IsValid() || !enabled.Bool() {
+		return
+	}
+	if addr := ocr.FieldByName("ContractAddress"); !addr.IsValid() || addr.String() == "" {
+		*errs = append(*errs, fmt.Errorf("ocr.contract_address: required when ocr.enabled is true"))
+	}
+	if addr := ocr.FieldByName("TransmitterAddress"); !addr.IsValid() || addr.String() == "" {
+		*errs = append(*errs, fmt.Errorf("ocr.transmitter_address: required when ocr.enabled is true"))
+	}
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// ReloadFunc parses the raw bytes of a config file (already normalized to
+// JSON by Watcher) and applies them. Implementations should leave any
+// previously applied config untouched when they return an error, so a bad
+// edit never takes the service down.
+type ReloadFunc func(data []byte) error
+
+// Watcher loads a YAML or JSON config file and watches it via fsnotify,
+// re-invoking a ReloadFunc on every change so long-running services (like
+// the EA server) can pick up new aggregation modes, timeouts, rate limits,
+// circuit-breaker thresholds or provider weights without a restart.
+type Watcher struct {
+	path   string
+	reload ReloadFunc
+	fsw    *fsnotify.Watcher
+	done   chan struct{}
+
+	// OnSuccess and OnFailure, if set, are invoked after each reload attempt
+	// so callers can update metrics or log structured reload events.
+	OnSuccess func()
+	OnFailure func(err error)
+
+	subMu       sync.Mutex
+	subscribers []chan []byte
+}
+
+// NewWatcher creates a Watcher for path, performing an initial load before
+// returning so callers start from the parsed file rather than zero values.
+func NewWatcher(path string, reload ReloadFunc) (*Watcher, error) {
+	w := &Watcher{path: path, reload: reload, done: make(chan struct{})}
+
+	if _, err := w.loadOnce(); err != nil {
+		return nil, fmt.Errorf("initial config load: %w", err)
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating fsnotify watcher: %w", err)
+	}
+	// Watch the containing directory rather than the file itself: editors
+	// commonly replace a config file via rename-into-place, which would
+	// otherwise orphan a watch held directly on the old inode.
+	if err := fsw.Add(filepath.Dir(path)); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("watching %s: %w", filepath.Dir(path), err)
+	}
+	w.fsw = fsw
+
+	return w, nil
+}
+
+// Start runs the watch loop in the background until ctx is cancelled or
+// Stop is called.
+func (w *Watcher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.done:
+				return
+			case event, ok := <-w.fsw.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(w.path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				w.handleReload()
+			case err, ok := <-w.fsw.Errors:
+				if !ok {
+					return
+				}
+				logging.Warnf("config watcher error: %v", err)
+			}
+		}
+	}()
+}
+
+// Stop terminates the watch loop and releases the fsnotify watcher.
+func (w *Watcher) Stop() {
+	close(w.done)
+	if w.fsw != nil {
+		w.fsw.Close()
+	}
+}
+
+// TriggerReload re-reads and re-applies the config file on demand, e.g. from
+// a SIGHUP handler for deployments where fsnotify doesn't fire reliably
+// (some network filesystems, certain container bind-mount setups).
+func (w *Watcher) TriggerReload() {
+	w.handleReload()
+}
+
+func (w *Watcher) handleReload() {
+	normalized, err := w.loadOnce()
+	if err != nil {
+		logging.WithError(err).Warn("config reload failed, keeping previous config")
+		if w.OnFailure != nil {
+			w.OnFailure(err)
+		}
+		return
+	}
+
+	logging.WithField("path", w.path).Info("config reloaded successfully")
+	if w.OnSuccess != nil {
+		w.OnSuccess()
+	}
+	w.notifySubscribers(normalized)
+}
+
+// Subscribe returns a channel that receives the normalized (JSON) bytes of
+// every successful reload, for consumers that want to re-arm themselves
+// from the new config rather than reading it once at startup. The channel
+// is buffered; a slow subscriber drops new reloads rather than blocking the
+// watch loop.
+func (w *Watcher) Subscribe() <-chan []byte {
+	ch := make(chan []byte, 1)
+	w.subMu.Lock()
+	w.subscribers = append(w.subscribers, ch)
+	w.subMu.Unlock()
+	return ch
+}
+
+func (w *Watcher) notifySubscribers(data []byte) {
+	w.subMu.Lock()
+	defer w.subMu.Unlock()
+	for _, ch := range w.subscribers {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
+
+func (w *Watcher) loadOnce() ([]byte, error) {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", w.path, err)
+	}
+
+	normalized := data
+	if ext := strings.ToLower(filepath.Ext(w.path)); ext == ".yaml" || ext == ".yml" {
+		var generic map[string]interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return nil, fmt.Errorf("parsing yaml: %w", err)
+		}
+		if normalized, err = json.Marshal(generic); err != nil {
+			return nil, fmt.Errorf("normalizing yaml to json: %w", err)
+		}
+	}
+
+	if err := w.reload(normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -0,0 +1,341 @@
+package enterprise
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+)
+
+const (
+	// cloudWatchMaxMetricsPerRequest is the PutMetricData per-call limit.
+	cloudWatchMaxMetricsPerRequest = 1000
+
+	// cloudWatchMaxPayloadBytes is the PutMetricData per-call payload limit.
+	cloudWatchMaxPayloadBytes = 40 * 1024
+
+	// awsMaxRetries bounds the exponential backoff retry loop for both
+	// CloudWatch and S3 calls before falling back to the dead-letter file.
+	awsMaxRetries = 5
+
+	// awsDeadLetterFile holds metrics that persistently failed to export to
+	// AWS, so they aren't silently dropped.
+	awsDeadLetterFile = "aws_export_deadletter.jsonl"
+)
+
+// cloudWatchPutter is the subset of the CloudWatch API the exporter needs,
+// so it can be swapped for a fake in tests without real AWS infrastructure.
+type cloudWatchPutter interface {
+	PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error)
+}
+
+// s3Putter is the subset of the S3 API the exporter needs.
+type s3Putter interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// awsClients bundles the CloudWatch and S3 handles built from ExporterConfig.
+type awsClients struct {
+	cloudWatch cloudWatchPutter
+	s3         s3Putter
+}
+
+// newAWSClients builds CloudWatch and S3 clients using static credentials
+// when AWSAccessKey/AWSSecretKey are set, falling back to the SDK's default
+// chain (IAM instance profile, environment, shared config, STS AssumeRole)
+// otherwise.
+func newAWSClients(cfg ExporterConfig) (*awsClients, error) {
+	ctx := context.Background()
+
+	optFns := []func(*config.LoadOptions) error{
+		config.WithRegion(cfg.AWSRegion),
+	}
+	if cfg.AWSAccessKey != "" && cfg.AWSSecretKey != "" {
+		optFns = append(optFns, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AWSAccessKey, cfg.AWSSecretKey, ""),
+		))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return &awsClients{
+		cloudWatch: cloudwatch.NewFromConfig(awsCfg),
+		s3:         s3.NewFromConfig(awsCfg),
+	}, nil
+}
+
+// exportToAWS publishes metrics to CloudWatch (as datapoints) and S3 (as a
+// gzip-compressed newline-delimited JSON object), retrying throttled calls
+// with exponential backoff and falling back to a local dead-letter file when
+// an upload fails persistently.
+func (e *MetricsExporter) exportToAWS(metrics []interface{}) error {
+	if !e.config.AWSEnabled {
+		return fmt.Errorf("AWS export not configured")
+	}
+
+	if e.awsClients == nil {
+		clients, err := newAWSClients(e.config)
+		if err != nil {
+			e.writeDeadLetter(metrics, err)
+			return fmt.Errorf("AWS clients unavailable: %w", err)
+		}
+		e.awsClients = clients
+	}
+
+	var errs []string
+
+	if err := e.exportToCloudWatch(metrics); err != nil {
+		errs = append(errs, fmt.Sprintf("cloudwatch: %v", err))
+	}
+
+	if err := e.exportToS3(metrics); err != nil {
+		errs = append(errs, fmt.Sprintf("s3: %v", err))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("AWS export errors: %s", strings.Join(errs, "; "))
+	}
+
+	logging.Infof("Exported %d metrics to AWS CloudWatch and S3", len(metrics))
+	return nil
+}
+
+// exportToCloudWatch batches metrics into PutMetricData calls respecting the
+// 1000-metrics-per-request and 40 KB payload limits.
+func (e *MetricsExporter) exportToCloudWatch(metrics []interface{}) error {
+	datums := make([]cwtypes.MetricDatum, 0, len(metrics))
+	for _, metric := range metrics {
+		datums = append(datums, metricToDatums(metric)...)
+	}
+
+	var lastErr error
+	for start := 0; start < len(datums); {
+		batch, size := nextCloudWatchBatch(datums[start:])
+		if len(batch) == 0 {
+			break
+		}
+		start += len(batch)
+
+		if err := e.putMetricDataWithRetry(batch); err != nil {
+			lastErr = err
+			e.writeDeadLetter(datumsAsInterfaces(batch), err)
+		}
+		_ = size
+	}
+
+	return lastErr
+}
+
+// nextCloudWatchBatch slices off as many leading datums as fit within the
+// per-request count and payload-size limits.
+func nextCloudWatchBatch(datums []cwtypes.MetricDatum) ([]cwtypes.MetricDatum, int) {
+	size := 0
+	count := 0
+	for count < len(datums) && count < cloudWatchMaxMetricsPerRequest {
+		encoded, err := json.Marshal(datums[count])
+		if err != nil {
+			break
+		}
+		if size+len(encoded) > cloudWatchMaxPayloadBytes && count > 0 {
+			break
+		}
+		size += len(encoded)
+		count++
+	}
+	return datums[:count], size
+}
+
+func (e *MetricsExporter) putMetricDataWithRetry(batch []cwtypes.MetricDatum) error {
+	input := &cloudwatch.PutMetricDataInput{
+		Namespace:  aws.String(e.config.CloudwatchGroup),
+		MetricData: batch,
+	}
+
+	var err error
+	for attempt := 0; attempt < awsMaxRetries; attempt++ {
+		_, err = e.awsClients.cloudWatch.PutMetricData(context.Background(), input)
+		if err == nil {
+			return nil
+		}
+		if !isThrottlingError(err) {
+			return err
+		}
+		time.Sleep(awsBackoff(attempt))
+	}
+	return fmt.Errorf("cloudwatch PutMetricData exhausted retries: %w", err)
+}
+
+// metricToDatums maps a single model.Metric-shaped value into one CloudWatch
+// datum per numeric field, dimensioned by Provider.
+func metricToDatums(metric interface{}) []cwtypes.MetricDatum {
+	encoded, err := json.Marshal(metric)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(encoded, &m); err != nil {
+		return nil
+	}
+
+	provider, _ := m["provider"].(string)
+	dims := []cwtypes.Dimension{{Name: aws.String("Provider"), Value: aws.String(provider)}}
+	now := time.Now()
+
+	fields := []struct {
+		name string
+		key  string
+		unit cwtypes.StandardUnit
+	}{
+		{"APY", "apy", cwtypes.StandardUnitPercent},
+		{"TVL", "tvl", cwtypes.StandardUnitNone},
+		{"PointsPerETH", "points_per_eth", cwtypes.StandardUnitNone},
+	}
+
+	datums := make([]cwtypes.MetricDatum, 0, len(fields))
+	for _, f := range fields {
+		v, ok := m[f.key].(float64)
+		if !ok {
+			continue
+		}
+		datums = append(datums, cwtypes.MetricDatum{
+			MetricName: aws.String(f.name),
+			Value:      aws.Float64(v),
+			Unit:       f.unit,
+			Dimensions: dims,
+			Timestamp:  aws.Time(now),
+		})
+	}
+	return datums
+}
+
+func datumsAsInterfaces(datums []cwtypes.MetricDatum) []interface{} {
+	out := make([]interface{}, len(datums))
+	for i, d := range datums {
+		out[i] = d
+	}
+	return out
+}
+
+// exportToS3 buffers the batch into gzip-compressed newline-delimited JSON
+// and uploads it under a time-partitioned key.
+func (e *MetricsExporter) exportToS3(metrics []interface{}) error {
+	if e.config.S3Bucket == "" {
+		return fmt.Errorf("S3 bucket not configured")
+	}
+
+	body, err := gzipNDJSON(metrics)
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics for S3: %w", err)
+	}
+
+	now := time.Now().UTC()
+	key := fmt.Sprintf("%s/dt=%s/hh=%s/%s.jsonl.gz",
+		strings.TrimSuffix(e.config.S3KeyPrefix, "/"),
+		now.Format("2006-01-02"),
+		now.Format("15"),
+		uuid.NewString(),
+	)
+
+	input := &s3.PutObjectInput{
+		Bucket:          aws.String(e.config.S3Bucket),
+		Key:             aws.String(key),
+		Body:            bytes.NewReader(body),
+		ContentType:     aws.String("application/x-ndjson"),
+		ContentEncoding: aws.String("gzip"),
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < awsMaxRetries; attempt++ {
+		_, lastErr = e.awsClients.s3.PutObject(context.Background(), input)
+		if lastErr == nil {
+			return nil
+		}
+		if !isThrottlingError(lastErr) {
+			break
+		}
+		time.Sleep(awsBackoff(attempt))
+	}
+
+	e.writeDeadLetter(metrics, lastErr)
+	return fmt.Errorf("s3 PutObject failed for key %s: %w", key, lastErr)
+}
+
+func gzipNDJSON(metrics []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+
+	for _, metric := range metrics {
+		line, err := json.Marshal(metric)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := gz.Write(line); err != nil {
+			return nil, err
+		}
+		if _, err := gz.Write([]byte("\n")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isThrottlingError reports whether err looks like an AWS throttling
+// response, worth retrying with backoff rather than failing immediately.
+func isThrottlingError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "Throttling") || strings.Contains(msg, "TooManyRequests") || strings.Contains(msg, "RequestLimitExceeded")
+}
+
+// awsBackoff returns an exponential backoff delay for the given retry attempt.
+func awsBackoff(attempt int) time.Duration {
+	delay := time.Duration(math.Pow(2, float64(attempt))) * 100 * time.Millisecond
+	if delay > 10*time.Second {
+		delay = 10 * time.Second
+	}
+	return delay
+}
+
+// writeDeadLetter appends metrics that failed to export persistently to a
+// local file so they aren't silently lost.
+func (e *MetricsExporter) writeDeadLetter(metrics []interface{}, cause error) {
+	f, err := os.OpenFile(awsDeadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		logging.Errorf("Failed to open AWS dead-letter file: %v", err)
+		return
+	}
+	defer f.Close()
+
+	for _, metric := range metrics {
+		line, err := json.Marshal(metric)
+		if err != nil {
+			continue
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			logging.Errorf("Failed to write AWS dead-letter entry: %v", err)
+			return
+		}
+	}
+
+	logging.Warnf("Wrote %d metrics to AWS dead-letter file after export failure: %v", len(metrics), cause)
+}
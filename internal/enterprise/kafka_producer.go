@@ -0,0 +1,216 @@
+package enterprise
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/IBM/sarama"
+	kafkaexporter "github.com/yourorg/restake-yield-ea/internal/exporter/kafka"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+)
+
+// newKafkaOTLPSink builds the OTLP-protobuf Kafka sink from cfg's Kafka
+// connection settings and KafkaOTLP* topics. It reuses the same broker/SASL/
+// TLS settings as the raw-JSON Kafka export (newSaramaProducer) since both
+// paths talk to the same cluster; only the topics and wire format differ.
+func newKafkaOTLPSink(cfg ExporterConfig) (*kafkaexporter.Exporter, error) {
+	return kafkaexporter.NewKafkaExporter(kafkaexporter.Config{
+		Brokers:       cfg.KafkaBrokers,
+		Topic:         cfg.KafkaTopic,
+		MetricsTopic:  cfg.KafkaOTLPMetricsTopic,
+		LogsTopic:     cfg.KafkaOTLPLogsTopic,
+		TracesTopic:   cfg.KafkaOTLPTracesTopic,
+		Username:      cfg.KafkaUsername,
+		Password:      cfg.KafkaPassword,
+		SASLMechanism: cfg.KafkaSASLMechanism,
+		TLSEnabled:    cfg.KafkaTLSEnabled,
+		TLSMinVersion: cfg.KafkaTLSMinVersion,
+		RequiredAcks:  cfg.KafkaRequiredAcks,
+		RetryMax:      cfg.KafkaRetryMax,
+		Compression:   cfg.KafkaCompression,
+	})
+}
+
+// kafkaProducer is the narrow interface the exporter depends on so that a fake
+// can be swapped in during tests instead of talking to real brokers.
+type kafkaProducer interface {
+	SendMessage(key, value []byte) error
+	Close() error
+}
+
+// saramaProducer wraps a sarama.SyncProducer behind the kafkaProducer interface.
+type saramaProducer struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+func newSaramaProducer(cfg ExporterConfig) (*saramaProducer, error) {
+	if len(cfg.KafkaBrokers) == 0 {
+		return nil, fmt.Errorf("no Kafka brokers configured")
+	}
+
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.Return.Errors = true
+	saramaCfg.Producer.RequiredAcks = kafkaAcksFromConfig(cfg.KafkaRequiredAcks)
+	saramaCfg.Producer.Retry.Max = kafkaRetryMaxOrDefault(cfg.KafkaRetryMax)
+	saramaCfg.Producer.Compression = kafkaCompressionFromConfig(cfg.KafkaCompression)
+
+	if cfg.KafkaUsername != "" {
+		saramaCfg.Net.SASL.Enable = true
+		saramaCfg.Net.SASL.User = cfg.KafkaUsername
+		saramaCfg.Net.SASL.Password = cfg.KafkaPassword
+
+		switch cfg.KafkaSASLMechanism {
+		case "SCRAM-SHA-256", "SCRAM-SHA-512":
+			saramaCfg.Net.SASL.Mechanism = sarama.SASLMechanism(cfg.KafkaSASLMechanism)
+			saramaCfg.Net.SASL.Handshake = true
+			saramaCfg.Net.SASL.SCRAMClientGeneratorFunc = scramClientGeneratorFor(cfg.KafkaSASLMechanism)
+		default:
+			saramaCfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		}
+	}
+
+	if cfg.KafkaTLSEnabled {
+		saramaCfg.Net.TLS.Enable = true
+		saramaCfg.Net.TLS.Config = &tls.Config{
+			MinVersion: kafkaTLSMinVersion(cfg.KafkaTLSMinVersion),
+		}
+	}
+
+	producer, err := sarama.NewSyncProducer(cfg.KafkaBrokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+
+	return &saramaProducer{topic: cfg.KafkaTopic, producer: producer}, nil
+}
+
+func (p *saramaProducer) SendMessage(key, value []byte) error {
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.ByteEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	})
+	return err
+}
+
+func (p *saramaProducer) Close() error {
+	return p.producer.Close()
+}
+
+func kafkaAcksFromConfig(acks string) sarama.RequiredAcks {
+	switch acks {
+	case "none":
+		return sarama.NoResponse
+	case "leader":
+		return sarama.WaitForLocal
+	case "all":
+		return sarama.WaitForAll
+	default:
+		return sarama.WaitForLocal
+	}
+}
+
+func kafkaRetryMaxOrDefault(retryMax int) int {
+	if retryMax <= 0 {
+		return 3
+	}
+	return retryMax
+}
+
+func kafkaCompressionFromConfig(codec string) sarama.CompressionCodec {
+	switch codec {
+	case "snappy":
+		return sarama.CompressionSnappy
+	case "lz4":
+		return sarama.CompressionLZ4
+	case "gzip":
+		return sarama.CompressionGZIP
+	default:
+		return sarama.CompressionNone
+	}
+}
+
+func kafkaTLSMinVersion(version string) uint16 {
+	switch version {
+	case "1.3":
+		return tls.VersionTLS13
+	case "1.1":
+		return tls.VersionTLS11
+	case "1.0":
+		return tls.VersionTLS10
+	default:
+		return tls.VersionTLS12
+	}
+}
+
+// kafkaStatus tracks delivery outcomes so GetExporterStatus can surface them.
+type kafkaStatus struct {
+	successCount uint64
+	failureCount uint64
+	mu           sync.RWMutex
+	lastError    string
+}
+
+func (s *kafkaStatus) recordSuccess() {
+	atomic.AddUint64(&s.successCount, 1)
+}
+
+func (s *kafkaStatus) recordFailure(err error) {
+	atomic.AddUint64(&s.failureCount, 1)
+	s.mu.Lock()
+	s.lastError = err.Error()
+	s.mu.Unlock()
+}
+
+func (s *kafkaStatus) snapshot() map[string]interface{} {
+	s.mu.RLock()
+	lastErr := s.lastError
+	s.mu.RUnlock()
+
+	return map[string]interface{}{
+		"success_count": atomic.LoadUint64(&s.successCount),
+		"failure_count": atomic.LoadUint64(&s.failureCount),
+		"last_error":    lastErr,
+	}
+}
+
+// kafkaQueue is a bounded in-memory queue with drop-oldest semantics so that
+// AddMetricBatch never blocks waiting on a slow or unreachable broker.
+type kafkaQueue struct {
+	mu       sync.Mutex
+	items    [][]interface{}
+	capacity int
+	dropped  uint64
+}
+
+func newKafkaQueue(capacity int) *kafkaQueue {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &kafkaQueue{capacity: capacity}
+}
+
+func (q *kafkaQueue) push(batch []interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.items) >= q.capacity {
+		q.items = q.items[1:]
+		q.dropped++
+		logging.Warn("Kafka export queue full, dropping oldest batch")
+	}
+	q.items = append(q.items, batch)
+}
+
+func (q *kafkaQueue) drain() [][]interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	drained := q.items
+	q.items = nil
+	return drained
+}
@@ -0,0 +1,53 @@
+package enterprise
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+)
+
+// xdgSCRAMClient adapts xdg-go/scram to sarama's SCRAMClient interface so
+// SASL/SCRAM-SHA-256 and SCRAM-SHA-512 can be used against brokers that
+// require it.
+type xdgSCRAMClient struct {
+	*scram.Client
+	*scram.ClientConversation
+	scram.HashGeneratorFcn
+}
+
+func (c *xdgSCRAMClient) Begin(userName, password, authzID string) error {
+	client, err := c.HashGeneratorFcn.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.Client = client
+	c.ClientConversation = c.Client.NewConversation()
+	return nil
+}
+
+func (c *xdgSCRAMClient) Step(challenge string) (string, error) {
+	return c.ClientConversation.Step(challenge)
+}
+
+func (c *xdgSCRAMClient) Done() bool {
+	return c.ClientConversation.Done()
+}
+
+func sha256HashGenerator() hash.Hash { return sha256.New() }
+func sha512HashGenerator() hash.Hash { return sha512.New() }
+
+// scramClientGeneratorFor returns the sarama SCRAMClientGeneratorFunc matching
+// the configured mechanism.
+func scramClientGeneratorFor(mechanism string) func() sarama.SCRAMClient {
+	hashGenerator := scram.SHA256
+	if mechanism == "SCRAM-SHA-512" {
+		hashGenerator = scram.SHA512
+	}
+
+	return func() sarama.SCRAMClient {
+		return &xdgSCRAMClient{HashGeneratorFcn: hashGenerator}
+	}
+}
@@ -8,11 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // MetricsExporter provides enterprise-grade metrics export capabilities
@@ -25,6 +24,28 @@ type MetricsExporter struct {
 	exportInterval   time.Duration
 	exportContext    context.Context
 	exportCancel     context.CancelFunc
+
+	// Kafka export state. kafkaMu guards kafkaProducer/kafkaQueue
+	// specifically, separate from mutex's batchMetrics, since exportToKafka
+	// can run concurrently from AddMetricBatch's fire-and-forget
+	// exportMetrics goroutine and periodicExport's ticker, and both lazily
+	// initialize kafkaProducer/kafkaQueue on first use.
+	kafkaMu       sync.Mutex
+	kafkaProducer kafkaProducer
+	kafkaQueue    *kafkaQueue
+	kafkaStatus   kafkaStatus
+
+	// AWS export state; built lazily on first export so a missing/invalid
+	// AWS config doesn't fail exporter construction.
+	awsClients *awsClients
+
+	// sinks are the Sink-interface destinations (OTLP, StatsD, webhook) fanned
+	// out to in parallel by exportMetrics, each with its own retry/backoff and
+	// status counter. AWS and Kafka predate the Sink interface and keep their
+	// own dedicated export paths above.
+	sinks        []Sink
+	sinkMu       sync.RWMutex
+	sinkStatuses map[string]*sinkStatus
 }
 
 // ExporterConfig holds configuration for metrics exporting
@@ -51,11 +72,39 @@ type ExporterConfig struct {
 	WebhookFormat   string `json:"webhook_format"`
 	
 	// Kafka settings
-	KafkaEnabled    bool     `json:"kafka_enabled"`
-	KafkaBrokers    []string `json:"kafka_brokers"`
-	KafkaTopic      string   `json:"kafka_topic"`
-	KafkaUsername   string   `json:"kafka_username,omitempty"`
-	KafkaPassword   string   `json:"kafka_password,omitempty"`
+	KafkaEnabled       bool     `json:"kafka_enabled"`
+	KafkaBrokers       []string `json:"kafka_brokers"`
+	KafkaTopic         string   `json:"kafka_topic"`
+	KafkaUsername      string   `json:"kafka_username,omitempty"`
+	KafkaPassword      string   `json:"kafka_password,omitempty"`
+	KafkaSASLMechanism string   `json:"kafka_sasl_mechanism,omitempty"` // "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"
+	KafkaTLSEnabled    bool     `json:"kafka_tls_enabled"`
+	KafkaTLSMinVersion string   `json:"kafka_tls_min_version,omitempty"` // "1.0".."1.3", defaults to 1.2
+	KafkaRequiredAcks  string   `json:"kafka_required_acks,omitempty"`   // "none", "leader", "all"
+	KafkaRetryMax      int      `json:"kafka_retry_max,omitempty"`
+	KafkaCompression   string   `json:"kafka_compression,omitempty"` // "none", "snappy", "lz4", "gzip"
+	KafkaQueueCapacity int      `json:"kafka_queue_capacity,omitempty"`
+
+	// Kafka OTLP export settings. Separate from KafkaEnabled's raw JSON
+	// export above: this streams the same batches OTLP-protobuf-encoded via
+	// internal/exporter/kafka, for pipelines that consume OTLP directly.
+	// Connection settings (brokers, SASL, TLS) are shared with KafkaEnabled.
+	KafkaOTLPEnabled      bool   `json:"kafka_otlp_enabled"`
+	KafkaOTLPMetricsTopic string `json:"kafka_otlp_metrics_topic,omitempty"`
+	KafkaOTLPLogsTopic    string `json:"kafka_otlp_logs_topic,omitempty"`
+	KafkaOTLPTracesTopic  string `json:"kafka_otlp_traces_topic,omitempty"`
+
+	// OTLP settings
+	OTLPEnabled     bool   `json:"otlp_enabled"`
+	OTLPEndpoint    string `json:"otlp_endpoint"`
+	OTLPInsecure    bool   `json:"otlp_insecure"`
+	OTLPServiceName string `json:"otlp_service_name,omitempty"`
+
+	// StatsD/DogStatsD settings
+	StatsDEnabled   bool     `json:"statsd_enabled"`
+	StatsDAddress   string   `json:"statsd_address"`
+	StatsDNamespace string   `json:"statsd_namespace,omitempty"`
+	StatsDTags      []string `json:"statsd_tags,omitempty"`
 }
 
 // NewMetricsExporter creates a new metrics exporter
@@ -85,12 +134,72 @@ func NewMetricsExporter(config ExporterConfig) (*MetricsExporter, error) {
 		batchMetrics:   make([]interface{}, 0, config.BatchSize),
 		exportInterval: exportInterval,
 	}
-	
+
+	if config.KafkaEnabled {
+		exporter.kafkaQueue = newKafkaQueue(config.KafkaQueueCapacity)
+
+		producer, err := newSaramaProducer(config)
+		if err != nil {
+			// Don't fail exporter construction over a broker being down; the
+			// bounded queue keeps buffering until a producer can be attached.
+			logging.Warnf("Kafka producer unavailable at startup, will buffer: %v", err)
+		} else {
+			exporter.kafkaProducer = producer
+		}
+	}
+
+	if config.AWSEnabled {
+		clients, err := newAWSClients(config)
+		if err != nil {
+			// Don't fail exporter construction over AWS credentials being
+			// unavailable at startup; exportToAWS retries client setup lazily.
+			logging.Warnf("AWS clients unavailable at startup, will retry on export: %v", err)
+		} else {
+			exporter.awsClients = clients
+		}
+	}
+
+	if config.OTLPEnabled {
+		sink, err := newOTLPSink(config)
+		if err != nil {
+			logging.Warnf("OTLP exporter unavailable at startup, skipping: %v", err)
+		} else {
+			exporter.sinks = append(exporter.sinks, sink)
+		}
+	}
+
+	if config.KafkaOTLPEnabled {
+		sink, err := newKafkaOTLPSink(config)
+		if err != nil {
+			logging.Warnf("Kafka OTLP exporter unavailable at startup, skipping: %v", err)
+		} else {
+			exporter.sinks = append(exporter.sinks, sink)
+		}
+	}
+
+	if config.StatsDEnabled {
+		sink, err := newStatsDSink(config)
+		if err != nil {
+			logging.Warnf("StatsD exporter unavailable at startup, skipping: %v", err)
+		} else {
+			exporter.sinks = append(exporter.sinks, sink)
+		}
+	}
+
+	if config.WebhookEnabled {
+		exporter.sinks = append(exporter.sinks, &webhookSink{exporter: exporter})
+	}
+
+	exporter.sinkStatuses = make(map[string]*sinkStatus, len(exporter.sinks))
+	for _, sink := range exporter.sinks {
+		exporter.sinkStatuses[sink.Name()] = &sinkStatus{}
+	}
+
 	// Start background task for periodic exports
 	exporter.exportContext, exporter.exportCancel = context.WithCancel(context.Background())
 	go exporter.periodicExport()
 	
-	logrus.Info("Enterprise metrics exporter initialized")
+	logging.Info("Enterprise metrics exporter initialized")
 	return exporter, nil
 }
 
@@ -152,19 +261,19 @@ func (e *MetricsExporter) exportMetrics() {
 		go func() {
 			defer wg.Done()
 			if err := e.exportToAWS(metrics); err != nil {
-				logrus.Errorf("Failed to export to AWS: %v", err)
+				logging.Errorf("Failed to export to AWS: %v", err)
 			}
 		}()
 	}
 	
-	if e.config.WebhookEnabled {
+	for _, sink := range e.sinks {
 		wg.Add(1)
-		go func() {
+		go func(s Sink) {
 			defer wg.Done()
-			if err := e.exportToWebhook(metrics); err != nil {
-				logrus.Errorf("Failed to export to webhook: %v", err)
+			if err := e.exportToSinkWithRetry(s, metrics); err != nil {
+				logging.Errorf("Failed to export to %s: %v", s.Name(), err)
 			}
-		}()
+		}(sink)
 	}
 	
 	if e.config.KafkaEnabled {
@@ -172,23 +281,27 @@ func (e *MetricsExporter) exportMetrics() {
 		go func() {
 			defer wg.Done()
 			if err := e.exportToKafka(metrics); err != nil {
-				logrus.Errorf("Failed to export to Kafka: %v", err)
+				logging.Errorf("Failed to export to Kafka: %v", err)
 			}
 		}()
 	}
 	
 	wg.Wait()
-	logrus.Infof("Exported %d metrics to enterprise endpoints", len(metrics))
+	logging.Infof("Exported %d metrics to enterprise endpoints", len(metrics))
 }
 
-// exportToAWS exports metrics to AWS CloudWatch and S3
-func (e *MetricsExporter) exportToAWS(metrics []interface{}) error {
-	// In a real implementation, this would use the AWS SDK to export metrics
-	// to CloudWatch and S3. For this example, we'll just log the operation.
-	logrus.Infof("Would export %d metrics to AWS CloudWatch and S3", len(metrics))
-	return nil
+// webhookSink adapts exportToWebhook to the Sink interface so it fans out
+// alongside OTLP and StatsD with the same retry/backoff and status counter.
+type webhookSink struct {
+	exporter *MetricsExporter
+}
+
+func (w *webhookSink) Export(ctx context.Context, batch []interface{}) error {
+	return w.exporter.exportToWebhook(batch)
 }
 
+func (w *webhookSink) Name() string { return "webhook" }
+
 // exportToWebhook exports metrics to a webhook endpoint
 func (e *MetricsExporter) exportToWebhook(metrics []interface{}) error {
 	if e.config.WebhookURL == "" {
@@ -238,16 +351,65 @@ func (e *MetricsExporter) exportToWebhook(metrics []interface{}) error {
 	return nil
 }
 
-// exportToKafka exports metrics to a Kafka topic
+// exportToKafka exports metrics to a Kafka topic. AddMetricBatch never blocks
+// on this: batches always land in the bounded kafkaQueue first (drop-oldest
+// if the broker is unreachable) and this drains whatever has queued up so
+// far, reconnecting lazily if the producer was never established.
 func (e *MetricsExporter) exportToKafka(metrics []interface{}) error {
 	if !e.config.KafkaEnabled || len(e.config.KafkaBrokers) == 0 {
 		return fmt.Errorf("Kafka not configured")
 	}
-	
-	// Log the data that would be sent to Kafka
-	logrus.Infof("Would export %d metrics to Kafka topic %s at brokers %s", 
-		len(metrics), e.config.KafkaTopic, strings.Join(e.config.KafkaBrokers, ","))
-	
+
+	e.kafkaMu.Lock()
+	defer e.kafkaMu.Unlock()
+
+	if e.kafkaQueue == nil {
+		e.kafkaQueue = newKafkaQueue(e.config.KafkaQueueCapacity)
+	}
+	e.kafkaQueue.push(metrics)
+
+	if e.kafkaProducer == nil {
+		producer, err := newSaramaProducer(e.config)
+		if err != nil {
+			e.kafkaStatus.recordFailure(err)
+			return fmt.Errorf("Kafka producer unavailable: %w", err)
+		}
+		e.kafkaProducer = producer
+	}
+
+	var lastErr error
+	for _, batch := range e.kafkaQueue.drain() {
+		for _, metric := range batch {
+			key := kafkaMessageKey(metric)
+
+			value, err := json.Marshal(metric)
+			if err != nil {
+				e.kafkaStatus.recordFailure(err)
+				lastErr = err
+				continue
+			}
+
+			if err := e.kafkaProducer.SendMessage(key, value); err != nil {
+				e.kafkaStatus.recordFailure(err)
+				lastErr = err
+				continue
+			}
+			e.kafkaStatus.recordSuccess()
+		}
+	}
+
+	logging.Infof("Exported %d metrics to Kafka topic %s", len(metrics), e.config.KafkaTopic)
+	return lastErr
+}
+
+// kafkaMessageKey derives the per-message partition key from the metric's
+// Provider field so all observations for a provider land on the same partition.
+func kafkaMessageKey(metric interface{}) []byte {
+	if m, ok := metric.(map[string]interface{}); ok {
+		if provider, ok := m["provider"].(string); ok {
+			return []byte(provider)
+		}
+	}
 	return nil
 }
 
@@ -259,6 +421,17 @@ func (e *MetricsExporter) Stop() {
 	
 	// Export any remaining metrics
 	e.exportMetrics()
+
+	e.kafkaMu.Lock()
+	producer := e.kafkaProducer
+	e.kafkaMu.Unlock()
+	if producer != nil {
+		if err := producer.Close(); err != nil {
+			logging.Warnf("Failed to close Kafka producer: %v", err)
+		}
+	}
+
+	e.closeSinks()
 }
 
 // GetExporterStatus returns the current status of the exporter
@@ -267,19 +440,36 @@ func (e *MetricsExporter) GetExporterStatus() map[string]interface{} {
 	defer e.mutex.RUnlock()
 	
 	status := map[string]interface{}{
-		"enabled":          e.config.Enabled,
-		"batch_size":       e.config.BatchSize,
-		"export_interval":  e.exportInterval.String(),
-		"current_batch":    len(e.batchMetrics),
-		"aws_enabled":      e.config.AWSEnabled,
-		"webhook_enabled":  e.config.WebhookEnabled,
-		"kafka_enabled":    e.config.KafkaEnabled,
+		"enabled":            e.config.Enabled,
+		"batch_size":         e.config.BatchSize,
+		"export_interval":    e.exportInterval.String(),
+		"current_batch":      len(e.batchMetrics),
+		"aws_enabled":        e.config.AWSEnabled,
+		"webhook_enabled":    e.config.WebhookEnabled,
+		"kafka_enabled":      e.config.KafkaEnabled,
+		"kafka_otlp_enabled": e.config.KafkaOTLPEnabled,
+		"otlp_enabled":       e.config.OTLPEnabled,
+		"statsd_enabled":     e.config.StatsDEnabled,
 	}
-	
+
 	if !e.lastExport.IsZero() {
 		status["last_export"] = e.lastExport.Format(time.RFC3339)
 		status["next_export_in"] = e.exportInterval - time.Since(e.lastExport)
 	}
-	
+
+	if e.config.KafkaEnabled {
+		status["kafka"] = e.kafkaStatus.snapshot()
+	}
+
+	if len(e.sinkStatuses) > 0 {
+		e.sinkMu.RLock()
+		sinks := make(map[string]interface{}, len(e.sinkStatuses))
+		for name, s := range e.sinkStatuses {
+			sinks[name] = s.snapshot()
+		}
+		e.sinkMu.RUnlock()
+		status["sinks"] = sinks
+	}
+
 	return status
 }
@@ -0,0 +1,145 @@
+package enterprise
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// otlpSink pushes each export batch to an OTLP/gRPC collector as a set of
+// synchronous gauges (TVL, APY, PointsPerETH, provider latency) plus an
+// aggregation-outcome counter, forcing a flush after every batch since the
+// exporter already batches on its own schedule (config.ExportInterval /
+// config.BatchSize) rather than the SDK's periodic reader.
+type otlpSink struct {
+	provider *sdkmetric.MeterProvider
+	reader   *sdkmetric.PeriodicReader
+
+	apy              metric.Float64Gauge
+	tvl              metric.Float64Gauge
+	pointsPerETH     metric.Float64Gauge
+	providerLatency  metric.Float64Histogram
+	aggregationCount metric.Int64Counter
+}
+
+// newOTLPSink dials cfg.OTLPEndpoint and registers the instruments used by
+// Export. Dialing is lazy/non-blocking (grpc.WithBlock is not set), matching
+// newAWSClients/newSaramaProducer's "don't fail construction over a
+// down backend" convention.
+func newOTLPSink(cfg ExporterConfig) (*otlpSink, error) {
+	ctx := context.Background()
+
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint)}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP gRPC exporter: %w", err)
+	}
+
+	serviceName := cfg.OTLPServiceName
+	if serviceName == "" {
+		serviceName = "restake-yield-ea"
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("building OTLP resource: %w", err)
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exporter)
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+	meter := provider.Meter("restake-yield-ea/enterprise")
+
+	apy, err := meter.Float64Gauge("restake.apy", metric.WithDescription("Aggregated APY per provider"))
+	if err != nil {
+		return nil, fmt.Errorf("creating apy gauge: %w", err)
+	}
+	tvl, err := meter.Float64Gauge("restake.tvl", metric.WithDescription("Aggregated TVL per provider"))
+	if err != nil {
+		return nil, fmt.Errorf("creating tvl gauge: %w", err)
+	}
+	points, err := meter.Float64Gauge("restake.points_per_eth", metric.WithDescription("Aggregated points-per-ETH per provider"))
+	if err != nil {
+		return nil, fmt.Errorf("creating points_per_eth gauge: %w", err)
+	}
+	latency, err := meter.Float64Histogram("restake.provider_latency_ms", metric.WithDescription("Provider response latency in milliseconds"))
+	if err != nil {
+		return nil, fmt.Errorf("creating provider_latency histogram: %w", err)
+	}
+	outcomes, err := meter.Int64Counter("restake.aggregation_outcome", metric.WithDescription("Count of aggregation runs by outcome"))
+	if err != nil {
+		return nil, fmt.Errorf("creating aggregation_outcome counter: %w", err)
+	}
+
+	return &otlpSink{
+		provider:         provider,
+		reader:           reader,
+		apy:              apy,
+		tvl:              tvl,
+		pointsPerETH:     points,
+		providerLatency:  latency,
+		aggregationCount: outcomes,
+	}, nil
+}
+
+// Export records every metric in batch against the OTLP instruments and
+// forces an immediate flush to the collector.
+func (s *otlpSink) Export(ctx context.Context, batch []interface{}) error {
+	for _, item := range batch {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(encoded, &m); err != nil {
+			continue
+		}
+
+		provider, _ := m["provider"].(string)
+		attrs := metric.WithAttributes(attribute.String("provider", provider))
+
+		if v, ok := m["apy"].(float64); ok {
+			s.apy.Record(ctx, v, attrs)
+		}
+		if v, ok := m["tvl"].(float64); ok {
+			s.tvl.Record(ctx, v, attrs)
+		}
+		if v, ok := m["points_per_eth"].(float64); ok {
+			s.pointsPerETH.Record(ctx, v, attrs)
+		}
+		if v, ok := m["latency_ms"].(float64); ok {
+			s.providerLatency.Record(ctx, v, attrs)
+		}
+
+		outcome := "success"
+		if v, ok := m["error"].(string); ok && v != "" {
+			outcome = "failure"
+		}
+		s.aggregationCount.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("provider", provider),
+			attribute.String("outcome", outcome),
+		))
+	}
+
+	if err := s.reader.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("flushing OTLP metrics: %w", err)
+	}
+	return nil
+}
+
+func (s *otlpSink) Name() string { return "otlp" }
+
+// Close shuts down the meter provider, flushing any remaining data and
+// closing the underlying gRPC connection.
+func (s *otlpSink) Close() error {
+	return s.provider.Shutdown(context.Background())
+}
@@ -0,0 +1,109 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sink is a single metrics export destination. OTLP, StatsD and the webhook
+// exporter all implement it so exportMetrics can fan a batch out to every
+// configured destination the same way, instead of one hand-rolled goroutine
+// per backend.
+type Sink interface {
+	// Export ships batch to the destination. Implementations should treat a
+	// non-nil error as retryable; exportToSinkWithRetry handles backoff.
+	Export(ctx context.Context, batch []interface{}) error
+
+	// Name identifies the sink for logging and GetExporterStatus.
+	Name() string
+}
+
+// sinkMaxRetries bounds the retry loop in exportToSinkWithRetry before the
+// failure is counted and logged, mirroring awsMaxRetries/putMetricDataWithRetry.
+const sinkMaxRetries = 3
+
+// exportToSinkWithRetry exports batch to sink with exponential backoff,
+// recording the outcome in e.sinkStatuses so GetExporterStatus can surface
+// per-sink health without one failing sink hiding another's errors.
+func (e *MetricsExporter) exportToSinkWithRetry(sink Sink, batch []interface{}) error {
+	var lastErr error
+	for attempt := 0; attempt < sinkMaxRetries; attempt++ {
+		if err := sink.Export(e.exportContext, batch); err == nil {
+			e.recordSinkSuccess(sink.Name())
+			return nil
+		} else {
+			lastErr = err
+		}
+		time.Sleep(awsBackoff(attempt))
+	}
+	e.recordSinkFailure(sink.Name(), lastErr)
+	return fmt.Errorf("%s export exhausted retries: %w", sink.Name(), lastErr)
+}
+
+func (e *MetricsExporter) recordSinkSuccess(name string) {
+	e.sinkMu.RLock()
+	status, ok := e.sinkStatuses[name]
+	e.sinkMu.RUnlock()
+	if ok {
+		status.recordSuccess()
+	}
+}
+
+func (e *MetricsExporter) recordSinkFailure(name string, err error) {
+	e.sinkMu.RLock()
+	status, ok := e.sinkStatuses[name]
+	e.sinkMu.RUnlock()
+	if ok {
+		status.recordFailure(err)
+	}
+}
+
+// closeSinks releases any resources (UDP sockets, gRPC connections) held by
+// sinks that implement io.Closer.
+func (e *MetricsExporter) closeSinks() {
+	for _, sink := range e.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				fmt.Printf("failed to close %s sink: %v\n", sink.Name(), err)
+			}
+		}
+	}
+}
+
+// sinkStatus tracks delivery outcomes for a single Sink, the generalization
+// of kafkaStatus to the Sink interface's arbitrary set of backends.
+type sinkStatus struct {
+	successCount uint64
+	failureCount uint64
+	mu           sync.RWMutex
+	lastError    string
+}
+
+func (s *sinkStatus) recordSuccess() {
+	atomic.AddUint64(&s.successCount, 1)
+}
+
+func (s *sinkStatus) recordFailure(err error) {
+	atomic.AddUint64(&s.failureCount, 1)
+	s.mu.Lock()
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+}
+
+func (s *sinkStatus) snapshot() map[string]interface{} {
+	s.mu.RLock()
+	lastErr := s.lastError
+	s.mu.RUnlock()
+
+	return map[string]interface{}{
+		"success_count": atomic.LoadUint64(&s.successCount),
+		"failure_count": atomic.LoadUint64(&s.failureCount),
+		"last_error":    lastErr,
+	}
+}
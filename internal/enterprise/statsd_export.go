@@ -0,0 +1,86 @@
+package enterprise
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// statsdSink pushes each export batch to a StatsD/DogStatsD daemon over UDP
+// using gauge ("g") lines, tagged DogStatsD-style ("|#key:value,...") since
+// that superset is accepted by most modern StatsD-compatible agents.
+type statsdSink struct {
+	conn      net.Conn
+	namespace string
+	tags      []string
+}
+
+// newStatsDSink dials cfg.StatsDAddress over UDP. UDP "dial" never touches
+// the network, so this succeeds even if no agent is listening yet, matching
+// the "don't fail construction over a down backend" convention used for the
+// other exporters.
+func newStatsDSink(cfg ExporterConfig) (*statsdSink, error) {
+	if cfg.StatsDAddress == "" {
+		return nil, fmt.Errorf("statsd address not configured")
+	}
+
+	conn, err := net.Dial("udp", cfg.StatsDAddress)
+	if err != nil {
+		return nil, fmt.Errorf("dialing statsd at %s: %w", cfg.StatsDAddress, err)
+	}
+
+	return &statsdSink{
+		conn:      conn,
+		namespace: strings.Trim(cfg.StatsDNamespace, "."),
+		tags:      cfg.StatsDTags,
+	}, nil
+}
+
+// Export writes one gauge datagram per numeric field per metric in batch.
+func (s *statsdSink) Export(ctx context.Context, batch []interface{}) error {
+	var lastErr error
+	for _, item := range batch {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(encoded, &m); err != nil {
+			continue
+		}
+
+		provider, _ := m["provider"].(string)
+		tags := append(append([]string{}, s.tags...), "provider:"+provider)
+
+		fields := []string{"apy", "tvl", "points_per_eth", "latency_ms"}
+		for _, field := range fields {
+			v, ok := m[field].(float64)
+			if !ok {
+				continue
+			}
+			if err := s.writeGauge(field, v, tags); err != nil {
+				lastErr = err
+			}
+		}
+	}
+	return lastErr
+}
+
+func (s *statsdSink) writeGauge(name string, value float64, tags []string) error {
+	metric := name
+	if s.namespace != "" {
+		metric = s.namespace + "." + name
+	}
+	line := fmt.Sprintf("%s:%g|g|#%s", metric, value, strings.Join(tags, ","))
+	_, err := s.conn.Write([]byte(line))
+	return err
+}
+
+func (s *statsdSink) Name() string { return "statsd" }
+
+// Close releases the underlying UDP socket.
+func (s *statsdSink) Close() error {
+	return s.conn.Close()
+}
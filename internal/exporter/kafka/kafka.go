@@ -0,0 +1,170 @@
+// Package kafka streams the adapter's yield observations, provider health
+// metrics and OTEL spans into Kafka as OTLP protobuf, so a Grafana/Alloy
+// pipeline (or any OTLP-over-Kafka collector receiver) can consume the
+// adapter's telemetry directly instead of adding it as a scrape target.
+//
+// This is deliberately separate from enterprise.ExporterConfig's existing
+// Kafka JSON export (internal/enterprise/kafka_producer.go): that path ships
+// plain JSON metric documents for log-style consumers, this one ships
+// OTLP-encoded protobuf for observability pipelines that expect it.
+package kafka
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// Config holds the Kafka connection and topic settings for NewKafkaExporter.
+type Config struct {
+	Brokers []string
+
+	// MetricsTopic, LogsTopic and TracesTopic route each telemetry signal to
+	// its own topic. Any left empty falls back to Topic.
+	Topic        string
+	MetricsTopic string
+	LogsTopic    string
+	TracesTopic  string
+
+	ServiceName string
+
+	Username      string
+	Password      string
+	SASLMechanism string // "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"
+
+	TLSEnabled    bool
+	TLSMinVersion string // "1.0".."1.3", defaults to 1.2
+
+	RequiredAcks string // "none", "leader", "all"
+	RetryMax     int
+	Compression  string // "none", "snappy", "lz4", "gzip"
+}
+
+// Exporter publishes OTLP-encoded metrics and spans to Kafka. It satisfies
+// enterprise.Sink (Export/Name, plus io.Closer) for metrics, and
+// sdktrace.SpanExporter (ExportSpans/Shutdown) for traces, without importing
+// either package - both are structural interfaces.
+type Exporter struct {
+	cfg      Config
+	producer sarama.SyncProducer
+
+	mu sync.Mutex
+}
+
+// NewKafkaExporter dials cfg.Brokers and returns an Exporter ready to
+// publish. Dialing failures are returned rather than swallowed, since unlike
+// the best-effort sinks in internal/enterprise, callers of this package are
+// expected to decide for themselves whether a down broker should block
+// startup.
+func NewKafkaExporter(cfg Config) (*Exporter, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("no Kafka brokers configured")
+	}
+
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.Return.Errors = true
+	saramaCfg.Producer.RequiredAcks = acksFromConfig(cfg.RequiredAcks)
+	saramaCfg.Producer.Retry.Max = retryMaxOrDefault(cfg.RetryMax)
+	saramaCfg.Producer.Compression = compressionFromConfig(cfg.Compression)
+
+	if cfg.Username != "" {
+		saramaCfg.Net.SASL.Enable = true
+		saramaCfg.Net.SASL.User = cfg.Username
+		saramaCfg.Net.SASL.Password = cfg.Password
+		if cfg.SASLMechanism != "" {
+			saramaCfg.Net.SASL.Mechanism = sarama.SASLMechanism(cfg.SASLMechanism)
+			saramaCfg.Net.SASL.Handshake = true
+		} else {
+			saramaCfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		}
+	}
+
+	if cfg.TLSEnabled {
+		saramaCfg.Net.TLS.Enable = true
+		saramaCfg.Net.TLS.Config = &tls.Config{MinVersion: tlsMinVersion(cfg.TLSMinVersion)}
+	}
+
+	producer, err := sarama.NewSyncProducer(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "restake-yield-ea"
+	}
+
+	return &Exporter{cfg: cfg, producer: producer}, nil
+}
+
+// Name identifies the sink for enterprise.MetricsExporter's status reporting.
+func (e *Exporter) Name() string { return "kafka-otlp" }
+
+// Close releases the underlying Kafka producer.
+func (e *Exporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.producer.Close()
+}
+
+func (e *Exporter) send(topic string, key, value []byte) error {
+	if topic == "" {
+		topic = e.cfg.Topic
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, _, err := e.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.ByteEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	})
+	return err
+}
+
+func acksFromConfig(acks string) sarama.RequiredAcks {
+	switch acks {
+	case "none":
+		return sarama.NoResponse
+	case "leader":
+		return sarama.WaitForLocal
+	case "all":
+		return sarama.WaitForAll
+	default:
+		return sarama.WaitForLocal
+	}
+}
+
+func retryMaxOrDefault(retryMax int) int {
+	if retryMax <= 0 {
+		return 3
+	}
+	return retryMax
+}
+
+func compressionFromConfig(codec string) sarama.CompressionCodec {
+	switch codec {
+	case "snappy":
+		return sarama.CompressionSnappy
+	case "lz4":
+		return sarama.CompressionLZ4
+	case "gzip":
+		return sarama.CompressionGZIP
+	default:
+		return sarama.CompressionNone
+	}
+}
+
+func tlsMinVersion(version string) uint16 {
+	switch version {
+	case "1.3":
+		return tls.VersionTLS13
+	case "1.1":
+		return tls.VersionTLS11
+	case "1.0":
+		return tls.VersionTLS10
+	default:
+		return tls.VersionTLS12
+	}
+}
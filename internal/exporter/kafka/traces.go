@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// RegisterAsOTelExporter wraps exp in a batch span processor and registers
+// it on tp, so spans flow to Kafka in addition to whatever exporter(s) tp
+// was already constructed with (e.g. otel.InitTracer's OTLP/HTTP exporter).
+func RegisterAsOTelExporter(tp *sdktrace.TracerProvider, exp *Exporter) {
+	tp.RegisterSpanProcessor(sdktrace.NewBatchSpanProcessor(exp))
+}
+
+// ExportSpans encodes spans as an OTLP TracesData protobuf message and
+// publishes it to cfg.TracesTopic (falling back to cfg.Topic), keyed by
+// trace ID. This satisfies sdktrace.SpanExporter.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	protoSpans := make([]*tracepb.Span, 0, len(spans))
+	for _, span := range spans {
+		protoSpans = append(protoSpans, spanToProto(span))
+	}
+
+	data := &tracepb.TracesData{
+		ResourceSpans: []*tracepb.ResourceSpans{
+			{
+				Resource: &resourcepb.Resource{
+					Attributes: []*commonpb.KeyValue{stringAttr("service.name", e.cfg.ServiceName)},
+				},
+				ScopeSpans: []*tracepb.ScopeSpans{
+					{
+						Scope: &commonpb.InstrumentationScope{Name: "restake-yield-ea/exporter/kafka"},
+						Spans: protoSpans,
+					},
+				},
+			},
+		},
+	}
+
+	payload, err := proto.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	traceID := spans[0].SpanContext().TraceID()
+	return e.send(e.tracesTopic(), traceID[:], payload)
+}
+
+// Shutdown flushes nothing extra (Export already sends synchronously) and
+// closes the Kafka producer, satisfying sdktrace.SpanExporter.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.Close()
+}
+
+func (e *Exporter) tracesTopic() string {
+	if e.cfg.TracesTopic != "" {
+		return e.cfg.TracesTopic
+	}
+	return e.cfg.Topic
+}
+
+func spanToProto(span sdktrace.ReadOnlySpan) *tracepb.Span {
+	sc := span.SpanContext()
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+
+	out := &tracepb.Span{
+		TraceId:           traceID[:],
+		SpanId:            spanID[:],
+		Name:              span.Name(),
+		Kind:              spanKindToProto(span.SpanKind()),
+		StartTimeUnixNano: uint64(span.StartTime().UnixNano()),
+		EndTimeUnixNano:   uint64(span.EndTime().UnixNano()),
+		Attributes:        attrsToProto(span.Attributes()),
+		Status: &tracepb.Status{
+			Code:    statusCodeToProto(span.Status().Code),
+			Message: span.Status().Description,
+		},
+	}
+
+	if parent := span.Parent(); parent.IsValid() {
+		parentSpanID := parent.SpanID()
+		out.ParentSpanId = parentSpanID[:]
+	}
+
+	return out
+}
+
+func attrsToProto(attrs []attribute.KeyValue) []*commonpb.KeyValue {
+	out := make([]*commonpb.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		var value *commonpb.AnyValue
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			value = &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: kv.Value.AsBool()}}
+		case attribute.INT64:
+			value = &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: kv.Value.AsInt64()}}
+		case attribute.FLOAT64:
+			value = &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: kv.Value.AsFloat64()}}
+		default:
+			value = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: kv.Value.Emit()}}
+		}
+		out = append(out, &commonpb.KeyValue{Key: string(kv.Key), Value: value})
+	}
+	return out
+}
+
+func spanKindToProto(kind trace.SpanKind) tracepb.Span_SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return tracepb.Span_SPAN_KIND_INTERNAL
+	case trace.SpanKindServer:
+		return tracepb.Span_SPAN_KIND_SERVER
+	case trace.SpanKindClient:
+		return tracepb.Span_SPAN_KIND_CLIENT
+	case trace.SpanKindProducer:
+		return tracepb.Span_SPAN_KIND_PRODUCER
+	case trace.SpanKindConsumer:
+		return tracepb.Span_SPAN_KIND_CONSUMER
+	default:
+		return tracepb.Span_SPAN_KIND_UNSPECIFIED
+	}
+}
+
+func statusCodeToProto(code codes.Code) tracepb.Status_StatusCode {
+	switch code {
+	case codes.Ok:
+		return tracepb.Status_STATUS_CODE_OK
+	case codes.Error:
+		return tracepb.Status_STATUS_CODE_ERROR
+	default:
+		return tracepb.Status_STATUS_CODE_UNSET
+	}
+}
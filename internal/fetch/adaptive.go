@@ -0,0 +1,274 @@
+package fetch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyWindowSize bounds how many recent samples each provider's ring
+// buffer retains for its p50/p95 estimate.
+const latencyWindowSize = 64
+
+// latencyTracker keeps a small ring buffer of recent request durations for
+// one (chain, provider) pair, used to derive an adaptive per-request
+// timeout instead of a single hardcoded value that's either too tight for
+// a slow-but-healthy provider or too loose for a fast one.
+type latencyTracker struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{samples: make([]time.Duration, 0, latencyWindowSize)}
+}
+
+func (t *latencyTracker) record(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.samples) < latencyWindowSize {
+		t.samples = append(t.samples, d)
+		return
+	}
+	t.samples[t.next] = d
+	t.next = (t.next + 1) % latencyWindowSize
+}
+
+// percentile returns the p-th percentile (0..1) of recorded samples, or
+// fallback if there aren't enough samples yet to estimate one.
+func (t *latencyTracker) percentile(p float64, fallback time.Duration) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.samples) == 0 {
+		return fallback
+	}
+	sorted := append([]time.Duration(nil), t.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// providerBreakerState mirrors the standard closed/open/half-open circuit
+// breaker states used by internal/circuitbreaker, scoped here to one
+// (chain, provider) pair's HTTP fetch health rather than data-quality.
+type providerBreakerState int
+
+const (
+	breakerClosed providerBreakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// providerBreaker trips after consecutiveFailureLimit failures in a row,
+// skipping the provider for cooldown (doubling on each re-trip, capped at
+// maxCooldown) before letting a single half-open probe through.
+type providerBreaker struct {
+	mu               sync.Mutex
+	state            providerBreakerState
+	consecutiveFails int
+	openedAt         time.Time
+	cooldown         time.Duration
+	probeInFlight    bool
+}
+
+const (
+	consecutiveFailureLimit = 3
+	defaultCooldown         = 30 * time.Second
+	maxCooldown             = 10 * time.Minute
+)
+
+func newProviderBreaker() *providerBreaker {
+	return &providerBreaker{cooldown: defaultCooldown}
+}
+
+// allow reports whether a call should be attempted right now, and if the
+// breaker is half-open, claims the single probe slot.
+func (b *providerBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probeInFlight = true
+		return true
+	case breakerHalfOpen:
+		return false // a probe is already in flight
+	default:
+		return true
+	}
+}
+
+func (b *providerBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFails = 0
+	b.probeInFlight = false
+	b.state = breakerClosed
+	b.cooldown = defaultCooldown
+}
+
+func (b *providerBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		// The probe failed: re-open and back off further.
+		b.probeInFlight = false
+		b.cooldown = time.Duration(math.Min(float64(b.cooldown*2), float64(maxCooldown)))
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		return
+	}
+
+	b.consecutiveFails++
+	if b.consecutiveFails >= consecutiveFailureLimit {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *providerBreaker) snapshot() BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return BreakerSnapshot{
+		State:            b.state.String(),
+		ConsecutiveFails: b.consecutiveFails,
+		Cooldown:         b.cooldown,
+	}
+}
+
+// String renders the breaker state the way internal/circuitbreaker.State
+// does, for consistent log/status output across packages.
+func (s providerBreakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// adaptiveController tracks per-(chain, provider) latency and breaker state
+// for MultiChainClient.fetchChainData, so one consistently slow or failing
+// provider gets a tighter timeout and eventually a cooldown instead of
+// stalling every Fetch call behind a single fixed 10s deadline.
+type adaptiveController struct {
+	mu        sync.Mutex
+	latencies map[string]*latencyTracker
+	breakers  map[string]*providerBreaker
+
+	minTimeout time.Duration
+	maxTimeout time.Duration
+	k          float64 // multiplier applied to p95 latency to derive the timeout
+}
+
+func newAdaptiveController() *adaptiveController {
+	return &adaptiveController{
+		latencies:  make(map[string]*latencyTracker),
+		breakers:   make(map[string]*providerBreaker),
+		minTimeout: 2 * time.Second,
+		maxTimeout: 20 * time.Second,
+		k:          2.0,
+	}
+}
+
+func providerKey(chain SupportedChain, provider string) string {
+	return string(chain) + "/" + provider
+}
+
+func (a *adaptiveController) latencyFor(key string) *latencyTracker {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	t, ok := a.latencies[key]
+	if !ok {
+		t = newLatencyTracker()
+		a.latencies[key] = t
+	}
+	return t
+}
+
+func (a *adaptiveController) breakerFor(key string) *providerBreaker {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	b, ok := a.breakers[key]
+	if !ok {
+		b = newProviderBreaker()
+		a.breakers[key] = b
+	}
+	return b
+}
+
+// timeoutFor returns min(maxTimeout, max(minTimeout, k * p95)) for key,
+// falling back to maxTimeout until enough samples have accumulated.
+func (a *adaptiveController) timeoutFor(key string) time.Duration {
+	p95 := a.latencyFor(key).percentile(0.95, a.maxTimeout)
+	d := time.Duration(float64(p95) * a.k)
+	if d < a.minTimeout {
+		return a.minTimeout
+	}
+	if d > a.maxTimeout {
+		return a.maxTimeout
+	}
+	return d
+}
+
+// BreakerSnapshot is the exported view of one provider's breaker state,
+// returned by MultiChainClient.Stats().
+type BreakerSnapshot struct {
+	State            string        `json:"state"`
+	ConsecutiveFails int           `json:"consecutive_fails"`
+	Cooldown         time.Duration `json:"cooldown"`
+}
+
+// LatencySnapshot is the exported view of one provider's latency estimate,
+// returned by MultiChainClient.Stats().
+type LatencySnapshot struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+}
+
+// ClientStats is the result of MultiChainClient.Stats(): per-(chain,
+// provider) breaker state and latency estimates, suitable for wiring into
+// Prometheus gauges or a /status endpoint.
+type ClientStats struct {
+	Breakers  map[string]BreakerSnapshot `json:"breakers"`
+	Latencies map[string]LatencySnapshot `json:"latencies"`
+}
+
+func (a *adaptiveController) stats() ClientStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := ClientStats{
+		Breakers:  make(map[string]BreakerSnapshot, len(a.breakers)),
+		Latencies: make(map[string]LatencySnapshot, len(a.latencies)),
+	}
+	for key, b := range a.breakers {
+		stats.Breakers[key] = b.snapshot()
+	}
+	for key, t := range a.latencies {
+		stats.Latencies[key] = LatencySnapshot{
+			P50: t.percentile(0.50, 0),
+			P95: t.percentile(0.95, 0),
+		}
+	}
+	return stats
+}
+
+// errBreakerOpen is returned by fetchChainData's provider loop when a
+// provider's breaker is open, so the caller's error message can name the
+// cooldown reason distinctly from a genuine fetch failure.
+var errBreakerOpen = fmt.Errorf("provider circuit breaker is open")
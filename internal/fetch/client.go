@@ -4,10 +4,12 @@ package fetch
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/yourorg/restake-yield-ea/internal/config"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 	"github.com/yourorg/restake-yield-ea/internal/model"
 )
 
@@ -17,6 +19,14 @@ type Client interface {
 	Fetch(ctx context.Context) ([]model.Metric, error)
 }
 
+// Provider is the interface MultiChainClient fans out to per chain. It is
+// satisfied by every REST-backed client in this package (GenericChainProvider
+// and its chain-specific embedders) as well as RPCProvider, which reads
+// on-chain state directly instead of a proprietary REST API.
+type Provider interface {
+	Fetch(ctx context.Context) ([]model.Metric, error)
+}
+
 // NewClient creates a new provider client based on the provided configuration and provider name
 func NewClient(cfg config.Config, provider string) Client {
 	switch provider {
@@ -31,13 +41,20 @@ func NewClient(cfg config.Config, provider string) Client {
 	}
 }
 
-// newRetryClient creates a new HTTP client with retry capabilities
+// sharedTransport is the single fetch.Transport instance backing every
+// provider client's HTTP traffic, so backoff/circuit/rate-limit state is
+// shared per upstream host rather than duplicated per client.
+var sharedTransport = NewTransport(DefaultTransportOptions())
+
+// newRetryClient creates a new HTTP client with retry capabilities. Retries,
+// backoff and the per-host circuit are handled by sharedTransport, so
+// retryablehttp's own retry loop is disabled here and only its request
+// helpers (used by the GraphQL-style clients) are kept.
 func newRetryClient() *retryablehttp.Client {
 	c := retryablehttp.NewClient()
-	c.RetryMax = 3
-	c.RetryWaitMin = 500 * time.Millisecond
-	c.RetryWaitMax = 3 * time.Second
+	c.RetryMax = 0
 	c.Logger = nil
+	c.HTTPClient.Transport = sharedTransport
 	return c
 }
 
@@ -46,12 +63,39 @@ func StandardClient(retryClient *retryablehttp.Client) *http.Client {
 	return retryClient.StandardClient()
 }
 
-// getAPIKey retrieves an API key for a specific provider from configuration
+// getAPIKey retrieves an API key for a specific provider from configuration.
+// A value of the form "secret://<provider>/<path>" is resolved against
+// cfg.SecretsResolver instead of being used literally; a path with no
+// "#<field>" suffix defaults to the "api_key" field, matching the KV
+// layout described for SecretsBackend (e.g. secret/data/restake-ea/karak
+// with an api_key key). Every call re-resolves through the resolver's own
+// cache, so a rotated key reaches long-running fetchers without a restart.
 func getAPIKey(cfg config.Config, provider string) string {
-	if k, ok := cfg.APIKeys[provider]; ok {
-		return k
+	raw, ok := cfg.APIKeys[provider]
+	if !ok || raw == "" {
+		return ""
+	}
+	if cfg.SecretsResolver == nil || !strings.HasPrefix(raw, "secret://") {
+		return raw
+	}
+
+	ref := raw
+	if !strings.Contains(ref, "#") {
+		ref += "#api_key"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resolved, err := cfg.SecretsResolver.Resolve(ctx, ref)
+	if err != nil {
+		logging.WithFields(logging.Fields{
+			"component": "fetch",
+			"provider":  provider,
+		}).Warnf("resolving API key secret: %v", err)
+		return ""
 	}
-	return ""
+	return resolved
 }
 
 // getEnvOrDefault retrieves an environment variable or returns the default value if not set
@@ -7,11 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/yourorg/restake-yield-ea/internal/config"
 	"github.com/yourorg/restake-yield-ea/internal/model"
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // EigenLayerClient implements a client for the EigenLayer API
@@ -24,14 +23,16 @@ type EigenLayerClient struct {
 // NewEigenLayerClient creates a new EigenLayer API client
 func NewEigenLayerClient() *EigenLayerClient {
 	cfg := config.Load()
-	retryClient := newRetryClient()
 	return &EigenLayerClient{
 		baseURL:    cfg.EigenURL,
-		httpClient: StandardClient(retryClient),
+		httpClient: WrapClient("eigenlayer", StandardClient(newRetryClient())),
 		apiKey:     getAPIKey(cfg, "eigenlayer"),
 	}
 }
 
+// Name identifies this provider for per-provider fallback caching and logs.
+func (c *EigenLayerClient) Name() string { return "eigenlayer" }
+
 // Fetch retrieves yield data from the EigenLayer API.
 func (c *EigenLayerClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/v1/metrics", nil)
@@ -42,7 +43,10 @@ func (c *EigenLayerClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	logrus.Debugf("Fetching metrics from EigenLayer: %s", c.baseURL)
+	logging.WithFields(logging.Fields{
+		"component": "fetch",
+		"provider":  "eigenlayer",
+	}).Debugf("Fetching metrics from EigenLayer: %s", c.baseURL)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching data from EigenLayer: %w", err)
@@ -84,33 +88,9 @@ func (c *EigenLayerClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 		})
 	}
 
-	logrus.Debugf("Received %d metrics from EigenLayer", len(metrics))
+	logging.WithFields(logging.Fields{
+		"component": "fetch",
+		"provider":  "eigenlayer",
+	}).Debugf("Received %d metrics from EigenLayer", len(metrics))
 	return metrics, nil
 }
-
-// newRetryClient creates an HTTP client with retry logic.
-func newRetryClient() *http.Client {
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 3
-	retryClient.RetryWaitMin = 1 * time.Second
-	retryClient.RetryWaitMax = 5 * time.Second
-	return retryClient.StandardClient()
-}
-
-// getAPIKey retrieves the API key from the environment variable.
-func getAPIKey(cfg *config.Config, key string) string {
-	apiKey := os.Getenv(key)
-	if apiKey == "" {
-		fmt.Printf("Warning: %s not set\n", key)
-	}
-	return apiKey
-}
-
-// getEnvOrDefault retrieves a value from the environment variable or returns the default value.
-func getEnvOrDefault(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-	return value
-}
\ No newline at end of file
@@ -0,0 +1,112 @@
+// Package graphql provides a small, typed GraphQL query builder for the
+// fetch package's GraphQL-backed providers (currently Karak), so query
+// strings aren't hand-assembled with string formatting at each call site.
+package graphql
+
+import "strings"
+
+// Variable is a named, typed query variable, e.g. {Name: "first", Type:
+// "Int"} for a "$first: Int" declaration.
+type Variable struct {
+	Name string
+	Type string
+}
+
+// Fragment is a reusable named fragment on a GraphQL type.
+type Fragment struct {
+	Name   string
+	On     string
+	Fields string
+}
+
+// Query describes a single named GraphQL query operation against one
+// top-level field, optionally paginated with a cursor.
+type Query struct {
+	// Name is the operation name, e.g. "Vaults".
+	Name string
+
+	// Field is the top-level field being queried, e.g. "vaults".
+	Field string
+
+	// Selection is Field's selection set, e.g. "address asset { symbol }
+	// apy tvl pointsPerETH ...VaultStats" (may reference fragment names).
+	Selection string
+
+	// Variables are declared on the operation and available to Field's
+	// arguments (see Paginated).
+	Variables []Variable
+
+	// Fragments are appended to the document and may be referenced from
+	// Selection as "...FragmentName".
+	Fragments []Fragment
+
+	// Paginated adds "(first: $first, after: $after)" arguments to Field
+	// and declares the corresponding $first/$after variables automatically
+	// - callers don't need to list them in Variables.
+	Paginated bool
+}
+
+// Build renders q as a GraphQL document string suitable for the "query"
+// field of a request body.
+func (q Query) Build() string {
+	var b strings.Builder
+
+	b.WriteString("query ")
+	b.WriteString(q.Name)
+
+	vars := q.Variables
+	if q.Paginated {
+		vars = append(append([]Variable{}, vars...),
+			Variable{Name: "first", Type: "Int"},
+			Variable{Name: "after", Type: "String"},
+		)
+	}
+	if len(vars) > 0 {
+		b.WriteString("(")
+		for i, v := range vars {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("$")
+			b.WriteString(v.Name)
+			b.WriteString(": ")
+			b.WriteString(v.Type)
+		}
+		b.WriteString(")")
+	}
+
+	b.WriteString(" { ")
+	b.WriteString(q.Field)
+	if q.Paginated {
+		b.WriteString("(first: $first, after: $after)")
+	}
+	b.WriteString(" { ")
+	b.WriteString(q.Selection)
+	b.WriteString(" } }")
+
+	for _, f := range q.Fragments {
+		b.WriteString(" fragment ")
+		b.WriteString(f.Name)
+		b.WriteString(" on ")
+		b.WriteString(f.On)
+		b.WriteString(" { ")
+		b.WriteString(f.Fields)
+		b.WriteString(" }")
+	}
+
+	return b.String()
+}
+
+// Request is the JSON body POSTed to a GraphQL endpoint: a query document
+// plus its variable bindings.
+type Request struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// PageInfo is the standard Relay-style pagination cursor payload returned
+// alongside a paginated field's result list.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
@@ -0,0 +1,250 @@
+package fetch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yourorg/restake-yield-ea/internal/config"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// graphqlBreakerState mirrors the standard closed/open/half-open states
+// used across the codebase (internal/circuitbreaker.State, adaptive.go's
+// providerBreakerState), scoped here to one GraphQL provider client's
+// transport health.
+type graphqlBreakerState int
+
+const (
+	graphqlBreakerClosed graphqlBreakerState = iota
+	graphqlBreakerOpen
+	graphqlBreakerHalfOpen
+)
+
+func (s graphqlBreakerState) String() string {
+	switch s {
+	case graphqlBreakerClosed:
+		return "closed"
+	case graphqlBreakerOpen:
+		return "open"
+	case graphqlBreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Prometheus metrics for graphqlBreaker state transitions, labeled by
+// provider. Mirrors circuitbreaker/metrics.go and fetch/metrics.go's
+// package-level, curry-on-use pattern.
+var (
+	graphqlBreakerTripsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "restake_graphql_breaker_trips_total",
+			Help: "Total number of times a provider's GraphQL circuit breaker has tripped open, labeled by provider.",
+		},
+		[]string{"provider"},
+	)
+
+	graphqlBreakerStateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "restake_graphql_breaker_state",
+			Help: "Current GraphQL circuit breaker state per provider (0=closed, 1=open, 2=half-open).",
+		},
+		[]string{"provider"},
+	)
+
+	graphqlBreakerStaleServedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "restake_graphql_breaker_stale_served_total",
+			Help: "Total number of Fetch calls served last-known-good stale metrics while a provider's GraphQL breaker was open, labeled by provider.",
+		},
+		[]string{"provider"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(graphqlBreakerTripsTotal, graphqlBreakerStateGauge, graphqlBreakerStaleServedTotal)
+}
+
+// graphqlBreaker is a per-provider circuit breaker around a GraphQL
+// provider client's Fetch, distinct from providerBreaker in adaptive.go
+// (which adapts MultiChainClient's per-chain HTTP timeouts and has no
+// Prometheus metrics or stale-result fallback) and from
+// circuitbreaker.CircuitBreaker (which trips on aggregated-metric content
+// like APY/TVL anomalies rather than transport failures). It trips after
+// ConsecutiveFailures failures in a row, or when the rolling error rate
+// over the last ErrorRateWindow calls exceeds ErrorRateThreshold, and
+// while open hands back the last-known-good metrics (marked Stale) instead
+// of failing the call outright.
+type graphqlBreaker struct {
+	provider string
+	cfg      config.ProviderBreakerConfig
+
+	mu            sync.Mutex
+	state         graphqlBreakerState
+	consecutive   int
+	results       []bool
+	openedAt      time.Time
+	probeInFlight bool
+
+	lastGood []model.Metric
+}
+
+// newGraphQLBreaker returns a graphqlBreaker for provider, using cfg's
+// thresholds for provider if present or config.DefaultProviderBreakerConfig
+// otherwise.
+func newGraphQLBreaker(provider string, cfg config.Config) *graphqlBreaker {
+	bcfg, ok := cfg.ProviderBreakers[provider]
+	if !ok {
+		bcfg = config.DefaultProviderBreakerConfig
+	}
+	if bcfg.ErrorRateWindow <= 0 {
+		bcfg.ErrorRateWindow = config.DefaultProviderBreakerConfig.ErrorRateWindow
+	}
+	if bcfg.OpenDuration <= 0 {
+		bcfg.OpenDuration = config.DefaultProviderBreakerConfig.OpenDuration
+	}
+
+	return &graphqlBreaker{
+		provider: provider,
+		cfg:      bcfg,
+		results:  make([]bool, 0, bcfg.ErrorRateWindow),
+	}
+}
+
+// allow reports whether a call should be attempted right now, and if the
+// breaker is half-open, claims the single probe slot.
+func (b *graphqlBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case graphqlBreakerClosed:
+		return true
+	case graphqlBreakerOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenDuration {
+			return false
+		}
+		b.setState(graphqlBreakerHalfOpen)
+		b.probeInFlight = true
+		return true
+	case graphqlBreakerHalfOpen:
+		return false // a probe is already in flight
+	default:
+		return true
+	}
+}
+
+// recordSuccess closes the breaker and stores metrics as the new
+// last-known-good result returned by stale() while the breaker is open.
+func (b *graphqlBreaker) recordSuccess(metrics []model.Metric) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutive = 0
+	b.probeInFlight = false
+	b.recordResult(true)
+	b.lastGood = append([]model.Metric(nil), metrics...)
+	b.setState(graphqlBreakerClosed)
+}
+
+// recordFailure accounts for a failed call and trips the breaker once
+// ConsecutiveFailures or ErrorRateThreshold is exceeded. A failed half-open
+// probe re-opens immediately.
+func (b *graphqlBreaker) recordFailure(reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.recordResult(false)
+
+	if b.state == graphqlBreakerHalfOpen {
+		b.probeInFlight = false
+		b.trip(reason)
+		return
+	}
+
+	b.consecutive++
+	if b.consecutive >= b.cfg.ConsecutiveFailures {
+		b.trip(reason)
+		return
+	}
+	if b.cfg.ErrorRateThreshold > 0 && b.errorRate() > b.cfg.ErrorRateThreshold {
+		b.trip(reason)
+	}
+}
+
+// recordResult appends to the rolling results window, evicting the oldest
+// entry once ErrorRateWindow is reached. Callers must hold b.mu.
+func (b *graphqlBreaker) recordResult(success bool) {
+	b.results = append(b.results, success)
+	if len(b.results) > b.cfg.ErrorRateWindow {
+		b.results = b.results[len(b.results)-b.cfg.ErrorRateWindow:]
+	}
+}
+
+// errorRate returns the fraction of failures in the current results
+// window. Callers must hold b.mu.
+func (b *graphqlBreaker) errorRate() float64 {
+	if len(b.results) == 0 {
+		return 0
+	}
+	failures := 0
+	for _, ok := range b.results {
+		if !ok {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(b.results))
+}
+
+// trip opens the breaker. Callers must hold b.mu.
+func (b *graphqlBreaker) trip(reason string) {
+	b.setState(graphqlBreakerOpen)
+	b.openedAt = time.Now()
+	b.consecutive = 0
+	graphqlBreakerTripsTotal.WithLabelValues(b.provider).Inc()
+	logging.WithFields(logging.Fields{
+		"component": "fetch",
+		"provider":  b.provider,
+	}).Warnf("GraphQL circuit breaker tripped: %s", reason)
+}
+
+// setState updates state and its gauge. Callers must hold b.mu.
+func (b *graphqlBreaker) setState(s graphqlBreakerState) {
+	b.state = s
+	graphqlBreakerStateGauge.WithLabelValues(b.provider).Set(float64(s))
+}
+
+// stale returns the last-known-good metrics marked Stale, and whether any
+// exist yet to return.
+func (b *graphqlBreaker) stale() ([]model.Metric, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.lastGood) == 0 {
+		return nil, false
+	}
+
+	graphqlBreakerStaleServedTotal.WithLabelValues(b.provider).Inc()
+
+	out := make([]model.Metric, len(b.lastGood))
+	for i, m := range b.lastGood {
+		m.Stale = true
+		out[i] = m
+	}
+	return out, true
+}
+
+// graphqlBreakerOpenErr is returned by Fetch when the breaker is open and
+// there's no last-known-good result to fall back to yet.
+type graphqlBreakerOpenErr struct {
+	provider string
+}
+
+func (e *graphqlBreakerOpenErr) Error() string {
+	return fmt.Sprintf("graphql circuit breaker open for provider %q and no last-known-good metrics available", e.provider)
+}
@@ -0,0 +1,71 @@
+package httpcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("httpcache")
+
+// BoltStore is a Store backed by a single bbolt database file, for
+// deployments that want persistence without the one-file-per-key layout
+// FileStore uses.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if needed) a bbolt database at path.
+// Callers should Close it on shutdown.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: opening bbolt db %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("httpcache: creating bucket: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	var entry Entry
+	found := false
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(bucketName).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &entry)
+	})
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("httpcache: reading %s: %w", key, err)
+	}
+	return entry, found, nil
+}
+
+func (s *BoltStore) Put(ctx context.Context, key string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("httpcache: encoding %s: %w", key, err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Put([]byte(key), data)
+	})
+}
+
+// Close releases the underlying bbolt database file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,64 @@
+package httpcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileStore is a Store backed by one JSON file per key in dir, for
+// persistence across restarts without an external dependency. Writes go
+// through a temp file plus rename so a crash mid-write can't leave a
+// truncated entry behind.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore rooted at dir. dir is created lazily on
+// first Put, not by this constructor.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+func (s *FileStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("httpcache: reading %s: %w", key, err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, false, fmt.Errorf("httpcache: decoding %s: %w", key, err)
+	}
+	return entry, true, nil
+}
+
+func (s *FileStore) Put(ctx context.Context, key string, entry Entry) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("httpcache: creating cache dir %s: %w", s.dir, err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("httpcache: encoding %s: %w", key, err)
+	}
+
+	tmp := s.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("httpcache: writing %s: %w", key, err)
+	}
+	if err := os.Rename(tmp, s.path(key)); err != nil {
+		return fmt.Errorf("httpcache: finalizing %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Package httpcache provides a persistent, revalidating HTTP response cache
+// for the fetch package's providers. Each entry stores the decoded
+// []model.Metric for a request alongside the validators (ETag,
+// Last-Modified) needed to issue a conditional request next time, so a
+// dashboard polling faster than an upstream API allows gets a 304 instead
+// of a full re-fetch.
+package httpcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// Entry is one cached HTTP response: the decoded metrics plus the
+// validators needed to revalidate it.
+type Entry struct {
+	Metrics      []model.Metric `json:"metrics"`
+	ETag         string         `json:"etag,omitempty"`
+	LastModified string         `json:"last_modified,omitempty"`
+	StoredAt     time.Time      `json:"stored_at"`
+}
+
+// Store is a pluggable blob store for Entry values, keyed by the opaque
+// string Key returns. Implementations: MemoryStore, FileStore, BoltStore.
+type Store interface {
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	Put(ctx context.Context, key string, entry Entry) error
+}
+
+// Key derives a cache key from the request method, URL and body, so two
+// requests that differ only in an irrelevant header share a cache entry
+// while two different POST bodies to the same URL (e.g. different GraphQL
+// queries) don't.
+func Key(method, url string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(url))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Client wraps an *http.Client with a Store, serving a cached Entry's
+// metrics (with a refreshed CollectedAt and FromCache set) on a 304 Not
+// Modified response instead of re-decoding, and otherwise decoding and
+// caching the fresh body via decode.
+type Client struct {
+	httpClient *http.Client
+	store      Store
+	decode     func([]byte) ([]model.Metric, error)
+}
+
+// NewClient builds a Client that caches decode's output in store.
+func NewClient(httpClient *http.Client, store Store, decode func([]byte) ([]model.Metric, error)) *Client {
+	return &Client{httpClient: httpClient, store: store, decode: decode}
+}
+
+// Do performs req, attaching If-None-Match/If-Modified-Since from any
+// cached entry for req's method+URL+requestBody first. requestBody must be
+// the same bytes used as req's body (http.Request doesn't expose it back
+// out once consumed), since it's part of the cache key.
+func (c *Client) Do(ctx context.Context, req *http.Request, requestBody []byte) ([]model.Metric, error) {
+	key := Key(req.Method, req.URL.String(), requestBody)
+
+	cached, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: reading cache entry: %w", err)
+	}
+	if ok {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if !ok {
+			return nil, fmt.Errorf("httpcache: got 304 with no cached entry for %s %s", req.Method, req.URL)
+		}
+		return stampFromCache(cached.Metrics), nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: reading body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("httpcache: unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	metrics, err := c.decode(body)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: decoding body: %w", err)
+	}
+
+	entry := Entry{
+		Metrics:      metrics,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StoredAt:     time.Now(),
+	}
+	if err := c.store.Put(ctx, key, entry); err != nil {
+		return nil, fmt.Errorf("httpcache: storing entry: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// stampFromCache returns a copy of metrics with CollectedAt refreshed to
+// now and FromCache set, so a served-from-cache metric is distinguishable
+// but isn't mistaken for stale data by freshness checks downstream.
+func stampFromCache(metrics []model.Metric) []model.Metric {
+	now := time.Now().Unix()
+	out := make([]model.Metric, len(metrics))
+	for i, m := range metrics {
+		m.CollectedAt = now
+		m.FromCache = true
+		out[i] = m
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package httpcache
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+)
+
+// RequestBuilder builds the next request to revalidate for a registered
+// task, returning the request and the exact bytes used as its body (Do
+// needs both: http.Request doesn't expose a consumed body back out, and
+// the body is part of the cache key).
+type RequestBuilder func(ctx context.Context) (req *http.Request, body []byte, err error)
+
+// Refresher periodically revalidates a fixed set of registered requests in
+// the background, bounded to maxConcurrent in-flight requests at a time -
+// so a dashboard polling faster than the upstream API allows gets served
+// from Client's cache instead of every poll hitting the network, while a
+// large registered set still can't open unbounded concurrent connections.
+type Refresher struct {
+	client        *Client
+	interval      time.Duration
+	maxConcurrent int
+
+	mu    sync.Mutex
+	tasks []refreshTask
+
+	cancel context.CancelFunc
+}
+
+type refreshTask struct {
+	name  string
+	build RequestBuilder
+}
+
+// NewRefresher returns a Refresher that revalidates its registered tasks
+// against client every interval, running at most maxConcurrent of them at
+// once.
+func NewRefresher(client *Client, interval time.Duration, maxConcurrent int) *Refresher {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &Refresher{client: client, interval: interval, maxConcurrent: maxConcurrent}
+}
+
+// Register adds a named request to revalidate on every tick once Start has
+// been called. Safe to call after Start.
+func (r *Refresher) Register(name string, build RequestBuilder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks = append(r.tasks, refreshTask{name: name, build: build})
+}
+
+// Start begins the background refresh loop. Call Stop to end it.
+func (r *Refresher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.refreshAll(ctx)
+			}
+		}
+	}()
+}
+
+// Stop ends the background refresh loop started by Start. It is a no-op if
+// Start was never called.
+func (r *Refresher) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+func (r *Refresher) refreshAll(ctx context.Context) {
+	r.mu.Lock()
+	tasks := append([]refreshTask{}, r.tasks...)
+	r.mu.Unlock()
+
+	sem := make(chan struct{}, r.maxConcurrent)
+	var wg sync.WaitGroup
+
+	for _, t := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(t refreshTask) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			req, body, err := t.build(ctx)
+			if err != nil {
+				logging.WithField("component", "httpcache").Warnf("refresh %s: building request: %v", t.name, err)
+				return
+			}
+			if _, err := r.client.Do(ctx, req, body); err != nil {
+				logging.WithField("component", "httpcache").Warnf("refresh %s: %v", t.name, err)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
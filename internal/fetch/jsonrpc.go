@@ -0,0 +1,154 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+// jsonRPCClient is a minimal Ethereum JSON-RPC/2.0 client used by
+// RPCProvider to read on-chain state directly, rather than trusting a
+// protocol's proprietary REST API.
+type jsonRPCClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newJSONRPCClient(chain, endpoint string) *jsonRPCClient {
+	return &jsonRPCClient{
+		endpoint:   endpoint,
+		httpClient: WrapClientForChain("rpc", chain, StandardClient(newRetryClient())),
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// call performs a single JSON-RPC request and decodes result into out.
+func (c *jsonRPCClient) call(ctx context.Context, method string, params interface{}, out interface{}) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("encoding rpc request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building rpc request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("rpc call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("decoding rpc response for %s: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("rpc error for %s: %s (code %d)", method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("unmarshaling rpc result for %s: %w", method, err)
+	}
+	return nil
+}
+
+// ethCall performs an eth_call against to with the given ABI-encoded
+// calldata at blockTag ("latest", or a "0x"-prefixed block number), and
+// returns the raw return data.
+func (c *jsonRPCClient) ethCall(ctx context.Context, to, data, blockTag string) ([]byte, error) {
+	if blockTag == "" {
+		blockTag = "latest"
+	}
+	var raw string
+	params := []interface{}{
+		map[string]string{"to": to, "data": data},
+		blockTag,
+	}
+	if err := c.call(ctx, "eth_call", params, &raw); err != nil {
+		return nil, err
+	}
+	return decodeHex(raw)
+}
+
+// blockNumber returns the latest block number known to the node.
+func (c *jsonRPCClient) blockNumber(ctx context.Context) (uint64, error) {
+	var raw string
+	if err := c.call(ctx, "eth_blockNumber", []interface{}{}, &raw); err != nil {
+		return 0, err
+	}
+	n, ok := new(big.Int).SetString(trimHexPrefix(raw), 16)
+	if !ok {
+		return 0, fmt.Errorf("malformed block number %q", raw)
+	}
+	return n.Uint64(), nil
+}
+
+// rpcBlockHeader is the subset of eth_getBlockByNumber's result that
+// lightVerify needs to walk and validate the header chain.
+type rpcBlockHeader struct {
+	Number     string `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	StateRoot  string `json:"stateRoot"`
+}
+
+// blockByNumber fetches the header (without full transaction bodies) for
+// the given block tag.
+func (c *jsonRPCClient) blockByNumber(ctx context.Context, blockTag string) (*rpcBlockHeader, error) {
+	var header rpcBlockHeader
+	if err := c.call(ctx, "eth_getBlockByNumber", []interface{}{blockTag, false}, &header); err != nil {
+		return nil, err
+	}
+	if header.Hash == "" {
+		return nil, fmt.Errorf("no block found for tag %q", blockTag)
+	}
+	return &header, nil
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(trimHexPrefix(s))
+}
+
+// word32At reads the 32-byte ABI word at the given word index (0-based)
+// from data and returns it as a big.Int, treating it as an unsigned
+// integer - the shape every restaking vault's totalShares/exchange-rate
+// accessor returns.
+func word32At(data []byte, index int) *big.Int {
+	start := index * 32
+	if start+32 > len(data) {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(data[start : start+32])
+}
@@ -1,47 +1,112 @@
 package fetch
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/yourorg/restake-yield-ea/internal/aggregate"
 	"github.com/yourorg/restake-yield-ea/internal/config"
+	"github.com/yourorg/restake-yield-ea/internal/fetch/graphql"
+	"github.com/yourorg/restake-yield-ea/internal/fetch/httpcache"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 	"github.com/yourorg/restake-yield-ea/internal/model"
 )
 
+// karakVaultsQuery requests every vault's identifying fields alongside its
+// yield metrics, instead of the single hardcoded "{ vaults { apy tvl
+// pointsPerETH } }" string this client used to send.
+var karakVaultsQuery = graphql.Query{
+	Name:      "Vaults",
+	Field:     "vaults",
+	Selection: "address asset { symbol } strategy apy tvl pointsPerETH",
+}.Build()
+
+// KarakClient implements a client for the Karak GraphQL API.
 type KarakClient struct {
-	cfg config.Config
+	cfg       config.Config
+	cache     *httpcache.Client
+	refresher *httpcache.Refresher
+	breaker   *graphqlBreaker
 }
 
-func (c *KarakClient) Fetch(ctx context.Context) ([]model.Metric, error) {
-	client := newRetryClient()
-	
-	graphqlQuery := `{"query":"{ vaults { apy tvl pointsPerETH } }"}`
-	req, err := retryablehttp.NewRequest("POST", c.cfg.KarakURL, []byte(graphqlQuery))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// NewKarakClient creates a new Karak API client.
+func NewKarakClient() *KarakClient {
+	cfg := config.Load()
+	httpClient := WrapClient("karak", StandardClient(newRetryClient()))
+
+	store := newKarakCacheStore(cfg)
+	cache := httpcache.NewClient(httpClient, store, decodeKarakVaults)
+
+	c := &KarakClient{cfg: cfg, cache: cache, breaker: newGraphQLBreaker("karak", cfg)}
+
+	if cfg.HTTPCacheRefreshInterval > 0 {
+		c.refresher = httpcache.NewRefresher(cache, cfg.HTTPCacheRefreshInterval, 4)
+		c.refresher.Register("karak-vaults", c.buildRequest)
+		c.refresher.Start()
 	}
 
-	if k := getAPIKey(c.cfg, "karak"); k != "" {
-		req.Header.Set("Authorization", k)
+	return c
+}
+
+// newKarakCacheStore builds the Store backing a KarakClient's httpcache.Client
+// from cfg.HTTPCacheBackend, defaulting to a non-persistent MemoryStore when
+// unset or unrecognized.
+func newKarakCacheStore(cfg config.Config) httpcache.Store {
+	switch cfg.HTTPCacheBackend {
+	case "file":
+		return httpcache.NewFileStore(cfg.HTTPCacheDir)
+	case "bolt":
+		store, err := httpcache.NewBoltStore(cfg.HTTPCacheDir)
+		if err != nil {
+			logging.WithField("component", "fetch").Warnf("opening bolt http cache, falling back to memory: %v", err)
+			return httpcache.NewMemoryStore()
+		}
+		return store
+	default:
+		return httpcache.NewMemoryStore()
 	}
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	resp, err := client.Do(req.WithContext(ctx))
+// Name identifies this provider for per-provider fallback caching and logs.
+func (c *KarakClient) Name() string { return "karak" }
+
+// buildRequest constructs the Karak vaults GraphQL request, shared by Fetch
+// and the background Refresher so both hash the same cache key.
+func (c *KarakClient) buildRequest(ctx context.Context) (*http.Request, []byte, error) {
+	body, err := json.Marshal(graphql.Request{Query: karakVaultsQuery})
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("failed to encode GraphQL request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.KarakURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	if k := getAPIKey(c.cfg, "karak"); k != "" {
+		req.Header.Set("Authorization", k)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, body, nil
+}
+
+// decodeKarakVaults turns a Karak GraphQL response body into one model.Metric
+// per vault. It's passed to httpcache.Client as the decode func run on a
+// cache miss; cache hits skip straight to this shape via the stored Entry.
+func decodeKarakVaults(body []byte) ([]model.Metric, error) {
 	var response struct {
 		Data struct {
 			Vaults []struct {
+				Address string `json:"address"`
+				Asset   struct {
+					Symbol string `json:"symbol"`
+				} `json:"asset"`
+				Strategy     string  `json:"strategy"`
 				APY          float64 `json:"apy"`
 				TVL          float64 `json:"tvl"`
 				PointsPerETH float64 `json:"pointsPerETH"`
@@ -49,7 +114,7 @@ func (c *KarakClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 		} `json:"data"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -57,15 +122,69 @@ func (c *KarakClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 		return nil, fmt.Errorf("no vaults found in response")
 	}
 
-	// Use first vault in array as specified
-	vault := response.Data.Vaults[0]
-	return []model.Metric{
-		{
+	now := time.Now().Unix()
+	vaultMetrics := make([]model.Metric, 0, len(response.Data.Vaults))
+	for _, vault := range response.Data.Vaults {
+		vaultMetrics = append(vaultMetrics, model.Metric{
+			Provider:     "karak",
+			Protocol:     vault.Strategy,
+			VaultID:      vault.Address,
+			Asset:        vault.Asset.Symbol,
 			APY:          vault.APY,
 			TVL:          vault.TVL,
 			PointsPerETH: vault.PointsPerETH,
-			CollectedAt:  time.Now().Unix(),
-			Provider:     "karak",
-		},
-	}, nil
-}
\ No newline at end of file
+			CollectedAt:  now,
+		})
+	}
+
+	return vaultMetrics, nil
+}
+
+func (c *KarakClient) Fetch(ctx context.Context) ([]model.Metric, error) {
+	if !c.breaker.allow() {
+		if stale, ok := c.breaker.stale(); ok {
+			return stale, nil
+		}
+		return nil, &graphqlBreakerOpenErr{provider: "karak"}
+	}
+
+	result, err := c.doFetch(ctx)
+	if err != nil {
+		c.breaker.recordFailure(err.Error())
+		if stale, ok := c.breaker.stale(); ok {
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	c.breaker.recordSuccess(result)
+	return result, nil
+}
+
+// doFetch performs the actual Karak GraphQL request, cache lookup and
+// aggregation, without any circuit breaker bookkeeping - split out so
+// Fetch's breaker logic doesn't get tangled up with the happy-path steps.
+func (c *KarakClient) doFetch(ctx context.Context) ([]model.Metric, error) {
+	req, body, err := c.buildRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultMetrics, err := c.cache.Do(ctx, req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cfg.KarakAggregationMode == "tvl_weighted" {
+		aggregated := aggregate.Weighted(vaultMetrics)
+		aggregated.Provider = "karak"
+		return []model.Metric{aggregated}, nil
+	}
+
+	logging.WithFields(logging.Fields{
+		"component": "fetch",
+		"provider":  "karak",
+	}).Debugf("Karak returned %d vaults", len(vaultMetrics))
+
+	return vaultMetrics, nil
+}
@@ -0,0 +1,100 @@
+package fetch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldMapping maps model.Metric field names to a small JSONPath-like
+// selector evaluated against a decoded JSON response, e.g.
+// "apy": "$.data[*].apy" or "tvl": "$.result.tvl".
+//
+// Supported grammar: a leading "$" root, "." field access, and a single
+// "[*]" wildcard over an array (taking the first element, since most
+// provider payloads put one quote per response and registry-driven clients
+// don't aggregate across the array themselves).
+type FieldMapping map[string]string
+
+// evalPath walks decoded JSON (the result of json.Unmarshal into
+// interface{}) according to a FieldMapping selector and returns the value
+// found, or an error if the path doesn't resolve.
+func evalPath(root interface{}, path string) (interface{}, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+
+	if path == "" {
+		return root, nil
+	}
+
+	current := root
+	for _, segment := range strings.Split(path, ".") {
+		field := segment
+		wildcard := false
+		if idx := strings.Index(segment, "["); idx >= 0 {
+			field = segment[:idx]
+			if strings.Contains(segment, "[*]") {
+				wildcard = true
+			}
+		}
+
+		if field != "" {
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("field %q: expected object, got %T", field, current)
+			}
+			current, ok = m[field]
+			if !ok {
+				return nil, fmt.Errorf("field %q not found", field)
+			}
+		}
+
+		if wildcard {
+			arr, ok := current.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("segment %q: expected array, got %T", segment, current)
+			}
+			if len(arr) == 0 {
+				return nil, fmt.Errorf("segment %q: array is empty", segment)
+			}
+			current = arr[0]
+		}
+	}
+
+	return current, nil
+}
+
+// asFloat64 coerces a decoded JSON value (float64, string, or json.Number-ish)
+// into a float64, which is what every model.Metric numeric field needs.
+func asFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to float64", v)
+	}
+}
+
+// asInt64 coerces a decoded JSON value into an int64 (for CollectedAt).
+func asInt64(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), nil
+	case string:
+		return strconv.ParseInt(n, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int64", v)
+	}
+}
+
+// asString coerces a decoded JSON value into a string.
+func asString(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("cannot convert %T to string", v)
+	}
+	return s, nil
+}
@@ -0,0 +1,201 @@
+package fetch
+
+import (
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Prometheus metrics for outbound provider HTTP traffic. These are
+// package-level, mirroring circuitbreaker's metrics.go, since every provider
+// client shares the same instrumentation and WrapClient just curries
+// provider/chain onto these vectors rather than threading a registry through
+// every constructor.
+var (
+	outboundRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "restake_provider_http_requests_total",
+			Help: "Total outbound provider HTTP requests, labeled by provider, chain, status code and method.",
+		},
+		[]string{"provider", "chain", "code", "method"},
+	)
+
+	outboundRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "restake_provider_http_request_duration_seconds",
+			Help:    "Outbound provider HTTP request latency in seconds, labeled by provider, chain and method.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"provider", "chain", "method"},
+	)
+
+	outboundRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "restake_provider_http_requests_in_flight",
+			Help: "Number of in-flight outbound provider HTTP requests, labeled by provider and chain.",
+		},
+		[]string{"provider", "chain"},
+	)
+
+	outboundTraceDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "restake_provider_http_trace_duration_seconds",
+			Help:    "Outbound provider HTTP connection-phase timings (DNS, connect, TLS, time-to-first-byte), labeled by provider, chain and event.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"provider", "chain", "event"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(outboundRequestsTotal, outboundRequestDuration, outboundRequestsInFlight, outboundTraceDuration)
+}
+
+// WrapClient instruments c's RoundTripper with the outbound request
+// counter/duration/in-flight metrics above plus DNS/connect/TLS/TTFB trace
+// timings, all labeled by provider (and chain, for multi-chain clients that
+// know it). Every provider constructor should call this once so outbound
+// latency and error rates are observable uniformly across providers.
+func WrapClient(provider string, c *http.Client) *http.Client {
+	return WrapClientForChain(provider, "", c)
+}
+
+// WrapClientForChain is WrapClient for callers, such as MultiChainClient,
+// that also know which chain the client talks to.
+func WrapClientForChain(provider, chain string, c *http.Client) *http.Client {
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	labels := prometheus.Labels{"provider": provider, "chain": chain}
+	counter := outboundRequestsTotal.MustCurryWith(labels)
+	duration := outboundRequestDuration.MustCurryWith(labels)
+	inFlight := outboundRequestsInFlight.With(labels)
+	trace := outboundTraceDuration.MustCurryWith(labels)
+
+	traced := promhttp.InstrumentRoundTripperTrace(&promhttp.InstrumentTrace{
+		DNSStart:          func(t float64) { trace.WithLabelValues("dns_start").Observe(t) },
+		DNSDone:           func(t float64) { trace.WithLabelValues("dns_done").Observe(t) },
+		ConnectStart:      func(t float64) { trace.WithLabelValues("connect_start").Observe(t) },
+		ConnectDone:       func(t float64) { trace.WithLabelValues("connect_done").Observe(t) },
+		TLSHandshakeStart: func(t float64) { trace.WithLabelValues("tls_start").Observe(t) },
+		TLSHandshakeDone:  func(t float64) { trace.WithLabelValues("tls_done").Observe(t) },
+		GotFirstResponseByte: func(t float64) {
+			trace.WithLabelValues("ttfb").Observe(t)
+		},
+	}, base)
+
+	instrumented := promhttp.InstrumentRoundTripperInFlight(inFlight,
+		promhttp.InstrumentRoundTripperDuration(duration,
+			promhttp.InstrumentRoundTripperCounter(counter, traced)))
+
+	wrapped := *c
+	wrapped.Transport = instrumented
+	return &wrapped
+}
+
+// ProviderLatencySnapshot summarizes p50/p95 request latency for one
+// provider, used by /status to surface per-provider health alongside the
+// raw Prometheus histograms.
+type ProviderLatencySnapshot struct {
+	Provider string  `json:"provider"`
+	P50      float64 `json:"p50_seconds"`
+	P95      float64 `json:"p95_seconds"`
+}
+
+// ProviderLatencySnapshots reads the outbound request duration histogram and
+// returns a p50/p95 estimate per provider label, suitable for embedding in a
+// status endpoint response. Series for the same provider across chains are
+// merged before estimating quantiles.
+func ProviderLatencySnapshots() ([]ProviderLatencySnapshot, error) {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]map[float64]uint64) // provider -> upper bound -> cumulative count
+	counts := make(map[string]uint64)
+	order := make([]string, 0)
+
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "restake_provider_http_request_duration_seconds" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			provider := labelValue(m, "provider")
+			if provider == "" {
+				continue
+			}
+			buckets, ok := merged[provider]
+			if !ok {
+				buckets = make(map[float64]uint64)
+				merged[provider] = buckets
+				order = append(order, provider)
+			}
+			for _, b := range m.GetHistogram().GetBucket() {
+				buckets[b.GetUpperBound()] += b.GetCumulativeCount()
+			}
+			counts[provider] += m.GetHistogram().GetSampleCount()
+		}
+	}
+
+	snapshots := make([]ProviderLatencySnapshot, 0, len(order))
+	for _, provider := range order {
+		snapshots = append(snapshots, ProviderLatencySnapshot{
+			Provider: provider,
+			P50:      quantileFromBuckets(merged[provider], counts[provider], 0.50),
+			P95:      quantileFromBuckets(merged[provider], counts[provider], 0.95),
+		})
+	}
+	return snapshots, nil
+}
+
+// labelValue returns the value of the named label on m, or "" if absent.
+func labelValue(m *dto.Metric, name string) string {
+	for _, lp := range m.GetLabel() {
+		if lp.GetName() == name {
+			return lp.GetValue()
+		}
+	}
+	return ""
+}
+
+// quantileFromBuckets estimates a quantile from cumulative histogram buckets
+// via linear interpolation within the bucket containing the target rank,
+// the same approximation Prometheus's histogram_quantile() uses.
+func quantileFromBuckets(buckets map[float64]uint64, total uint64, q float64) float64 {
+	if total == 0 || len(buckets) == 0 {
+		return 0
+	}
+
+	bounds := make([]float64, 0, len(buckets))
+	for b := range buckets {
+		bounds = append(bounds, b)
+	}
+	sort.Float64s(bounds)
+
+	target := q * float64(total)
+	var prevBound float64
+	var prevCount uint64
+	for _, b := range bounds {
+		count := buckets[b]
+		if float64(count) >= target {
+			if math.IsInf(b, 1) {
+				return prevBound
+			}
+			if count == prevCount {
+				return b
+			}
+			fraction := (target - float64(prevCount)) / float64(count-prevCount)
+			return prevBound + fraction*(b-prevBound)
+		}
+		prevBound = b
+		prevCount = count
+	}
+	return prevBound
+}
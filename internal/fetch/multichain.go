@@ -10,8 +10,11 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
+	"github.com/yourorg/restake-yield-ea/internal/attest"
 	"github.com/yourorg/restake-yield-ea/internal/model"
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // Using types from shared package
@@ -36,65 +39,128 @@ const (
 // ChainConfig holds configuration for a specific blockchain network
 type ChainConfig = types.ChainConfig
 
-// MultiChainClient can fetch data from multiple blockchains
-type MultiChainClient struct {
-	httpClient    *http.Client
+// Handler is the transport-agnostic core of multi-chain fetching: it owns
+// provider registration, per-chain caching, and fan-out across providers,
+// without knowing or caring whether a given Provider talks HTTP, a
+// websocket subscription, gRPC or an IPC socket underneath (see
+// Transport). MultiChainClient wraps a Handler for backward compatibility
+// with existing callers.
+type Handler struct {
 	chains        map[SupportedChain]ChainConfig
 	dataProviders map[SupportedChain][]Provider
 	mutex         sync.RWMutex
 	cacheTTL      time.Duration
 	cachedData    map[SupportedChain][]model.Metric
 	cacheTime     map[SupportedChain]time.Time
+
+	// adaptive tracks per-(chain, provider) latency and circuit breaker
+	// state, so a slow or repeatedly failing provider gets a tighter
+	// timeout and an eventual cooldown instead of stalling every Fetch
+	// behind a fixed deadline.
+	adaptive *adaptiveController
+
+	// refreshGroup deduplicates concurrent cache-miss refreshes for the
+	// same chain: N callers hitting an expired cache entry at once trigger
+	// exactly one fetchAllProviders call, not N parallel ones.
+	refreshGroup singleflight.Group
+
+	// providerResults is a bounded LRU of the most recent successful
+	// result per (chain, provider) pair, reused across Fetch calls
+	// independent of the per-chain cacheTTL/cacheTime above.
+	providerResults *providerCache
+
+	// signer, if set via SetSigner, signs every metric Fetch collects so
+	// downstream consumers can verify chain of custody. Nil by default.
+	signer *attest.Signer
 }
 
-// NewMultiChainClient creates a client that can fetch from multiple chains
-func NewMultiChainClient(chains map[SupportedChain]ChainConfig) *MultiChainClient {
-	retryClient := newRetryClient()
-	
-	return &MultiChainClient{
-		httpClient:    StandardClient(retryClient),
-		chains:        chains,
-		dataProviders: make(map[SupportedChain][]Provider),
-		cacheTTL:      5 * time.Minute,
-		cachedData:    make(map[SupportedChain][]model.Metric),
-		cacheTime:     make(map[SupportedChain]time.Time),
+// NewHandler creates a Handler that can fetch from the given chains.
+// Providers are registered afterward via RegisterProvider, or derived
+// on demand by createDefaultProvider when none were registered.
+func NewHandler(chains map[SupportedChain]ChainConfig) *Handler {
+	return &Handler{
+		chains:          chains,
+		dataProviders:   make(map[SupportedChain][]Provider),
+		cacheTTL:        5 * time.Minute,
+		cachedData:      make(map[SupportedChain][]model.Metric),
+		cacheTime:       make(map[SupportedChain]time.Time),
+		adaptive:        newAdaptiveController(),
+		providerResults: newProviderCache(),
 	}
 }
 
+// InvalidateChain drops the cached result for chain, so the next Fetch
+// call blocks on a fresh provider fetch instead of serving stale data.
+func (c *Handler) InvalidateChain(chain SupportedChain) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.cachedData, chain)
+	delete(c.cacheTime, chain)
+}
+
+// InvalidateAll drops every cached chain result and the per-provider result
+// cache.
+func (c *Handler) InvalidateAll() {
+	c.mutex.Lock()
+	c.cachedData = make(map[SupportedChain][]model.Metric)
+	c.cacheTime = make(map[SupportedChain]time.Time)
+	c.mutex.Unlock()
+	c.providerResults.invalidate()
+}
+
+// SetSigner configures a Signer that Fetch uses to sign every metric it
+// collects before returning it; pass nil to disable signing (the default).
+func (c *Handler) SetSigner(s *attest.Signer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.signer = s
+}
+
+// Stats returns the current per-(chain, provider) circuit breaker state and
+// latency estimates, so operators can wire them into Prometheus gauges
+// alongside the outbound HTTP metrics in metrics.go.
+func (c *Handler) Stats() ClientStats {
+	return c.adaptive.stats()
+}
+
 // RegisterProvider adds a data provider for a specific chain
-func (c *MultiChainClient) RegisterProvider(chain SupportedChain, provider Provider) {
+func (c *Handler) RegisterProvider(chain SupportedChain, provider Provider) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	
+
 	c.dataProviders[chain] = append(c.dataProviders[chain], provider)
-	logrus.Infof("Registered provider for chain %s", chain)
+	logging.WithFields(logging.Fields{
+		"component": "multichain",
+		"chain_id":  string(chain),
+	}).Info("Registered provider for chain")
 }
 
 // Fetch retrieves data from all enabled chains
-func (c *MultiChainClient) Fetch(ctx context.Context) ([]model.Metric, error) {
+func (c *Handler) Fetch(ctx context.Context) ([]model.Metric, error) {
 	c.mutex.RLock()
 	enabledChains := c.getEnabledChains()
+	signer := c.signer
 	c.mutex.RUnlock()
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	
+
 	allMetrics := make([]model.Metric, 0)
 	errors := make(map[SupportedChain]error)
-	
+
 	// Create a channel for results
 	resultCh := make(chan struct {
 		chain   SupportedChain
 		metrics []model.Metric
 		err     error
 	}, len(enabledChains))
-	
+
 	// Launch a goroutine for each chain
 	for _, chain := range enabledChains {
 		wg.Add(1)
 		go func(chain SupportedChain) {
 			defer wg.Done()
-			
+
 			metrics, err := c.fetchChainData(ctx, chain)
 			resultCh <- struct {
 				chain   SupportedChain
@@ -103,27 +169,30 @@ func (c *MultiChainClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 			}{chain, metrics, err}
 		}(chain)
 	}
-	
+
 	// Launch a goroutine to close the channel when all fetches are done
 	go func() {
 		wg.Wait()
 		close(resultCh)
 	}()
-	
+
 	// Collect results from the channel
 	for result := range resultCh {
 		if result.err != nil {
 			errors[result.chain] = result.err
-			logrus.Warnf("Error fetching data for chain %s: %v", result.chain, result.err)
+			logging.WithFields(logging.Fields{
+				"component": "multichain",
+				"chain_id":  string(result.chain),
+			}).Warnf("Error fetching data for chain: %v", result.err)
 			continue
 		}
-		
+
 		// Add chain information to each metric
 		chainMetrics := make([]model.Metric, len(result.metrics))
 		for i, metric := range result.metrics {
 			chainMetric := metric
 			chainMetric.Chain = string(result.chain)
-			
+
 			// Add chain-specific weight for cross-chain aggregation
 			chainConfig, ok := c.chains[result.chain]
 			if ok {
@@ -131,51 +200,92 @@ func (c *MultiChainClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 			} else {
 				chainMetric.Weight = 1.0 // Default weight
 			}
-			
+
+			if signer != nil {
+				chainMetric = signer.Sign(chainMetric)
+			}
+
 			chainMetrics[i] = chainMetric
 		}
-		
+
 		mu.Lock()
 		allMetrics = append(allMetrics, chainMetrics...)
 		mu.Unlock()
-		
-		// Update cache
-		c.mutex.Lock()
-		c.cachedData[result.chain] = result.metrics
-		c.cacheTime[result.chain] = time.Now()
-		c.mutex.Unlock()
+
+		// The chain-level cache itself is updated inside
+		// fetchAllProviders (the only place that performs a genuine
+		// fresh fetch), not here - doing it here too would bump
+		// cacheTime on every stale-while-revalidate hit and defeat the
+		// staleness check in fetchChainData.
 	}
-	
+
 	if len(allMetrics) == 0 && len(errors) > 0 {
 		// If all chains failed, return the first error
 		for _, err := range errors {
 			return nil, fmt.Errorf("multi-chain fetch failed: %w", err)
 		}
 	}
-	
-	logrus.Infof("Fetched metrics from %d/%d chains, total metrics: %d", 
+
+	logging.WithField("component", "multichain").Infof("Fetched metrics from %d/%d chains, total metrics: %d",
 		len(enabledChains)-len(errors), len(enabledChains), len(allMetrics))
-	
+
 	return allMetrics, nil
 }
 
-// fetchChainData retrieves data for a specific chain, using cache if available
-func (c *MultiChainClient) fetchChainData(ctx context.Context, chain SupportedChain) ([]model.Metric, error) {
-	// Check cache first
+// fetchChainData retrieves data for a specific chain, using cache if
+// available. It implements stale-while-revalidate semantics: fresh cache
+// (< cacheTTL old) is returned immediately; stale-but-usable cache
+// (< 2*cacheTTL old) is also returned immediately, with a refresh kicked
+// off in the background; anything older blocks on a fresh fetch. Every
+// fetch - background or blocking - goes through refreshGroup so N
+// concurrent callers past the same expiry only trigger one round of
+// provider calls, not N.
+func (c *Handler) fetchChainData(ctx context.Context, chain SupportedChain) ([]model.Metric, error) {
 	c.mutex.RLock()
-	if metrics, ok := c.cachedData[chain]; ok {
-		if time.Since(c.cacheTime[chain]) < c.cacheTTL {
-			c.mutex.RUnlock()
-			return metrics, nil
+	cached, haveCached := c.cachedData[chain]
+	age := time.Since(c.cacheTime[chain])
+	c.mutex.RUnlock()
+
+	if haveCached {
+		if age < c.cacheTTL {
+			return cached, nil
+		}
+		if age < 2*c.cacheTTL {
+			go func() {
+				// Background refresh: a fresh context, since ctx belongs
+				// to the caller that's about to return with the stale
+				// value and may be canceled the moment it does.
+				if _, err, _ := c.refreshGroup.Do(string(chain), func() (interface{}, error) {
+					return c.fetchAllProviders(context.Background(), chain)
+				}); err != nil {
+					logging.WithFields(logging.Fields{
+						"component": "multichain",
+						"chain_id":  string(chain),
+					}).Warnf("background cache refresh failed: %v", err)
+				}
+			}()
+			return cached, nil
 		}
 	}
-	c.mutex.RUnlock()
-	
+
+	result, err, _ := c.refreshGroup.Do(string(chain), func() (interface{}, error) {
+		return c.fetchAllProviders(ctx, chain)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]model.Metric), nil
+}
+
+// fetchAllProviders fans out to every provider registered for chain,
+// updates the chain-level and per-provider caches on success, and is the
+// function refreshGroup dedupes concurrent callers onto.
+func (c *Handler) fetchAllProviders(ctx context.Context, chain SupportedChain) ([]model.Metric, error) {
 	// Get providers for this chain
 	c.mutex.RLock()
 	providers := c.dataProviders[chain]
 	c.mutex.RUnlock()
-	
+
 	if len(providers) == 0 {
 		// Try using default provider for this chain
 		defaultProvider, err := c.createDefaultProvider(chain)
@@ -184,56 +294,83 @@ func (c *MultiChainClient) fetchChainData(ctx context.Context, chain SupportedCh
 		}
 		providers = []Provider{defaultProvider}
 	}
-	
+
 	// Fetch from all providers for this chain
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	metrics := make([]model.Metric, 0)
 	providerErrors := make([]error, 0)
-	
-	for _, provider := range providers {
+
+	for i, provider := range providers {
 		wg.Add(1)
-		go func(p Provider) {
+		go func(p Provider, index int) {
 			defer wg.Done()
-			
-			// Create a timeout context for this provider
-			providerCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+			key := providerKey(chain, providerLabel(p, index))
+			breaker := c.adaptive.breakerFor(key)
+			if !breaker.allow() {
+				mu.Lock()
+				providerErrors = append(providerErrors, fmt.Errorf("%s: %w", key, errBreakerOpen))
+				mu.Unlock()
+				return
+			}
+
+			// Adaptive per-provider timeout: k * p95 of recent latency,
+			// clamped to [minTimeout, maxTimeout], instead of one fixed
+			// deadline for every provider regardless of how fast it is.
+			timeout := c.adaptive.timeoutFor(key)
+			providerCtx, cancel := context.WithTimeout(ctx, timeout)
 			defer cancel()
-			
+
+			start := time.Now()
 			providerMetrics, err := p.Fetch(providerCtx)
+			c.adaptive.latencyFor(key).record(time.Since(start))
+
 			if err != nil {
+				breaker.recordFailure()
 				mu.Lock()
-				providerErrors = append(providerErrors, err)
+				if stale, _, ok := c.providerResults.get(key); ok {
+					metrics = append(metrics, stale...)
+				} else {
+					providerErrors = append(providerErrors, err)
+				}
 				mu.Unlock()
 				return
 			}
-			
+			breaker.recordSuccess()
+			c.providerResults.put(key, providerMetrics)
+
 			mu.Lock()
 			metrics = append(metrics, providerMetrics...)
 			mu.Unlock()
-		}(provider)
+		}(provider, i)
 	}
-	
+
 	wg.Wait()
-	
+
 	if len(metrics) == 0 && len(providerErrors) > 0 {
 		return nil, fmt.Errorf("all providers failed for chain %s", chain)
 	}
-	
+
+	c.mutex.Lock()
+	c.cachedData[chain] = metrics
+	c.cacheTime[chain] = time.Now()
+	c.mutex.Unlock()
+
 	return metrics, nil
 }
 
 // createDefaultProvider creates a basic provider for the specified chain
-func (c *MultiChainClient) createDefaultProvider(chain SupportedChain) (Provider, error) {
+func (c *Handler) createDefaultProvider(chain SupportedChain) (Provider, error) {
 	// Get chain config
 	c.mutex.RLock()
 	chainConfig, ok := c.chains[chain]
 	c.mutex.RUnlock()
-	
+
 	if !ok || !chainConfig.Enabled {
 		return nil, fmt.Errorf("chain %s not configured or disabled", chain)
 	}
-	
+
 	// Create an appropriate provider based on the chain
 	switch chain {
 	case ChainEthereum:
@@ -248,19 +385,42 @@ func (c *MultiChainClient) createDefaultProvider(chain SupportedChain) (Provider
 	}
 }
 
+// providerLabel identifies p for the adaptive controller's per-provider
+// keying: its Name() if it implements one (as EigenLayerClient and
+// RPCProvider do), otherwise its registration index within the chain.
+func providerLabel(p Provider, index int) string {
+	if named, ok := p.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("provider-%d", index)
+}
+
 // getEnabledChains returns a list of chains that are enabled
-func (c *MultiChainClient) getEnabledChains() []SupportedChain {
+func (c *Handler) getEnabledChains() []SupportedChain {
 	var enabledChains []SupportedChain
-	
+
 	for chain, config := range c.chains {
 		if config.Enabled {
 			enabledChains = append(enabledChains, chain)
 		}
 	}
-	
+
 	return enabledChains
 }
 
+// MultiChainClient is the existing public entry point kept for backward
+// compatibility: it's a thin wrapper around a Handler, so every pre-existing
+// caller (NewMultiChainClient, RegisterProvider, Fetch, Stats) keeps working
+// unchanged while the fan-out/caching logic itself now lives in Handler.
+type MultiChainClient struct {
+	*Handler
+}
+
+// NewMultiChainClient creates a client that can fetch from multiple chains
+func NewMultiChainClient(chains map[SupportedChain]ChainConfig) *MultiChainClient {
+	return &MultiChainClient{Handler: NewHandler(chains)}
+}
+
 // GenericChainProvider is a fallback provider for any supported chain
 type GenericChainProvider struct {
 	chain      string
@@ -275,7 +435,7 @@ func NewGenericChainProvider(chain, apiURL, apiKey string) *GenericChainProvider
 		chain:      chain,
 		apiURL:     apiURL,
 		apiKey:     apiKey,
-		httpClient: StandardClient(newRetryClient()),
+		httpClient: WrapClientForChain(chain, chain, StandardClient(newRetryClient())),
 	}
 }
 
@@ -285,23 +445,23 @@ func (p *GenericChainProvider) Fetch(ctx context.Context) ([]model.Metric, error
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
-	
+
 	if p.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+p.apiKey)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	
+
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching data from %s: %w", p.chain, err)
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("%s API error: status %d, body: %s", p.chain, resp.StatusCode, string(body))
 	}
-	
+
 	var response struct {
 		Data []struct {
 			Protocol     string  `json:"protocol"`
@@ -311,15 +471,15 @@ func (p *GenericChainProvider) Fetch(ctx context.Context) ([]model.Metric, error
 			Timestamp    int64   `json:"timestamp"`
 		} `json:"data"`
 	}
-	
+
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
-	
+
 	if len(response.Data) == 0 {
 		return nil, fmt.Errorf("no data returned from %s", p.chain)
 	}
-	
+
 	metrics := make([]model.Metric, 0, len(response.Data))
 	for _, data := range response.Data {
 		metrics = append(metrics, model.Metric{
@@ -331,7 +491,7 @@ func (p *GenericChainProvider) Fetch(ctx context.Context) ([]model.Metric, error
 			CollectedAt:  data.Timestamp,
 		})
 	}
-	
+
 	return metrics, nil
 }
 
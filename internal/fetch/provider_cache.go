@@ -0,0 +1,90 @@
+package fetch
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// maxProviderCacheEntries bounds the per-provider result cache so a large
+// or constantly-churning provider set can't grow it without limit; the
+// least-recently-used (chain, provider) entry is evicted once the cap is
+// hit, mirroring internal/logging's dedup LRU.
+const maxProviderCacheEntries = 256
+
+// providerCacheEntry is one (chain, provider) result, tracked in the LRU
+// list so per-provider fetches can be reused across MultiChainClient.Fetch
+// calls that happen to want the same chain+provider pair (e.g. a generic
+// provider registered under two aliases).
+type providerCacheEntry struct {
+	key     string
+	metrics []model.Metric
+	at      time.Time
+	elem    *list.Element
+}
+
+// providerCache is a bounded LRU of the most recent successful result per
+// (chain, provider) pair, keyed the same way as adaptiveController
+// (providerKey).
+type providerCache struct {
+	mu      sync.Mutex
+	entries map[string]*providerCacheEntry
+	order   *list.List // front = most recently used
+}
+
+func newProviderCache() *providerCache {
+	return &providerCache{
+		entries: make(map[string]*providerCacheEntry),
+		order:   list.New(),
+	}
+}
+
+// get returns the cached metrics for key and how long ago they were
+// stored, if present.
+func (c *providerCache) get(key string) ([]model.Metric, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	c.order.MoveToFront(entry.elem)
+	return entry.metrics, time.Since(entry.at), true
+}
+
+// put stores metrics for key, evicting the least-recently-used entry if
+// the cache is at capacity and key is new.
+func (c *providerCache) put(key string, metrics []model.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.metrics = metrics
+		entry.at = time.Now()
+		c.order.MoveToFront(entry.elem)
+		return
+	}
+
+	entry := &providerCacheEntry{key: key, metrics: metrics, at: time.Now()}
+	entry.elem = c.order.PushFront(entry)
+	c.entries[key] = entry
+
+	if len(c.entries) > maxProviderCacheEntries {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*providerCacheEntry).key)
+		}
+	}
+}
+
+// invalidate removes every cached entry, used by Handler.InvalidateAll.
+func (c *providerCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*providerCacheEntry)
+	c.order = list.New()
+}
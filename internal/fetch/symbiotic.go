@@ -11,13 +11,26 @@ import (
 	"github.com/yourorg/restake-yield-ea/internal/model"
 )
 
+// SymbioticClient implements a client for the Symbiotic API.
 type SymbioticClient struct {
-	cfg config.Config
+	cfg        config.Config
+	httpClient *http.Client
 }
 
+// NewSymbioticClient creates a new Symbiotic API client.
+func NewSymbioticClient() *SymbioticClient {
+	cfg := config.Load()
+	return &SymbioticClient{
+		cfg:        cfg,
+		httpClient: WrapClient("symbiotic", StandardClient(newRetryClient())),
+	}
+}
+
+// Name identifies this provider for per-provider fallback caching and logs.
+func (c *SymbioticClient) Name() string { return "symbiotic" }
+
 func (c *SymbioticClient) Fetch(ctx context.Context) ([]model.Metric, error) {
-	client := newRetryClient()
-	req, err := retryablehttp.NewRequest("GET", c.cfg.SymbioticURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.SymbioticURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -26,7 +39,7 @@ func (c *SymbioticClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 		req.Header.Set("Authorization", k)
 	}
 
-	resp, err := client.Do(req.WithContext(ctx))
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -56,4 +69,4 @@ func (c *SymbioticClient) Fetch(ctx context.Context) ([]model.Metric, error) {
 			Provider:     "symbiotic",
 		},
 	}, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,333 @@
+package fetch
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"golang.org/x/time/rate"
+)
+
+// TransportOptions configures the shared Transport used by every provider client.
+type TransportOptions struct {
+	// MaxRetries is the maximum number of attempts after the initial request.
+	MaxRetries int
+
+	// BaseBackoff and MaxBackoff bound the exponential-backoff-with-full-jitter
+	// delay between retries.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// PerHostRPS and PerHostBurst configure a token-bucket rate limiter keyed
+	// by request host.
+	PerHostRPS   float64
+	PerHostBurst int
+
+	// RequestTimeout derives a per-request deadline from the caller's context
+	// if the context has no earlier deadline of its own.
+	RequestTimeout time.Duration
+
+	// HedgeAfter fires a second, concurrent attempt if the first hasn't
+	// returned within this duration. Zero disables hedging.
+	HedgeAfter time.Duration
+
+	// FailureThreshold is the number of consecutive 5xx/transport errors on a
+	// host before the per-host circuit trips and fails fast.
+	FailureThreshold int
+
+	// CircuitCooldown is how long the per-host circuit stays open before
+	// allowing a probe request through.
+	CircuitCooldown time.Duration
+
+	// Base is the underlying RoundTripper; defaults to http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// DefaultTransportOptions returns sensible defaults for provider API traffic.
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		MaxRetries:       3,
+		BaseBackoff:      200 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		PerHostRPS:       10,
+		PerHostBurst:     20,
+		RequestTimeout:   10 * time.Second,
+		HedgeAfter:       0,
+		FailureThreshold: 5,
+		CircuitCooldown:  30 * time.Second,
+	}
+}
+
+// hostStats tracks per-host delivery statistics and the simple fail-fast
+// circuit used to avoid hammering a broker/provider that's already down.
+type hostStats struct {
+	mu               sync.Mutex
+	attempts         uint64
+	retries          uint64
+	hedges           uint64
+	errors           uint64
+	consecutiveFails int
+	circuitOpenUntil time.Time
+	limiter          *rate.Limiter
+}
+
+// Transport is a shared http.RoundTripper providing exponential backoff with
+// full jitter, Retry-After honoring, per-host rate limiting, per-request
+// deadlines, hedged requests, and a per-host fail-fast circuit. It is used by
+// every provider client so retry/backoff behavior only needs to live in one
+// place.
+type Transport struct {
+	opts TransportOptions
+
+	mu    sync.Mutex
+	hosts map[string]*hostStats
+}
+
+// NewTransport creates a Transport with the given options, filling in
+// defaults for anything left zero-valued.
+func NewTransport(opts TransportOptions) *Transport {
+	if opts.Base == nil {
+		opts.Base = http.DefaultTransport
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultTransportOptions().MaxRetries
+	}
+	if opts.BaseBackoff == 0 {
+		opts.BaseBackoff = DefaultTransportOptions().BaseBackoff
+	}
+	if opts.MaxBackoff == 0 {
+		opts.MaxBackoff = DefaultTransportOptions().MaxBackoff
+	}
+	if opts.FailureThreshold == 0 {
+		opts.FailureThreshold = DefaultTransportOptions().FailureThreshold
+	}
+	if opts.CircuitCooldown == 0 {
+		opts.CircuitCooldown = DefaultTransportOptions().CircuitCooldown
+	}
+	return &Transport{opts: opts, hosts: make(map[string]*hostStats)}
+}
+
+func (t *Transport) statsFor(host string) *hostStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hs, ok := t.hosts[host]
+	if !ok {
+		hs = &hostStats{}
+		if t.opts.PerHostRPS > 0 {
+			hs.limiter = rate.NewLimiter(rate.Limit(t.opts.PerHostRPS), t.opts.PerHostBurst)
+		}
+		t.hosts[host] = hs
+	}
+	return hs
+}
+
+// HostStats exposes per-host attempt/retry/hedge/error counters for the
+// enterprise exporter to surface on dashboards.
+func (t *Transport) HostStats() map[string]map[string]uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make(map[string]map[string]uint64, len(t.hosts))
+	for host, hs := range t.hosts {
+		hs.mu.Lock()
+		out[host] = map[string]uint64{
+			"attempts": hs.attempts,
+			"retries":  hs.retries,
+			"hedges":   hs.hedges,
+			"errors":   hs.errors,
+		}
+		hs.mu.Unlock()
+	}
+	return out
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	hs := t.statsFor(host)
+
+	if open, retryAfter := t.circuitOpen(hs); open {
+		return nil, &circuitOpenError{host: host, retryAfter: retryAfter}
+	}
+
+	ctx := req.Context()
+	if t.opts.RequestTimeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, t.opts.RequestTimeout)
+			defer cancel()
+			req = req.WithContext(ctx)
+		}
+	}
+
+	if hs.limiter != nil {
+		if err := hs.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= t.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			hs.mu.Lock()
+			hs.retries++
+			hs.mu.Unlock()
+
+			delay := t.backoffDelay(attempt, lastErr)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		hs.mu.Lock()
+		hs.attempts++
+		hs.mu.Unlock()
+
+		resp, err := t.attempt(req, hs)
+		if err == nil && resp.StatusCode < 500 {
+			t.recordSuccess(hs)
+			return resp, nil
+		}
+
+		if err == nil {
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+			lastErr = &httpStatusError{statusCode: resp.StatusCode, retryAfter: retryAfter}
+		} else {
+			lastErr = err
+		}
+
+		t.recordFailure(hs)
+	}
+
+	return nil, lastErr
+}
+
+// attempt fires a single request, optionally racing a hedged duplicate if
+// HedgeAfter elapses before the primary attempt returns.
+func (t *Transport) attempt(req *http.Request, hs *hostStats) (*http.Response, error) {
+	if t.opts.HedgeAfter <= 0 {
+		return t.opts.Base.RoundTrip(req)
+	}
+
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+
+	primary := make(chan result, 1)
+	go func() {
+		resp, err := t.opts.Base.RoundTrip(req)
+		primary <- result{resp, err}
+	}()
+
+	select {
+	case r := <-primary:
+		return r.resp, r.err
+	case <-time.After(t.opts.HedgeAfter):
+		hs.mu.Lock()
+		hs.hedges++
+		hs.mu.Unlock()
+
+		hedged := make(chan result, 1)
+		go func() {
+			resp, err := t.opts.Base.RoundTrip(req.Clone(req.Context()))
+			hedged <- result{resp, err}
+		}()
+
+		select {
+		case r := <-primary:
+			return r.resp, r.err
+		case r := <-hedged:
+			return r.resp, r.err
+		}
+	}
+}
+
+// backoffDelay computes exponential backoff with full jitter, honoring a
+// Retry-After header when the prior error carries one.
+func (t *Transport) backoffDelay(attempt int, lastErr error) time.Duration {
+	if statusErr, ok := lastErr.(*httpStatusError); ok && statusErr.retryAfter > 0 {
+		return statusErr.retryAfter
+	}
+
+	capped := math.Min(float64(t.opts.MaxBackoff), float64(t.opts.BaseBackoff)*math.Pow(2, float64(attempt-1)))
+	return time.Duration(rand.Float64() * capped)
+}
+
+func (t *Transport) recordSuccess(hs *hostStats) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hs.consecutiveFails = 0
+}
+
+func (t *Transport) recordFailure(hs *hostStats) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hs.errors++
+	hs.consecutiveFails++
+	if hs.consecutiveFails >= t.opts.FailureThreshold {
+		hs.circuitOpenUntil = time.Now().Add(t.opts.CircuitCooldown)
+		logging.Warnf("fetch transport: per-host circuit tripped after %d consecutive failures", hs.consecutiveFails)
+	}
+}
+
+func (t *Transport) circuitOpen(hs *hostStats) (bool, time.Duration) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	if hs.circuitOpenUntil.IsZero() {
+		return false, 0
+	}
+	remaining := time.Until(hs.circuitOpenUntil)
+	if remaining <= 0 {
+		hs.circuitOpenUntil = time.Time{}
+		hs.consecutiveFails = 0
+		return false, 0
+	}
+	return true, remaining
+}
+
+// parseRetryAfter parses the Retry-After header, which may be either a
+// number of seconds or an HTTP-date. Unparseable or absent values return 0.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
+// httpStatusError wraps a non-2xx response so backoffDelay can honor
+// Retry-After without re-parsing the raw response.
+type httpStatusError struct {
+	statusCode int
+	retryAfter time.Duration
+}
+
+func (e *httpStatusError) Error() string {
+	return "upstream returned status " + strconv.Itoa(e.statusCode)
+}
+
+// circuitOpenError is returned immediately when a per-host circuit is open.
+type circuitOpenError struct {
+	host       string
+	retryAfter time.Duration
+}
+
+func (e *circuitOpenError) Error() string {
+	return "fetch transport: circuit open for host " + e.host + ", retry in " + e.retryAfter.String()
+}
@@ -0,0 +1,175 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/fetch/commons"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// StreamTransport is the pluggable transport abstraction a Provider uses to
+// actually talk to a data source. It's named distinctly from this
+// package's existing Transport (the http.RoundTripper in transport.go) to
+// avoid colliding with it - that type instruments the HTTP transport
+// layer, this interface is one layer up, abstracting which wire protocol a
+// provider speaks at all.
+//
+// Request is a single poll (used by Provider.Fetch); Subscribe is for
+// transports that can push updates (currently only WebSocketTransport) -
+// HTTPTransport, GRPCTransport and IPCTransport implement it by polling
+// Request on an interval, since request/response is all they support here.
+type StreamTransport interface {
+	// Request performs one request/response exchange and returns the
+	// decoded metrics.
+	Request(ctx context.Context) ([]model.Metric, error)
+
+	// Subscribe starts streaming updates to the returned channel until ctx
+	// is canceled, at which point the channel is closed.
+	Subscribe(ctx context.Context) (<-chan model.Metric, error)
+
+	// Close releases any held connection.
+	Close() error
+}
+
+// HTTPTransport is a StreamTransport backed by a single GET request per
+// poll - the transport GenericChainProvider has always used, now exposed
+// as a named, reusable implementation so new providers can share it.
+type HTTPTransport struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+	decode     func([]byte) ([]model.Metric, error)
+}
+
+// NewHTTPTransport builds an HTTPTransport that GETs url (with apiKey as a
+// bearer token, if non-empty) and decodes the response body with decode.
+func NewHTTPTransport(chain, url, apiKey string, decode func([]byte) ([]model.Metric, error)) *HTTPTransport {
+	return &HTTPTransport{
+		url:        url,
+		apiKey:     apiKey,
+		httpClient: WrapClientForChain("http-transport", chain, StandardClient(newRetryClient())),
+		decode:     decode,
+	}
+}
+
+// Request performs a single GET and decodes the body via t.decode.
+func (t *HTTPTransport) Request(ctx context.Context) ([]model.Metric, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http transport: building request: %w", err)
+	}
+	if t.apiKey != "" {
+		name, value := commons.AuthHeader(t.apiKey)
+		req.Header.Set(name, value)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http transport: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("http transport: reading body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http transport: status %d: %s", resp.StatusCode, string(body))
+	}
+	return t.decode(body)
+}
+
+// Subscribe polls Request every interval, since plain HTTP has no push
+// mechanism; pollInterval defaults to 30s if unset via WithPollInterval.
+func (t *HTTPTransport) Subscribe(ctx context.Context) (<-chan model.Metric, error) {
+	return pollingSubscribe(ctx, 30*time.Second, t.Request)
+}
+
+// Close is a no-op: HTTPTransport holds no persistent connection.
+func (t *HTTPTransport) Close() error { return nil }
+
+// pollingSubscribe adapts a Request-shaped poll function into a Subscribe
+// channel, shared by every transport that can't natively push updates.
+func pollingSubscribe(ctx context.Context, interval time.Duration, request func(context.Context) ([]model.Metric, error)) (<-chan model.Metric, error) {
+	out := make(chan model.Metric)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				metrics, err := request(ctx)
+				if err != nil {
+					continue
+				}
+				for _, m := range metrics {
+					select {
+					case out <- m:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// GRPCTransport and IPCTransport are placeholders for the two remaining
+// transports called out in the fetch-package transport split: neither
+// EigenLayer, Karak nor Symbiotic expose a gRPC or IPC yield-data API
+// today, so there is no concrete protocol to implement against yet. Both
+// are wired into the StreamTransport interface now so a real
+// implementation can be dropped in later without another interface
+// change; until then, Request/Subscribe return an explicit "not supported"
+// error rather than silently returning no data.
+
+// GRPCTransport is a StreamTransport stub for a future gRPC-based provider.
+type GRPCTransport struct {
+	target string
+}
+
+// NewGRPCTransport returns a GRPCTransport targeting addr. No provider in
+// this codebase currently speaks gRPC; Request/Subscribe report that
+// explicitly rather than pretending to succeed.
+func NewGRPCTransport(addr string) *GRPCTransport {
+	return &GRPCTransport{target: addr}
+}
+
+func (t *GRPCTransport) Request(ctx context.Context) ([]model.Metric, error) {
+	return nil, fmt.Errorf("grpc transport: no gRPC yield-data provider is configured for %s", t.target)
+}
+
+func (t *GRPCTransport) Subscribe(ctx context.Context) (<-chan model.Metric, error) {
+	return nil, fmt.Errorf("grpc transport: no gRPC yield-data provider is configured for %s", t.target)
+}
+
+func (t *GRPCTransport) Close() error { return nil }
+
+// IPCTransport is a StreamTransport stub for a future local-socket provider
+// (e.g. a sidecar node client exposing yield data over a unix socket).
+type IPCTransport struct {
+	socketPath string
+}
+
+// NewIPCTransport returns an IPCTransport for the unix socket at path.
+func NewIPCTransport(path string) *IPCTransport {
+	return &IPCTransport{socketPath: path}
+}
+
+func (t *IPCTransport) Request(ctx context.Context) ([]model.Metric, error) {
+	return nil, fmt.Errorf("ipc transport: no IPC yield-data provider is configured at %s", t.socketPath)
+}
+
+func (t *IPCTransport) Subscribe(ctx context.Context) (<-chan model.Metric, error) {
+	return nil, fmt.Errorf("ipc transport: no IPC yield-data provider is configured at %s", t.socketPath)
+}
+
+func (t *IPCTransport) Close() error { return nil }
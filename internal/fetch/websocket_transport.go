@@ -0,0 +1,120 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yourorg/restake-yield-ea/internal/fetch/commons"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// WebSocketTransport is a StreamTransport that holds an open websocket
+// connection and decodes each inbound message as a yield update, so a
+// provider that supports streaming (e.g. EigenLayer's APY feed) doesn't
+// need to be polled every cacheTTL - Subscribe delivers updates as they
+// arrive instead. Request is still supported for callers that just want
+// one value: it opens a connection, waits for the first message, and
+// closes it again.
+type WebSocketTransport struct {
+	url    string
+	decode func([]byte) ([]model.Metric, error)
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+// NewWebSocketTransport builds a WebSocketTransport that dials url lazily
+// on first use and decodes each text/binary message with decode.
+func NewWebSocketTransport(url string, decode func([]byte) ([]model.Metric, error)) *WebSocketTransport {
+	return &WebSocketTransport{url: url, decode: decode}
+}
+
+func (t *WebSocketTransport) dial(ctx context.Context) (*websocket.Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn != nil {
+		return t.conn, nil
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, t.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("websocket transport: dialing %s: %w", t.url, err)
+	}
+	t.conn = conn
+	return conn, nil
+}
+
+// Request opens a connection if needed, waits for a single message, and
+// returns its decoded metrics.
+func (t *WebSocketTransport) Request(ctx context.Context) ([]model.Metric, error) {
+	conn, err := t.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("websocket transport: reading message: %w", err)
+	}
+	return t.decode(data)
+}
+
+// Subscribe dials once and streams every decoded message to the returned
+// channel until ctx is canceled or the connection drops, reconnecting with
+// commons.Backoff between attempts so a restart of the upstream feed
+// doesn't permanently end the subscription.
+func (t *WebSocketTransport) Subscribe(ctx context.Context) (<-chan model.Metric, error) {
+	out := make(chan model.Metric)
+
+	go func() {
+		defer close(out)
+		attempt := 0
+		for ctx.Err() == nil {
+			conn, err := t.dial(ctx)
+			if err != nil {
+				logging.WithField("component", "fetch.websocket").Warnf("connect failed: %v", err)
+				time.Sleep(commons.Backoff(attempt, time.Second, 30*time.Second))
+				attempt++
+				continue
+			}
+			attempt = 0
+
+			for {
+				_, data, err := conn.ReadMessage()
+				if err != nil {
+					t.mu.Lock()
+					t.conn = nil
+					t.mu.Unlock()
+					break
+				}
+				metrics, err := t.decode(data)
+				if err != nil {
+					continue
+				}
+				for _, m := range metrics {
+					select {
+					case out <- m:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Close closes the underlying connection, if one is open.
+func (t *WebSocketTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
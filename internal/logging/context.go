@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"context"
+)
+
+// ctxKey namespaces context values so this package never collides with keys
+// set by other packages.
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	jobRunIDKey
+	aggregationModeKey
+	providerCountKey
+)
+
+// WithRequestID attaches the Chainlink request ID to ctx for every log line
+// written via L(ctx) downstream.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// WithJobRunID attaches the Chainlink job run ID to ctx.
+func WithJobRunID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, jobRunIDKey, id)
+}
+
+// WithAggregationMode attaches the active aggregation mode to ctx.
+func WithAggregationMode(ctx context.Context, mode string) context.Context {
+	return context.WithValue(ctx, aggregationModeKey, mode)
+}
+
+// WithProviderCount attaches the number of providers consulted for this
+// request to ctx.
+func WithProviderCount(ctx context.Context, count int) context.Context {
+	return context.WithValue(ctx, providerCountKey, count)
+}
+
+// L returns an Entry augmented with whatever request-scoped attributes have
+// been attached to ctx via the With* helpers above, so call sites keep the
+// same Debugf/Infof/Warnf/Errorf ergonomics as the package-level functions.
+func L(ctx context.Context) *Entry {
+	return &Entry{attrs: attrsFromContext(ctx)}
+}
+
+func attrsFromContext(ctx context.Context) []any {
+	if ctx == nil {
+		return nil
+	}
+
+	var attrs []any
+	if v, ok := ctx.Value(requestIDKey).(string); ok {
+		attrs = append(attrs, "request_id", v)
+	}
+	if v, ok := ctx.Value(jobRunIDKey).(string); ok {
+		attrs = append(attrs, "job_run_id", v)
+	}
+	if v, ok := ctx.Value(aggregationModeKey).(string); ok {
+		attrs = append(attrs, "aggregation_mode", v)
+	}
+	if v, ok := ctx.Value(providerCountKey).(int); ok {
+		attrs = append(attrs, "provider_count", v)
+	}
+	return attrs
+}
@@ -0,0 +1,212 @@
+package logging
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultDedupWindow is how long an identical warn/error line is suppressed
+// before its coalesced summary is flushed.
+const DefaultDedupWindow = 10 * time.Second
+
+// maxDedupEntries bounds the LRU so a flood of distinct messages (as opposed
+// to repeats of the same one) can't grow the handler's memory without
+// limit; the oldest tracked key is evicted (and flushed) once the cap is hit.
+const maxDedupEntries = 1024
+
+// dedupState is the suppressed-repeat bookkeeping for one (level, message,
+// error-class) key.
+type dedupState struct {
+	key      string
+	record   slog.Record
+	count    int
+	lastSeen time.Time
+	elem     *list.Element
+}
+
+// dedupStore is the LRU of in-flight suppressed keys, shared by every
+// dedupHandler derived from the same root via WithAttrs/WithGroup so calling
+// slog.Logger.With(...) (as L(ctx) and Entry do on every log call) doesn't
+// fork the dedup state or leak an expiry goroutine per call.
+type dedupStore struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*dedupState
+	order   *list.List // front = most recently seen
+
+	// flush emits a suppressed-repeat summary via the handler that created
+	// this store, set once up front so the background expiry loop has
+	// somewhere to send what it finds.
+	flush func(*dedupState)
+}
+
+func newDedupStore(window time.Duration, flush func(*dedupState)) *dedupStore {
+	s := &dedupStore{
+		window:  window,
+		entries: make(map[string]*dedupState),
+		order:   list.New(),
+		flush:   flush,
+	}
+	go s.expireLoop()
+	return s
+}
+
+// dedupHandler wraps a slog.Handler and coalesces repeated warn/error
+// records sharing a (level, message, error-class) key against the shared
+// store: the first occurrence passes straight through so an incident is
+// visible immediately, later repeats within window are counted rather than
+// re-emitted, and once a key goes window-long without a repeat its
+// suppressed count and last-seen timestamp are flushed as a single summary
+// line. This keeps an error storm ("provider X failed" x10000 during an
+// outage) from flooding the log while still reporting how bad it was.
+type dedupHandler struct {
+	next  slog.Handler
+	store *dedupStore
+}
+
+func newDedupHandler(next slog.Handler, window time.Duration) *dedupHandler {
+	h := &dedupHandler{next: next}
+	h.store = newDedupStore(window, h.flushState)
+	return h
+}
+
+func (h *dedupHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return h.next.Enabled(ctx, lvl)
+}
+
+func (h *dedupHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn {
+		// Only error-class storms are worth deduping; lower levels pass through.
+		return h.next.Handle(ctx, r)
+	}
+
+	evicted, suppressed := h.store.observe(r)
+	if evicted != nil {
+		h.flushState(evicted)
+	}
+	if suppressed {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dedupHandler{next: h.next.WithAttrs(attrs), store: h.store}
+}
+
+func (h *dedupHandler) WithGroup(name string) slog.Handler {
+	return &dedupHandler{next: h.next.WithGroup(name), store: h.store}
+}
+
+// flushState emits a summary line for a suppressed repeat run, if there was
+// more than the one pass-through occurrence.
+func (h *dedupHandler) flushState(state *dedupState) {
+	if state.count <= 1 {
+		return
+	}
+	r := state.record.Clone()
+	r.Add(
+		slog.Int("repeated", state.count-1),
+		slog.Time("last_seen", state.lastSeen),
+	)
+	_ = h.next.Handle(context.Background(), r)
+}
+
+// observe records a sighting of r against the store. If an identical key is
+// already tracked, it bumps the count and reports suppressed=true so the
+// caller drops the record. Otherwise it starts tracking the key (evicting
+// the oldest one if the LRU is full) and reports suppressed=false so the
+// caller passes the first occurrence through.
+func (s *dedupStore) observe(r slog.Record) (evicted *dedupState, suppressed bool) {
+	key := dedupKey(r)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.entries[key]; ok {
+		state.count++
+		state.lastSeen = now
+		s.order.MoveToFront(state.elem)
+		return nil, true
+	}
+
+	state := &dedupState{key: key, record: r.Clone(), count: 1, lastSeen: now}
+	state.elem = s.order.PushFront(state)
+	s.entries[key] = state
+	if len(s.entries) > maxDedupEntries {
+		evicted = s.evictOldestLocked()
+	}
+	return evicted, false
+}
+
+// evictOldestLocked removes the least-recently-seen entry; caller holds mu.
+func (s *dedupStore) evictOldestLocked() *dedupState {
+	back := s.order.Back()
+	if back == nil {
+		return nil
+	}
+	state := back.Value.(*dedupState)
+	s.order.Remove(back)
+	delete(s.entries, state.key)
+	return state
+}
+
+// expireLoop periodically flushes entries that have gone window-long
+// without a repeat. It runs for the lifetime of the store, which is
+// effectively the process since Configure is only called at startup/reload.
+func (s *dedupStore) expireLoop() {
+	ticker := time.NewTicker(s.window)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, state := range s.flushExpired() {
+			s.flush(state)
+		}
+	}
+}
+
+func (s *dedupStore) flushExpired() []*dedupState {
+	now := time.Now()
+
+	s.mu.Lock()
+	var expired []*dedupState
+	for e := s.order.Back(); e != nil; {
+		prev := e.Prev()
+		state := e.Value.(*dedupState)
+		if now.Sub(state.lastSeen) < s.window {
+			break // order is MRU-first, so nothing further back is newer
+		}
+		s.order.Remove(e)
+		delete(s.entries, state.key)
+		expired = append(expired, state)
+		e = prev
+	}
+	s.mu.Unlock()
+
+	return expired
+}
+
+// dedupKey identifies a record by level, message and error class (the
+// concrete type of its "error" attribute, if any), which is what repeated
+// "provider X failed: connection refused" storms share even as the
+// underlying error value differs.
+func dedupKey(r slog.Record) string {
+	errClass := ""
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key != "error" {
+			return true
+		}
+		if err, ok := a.Value.Any().(error); ok {
+			errClass = fmt.Sprintf("%T", err)
+		} else {
+			errClass = a.Value.String()
+		}
+		return false
+	})
+	return fmt.Sprintf("%d|%s|%s", r.Level, r.Message, errClass)
+}
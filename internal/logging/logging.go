@@ -0,0 +1,166 @@
+// Package logging is a thin log/slog wrapper that replaces sirupsen/logrus
+// across the adapter. It keeps logrus's Fields/WithFields ergonomics so call
+// sites read the same, while adding JSON/text handler selection, a
+// runtime-adjustable level, and request-scoped attributes threaded through
+// context.Context (see context.go) and a dedup handler for error storms (see
+// dedup.go).
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// Fields is a logrus-style alias for a set of structured log attributes.
+type Fields map[string]interface{}
+
+// Format selects the encoding used by Configure.
+type Format string
+
+// Supported output formats.
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+var (
+	level  = new(slog.LevelVar)
+	logger atomic.Pointer[slog.Logger]
+
+	// mu guards Configure so concurrent reconfiguration (e.g. a hot config
+	// reload alongside startup) can't race on logger.
+	mu sync.Mutex
+)
+
+func init() {
+	level.Set(slog.LevelInfo)
+	logger.Store(slog.New(newBaseHandler(FormatText, os.Stderr)))
+}
+
+func newBaseHandler(format Format, w io.Writer) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// Configure rebuilds the root logger with the given format and writer,
+// wrapping the base handler in a dedupHandler so repeated warn/error lines
+// within DefaultDedupWindow coalesce into one summary.
+func Configure(format Format, w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	logger.Store(slog.New(newDedupHandler(newBaseHandler(format, w), DefaultDedupWindow)))
+}
+
+// SetLevel changes the minimum level the logger accepts at runtime, e.g.
+// from the PUT /admin/loglevel endpoint.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Level returns the current minimum log level.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// ParseLevel maps the logrus-style level names the adapter has always
+// accepted via LOG_LEVEL to a slog.Level.
+func ParseLevel(name string) (slog.Level, error) {
+	switch name {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+func root() *slog.Logger {
+	return logger.Load()
+}
+
+// Package-level helpers mirroring logrus's free-function ergonomics for call
+// sites with no context.Context to attach request-scoped attributes from.
+func Debugf(format string, args ...interface{}) { root().Debug(fmt.Sprintf(format, args...)) }
+func Infof(format string, args ...interface{})  { root().Info(fmt.Sprintf(format, args...)) }
+func Warnf(format string, args ...interface{})  { root().Warn(fmt.Sprintf(format, args...)) }
+func Errorf(format string, args ...interface{}) { root().Error(fmt.Sprintf(format, args...)) }
+
+func Debug(args ...interface{}) { root().Debug(fmt.Sprint(args...)) }
+func Info(args ...interface{})  { root().Info(fmt.Sprint(args...)) }
+func Warn(args ...interface{})  { root().Warn(fmt.Sprint(args...)) }
+func Error(args ...interface{}) { root().Error(fmt.Sprint(args...)) }
+
+// Fatal logs at error level and terminates the process, mirroring logrus.Fatal.
+func Fatal(args ...interface{}) {
+	root().Error(fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+// Fatalf logs at error level and terminates the process, mirroring logrus.Fatalf.
+func Fatalf(format string, args ...interface{}) {
+	root().Error(fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// Entry pins a logger to a set of structured attributes, mirroring
+// logrus.Entry so WithFields/WithField/WithError call sites port over
+// unchanged.
+type Entry struct {
+	attrs []any
+}
+
+// WithFields starts an Entry carrying fields as structured attributes.
+func WithFields(fields Fields) *Entry {
+	return &Entry{attrs: fieldsToAttrs(fields)}
+}
+
+// WithField starts an Entry carrying a single structured attribute.
+func WithField(key string, value interface{}) *Entry {
+	return WithFields(Fields{key: value})
+}
+
+// WithError starts an Entry carrying err under the conventional "error" key.
+func WithError(err error) *Entry {
+	return WithFields(Fields{"error": err})
+}
+
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return &Entry{attrs: append(append([]any{}, e.attrs...), key, value)}
+}
+
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	root().With(e.attrs...).Debug(fmt.Sprintf(format, args...))
+}
+func (e *Entry) Infof(format string, args ...interface{}) {
+	root().With(e.attrs...).Info(fmt.Sprintf(format, args...))
+}
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	root().With(e.attrs...).Warn(fmt.Sprintf(format, args...))
+}
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	root().With(e.attrs...).Error(fmt.Sprintf(format, args...))
+}
+func (e *Entry) Debug(args ...interface{}) { root().With(e.attrs...).Debug(fmt.Sprint(args...)) }
+func (e *Entry) Info(args ...interface{})  { root().With(e.attrs...).Info(fmt.Sprint(args...)) }
+func (e *Entry) Warn(args ...interface{})  { root().With(e.attrs...).Warn(fmt.Sprint(args...)) }
+func (e *Entry) Error(args ...interface{}) { root().With(e.attrs...).Error(fmt.Sprint(args...)) }
+
+func fieldsToAttrs(f Fields) []any {
+	attrs := make([]any, 0, len(f)*2)
+	for k, v := range f {
+		attrs = append(attrs, k, v)
+	}
+	return attrs
+}
@@ -44,6 +44,52 @@ type Metric struct {
 	
 	// Version indicates the data schema version
 	Version string `json:"version,omitempty"`
+
+	// VaultID identifies the specific vault/strategy this metric came
+	// from, for providers (like Karak) that report per-vault rather than
+	// a single protocol-wide figure. Empty for providers/aggregates that
+	// don't distinguish vaults.
+	VaultID string `json:"vault_id,omitempty"`
+
+	// Asset is the underlying asset symbol or address for VaultID, e.g.
+	// "stETH" or a token contract address.
+	Asset string `json:"asset,omitempty"`
+
+	// FromCache is true when this metric was served from httpcache's
+	// persistent store on a 304 Not Modified revalidation, rather than
+	// freshly decoded from the upstream response body.
+	FromCache bool `json:"from_cache,omitempty"`
+
+	// Signature is the Ed25519 signature over this metric's canonical
+	// fields, set by attest.Signer.Sign. Encoded as base64 in JSON since
+	// it's a []byte.
+	Signature []byte `json:"signature,omitempty"`
+
+	// SignerPubKey is the Ed25519 public key that produced Signature.
+	SignerPubKey []byte `json:"signer_pub_key,omitempty"`
+
+	// MerkleProof is the sibling-hash path proving this metric's inclusion
+	// in a batch root computed by attest.BuildBatch: a flat concatenation
+	// of 32-byte nodes from leaf to root.
+	MerkleProof []byte `json:"merkle_proof,omitempty"`
+
+	// Stale is true when this metric is a last-known-good result served by
+	// a provider's circuit breaker while its underlying endpoint is open,
+	// rather than freshly fetched. Consumers that can't tolerate stale data
+	// should check this before trusting the metric.
+	Stale bool `json:"stale,omitempty"`
+
+	// Quantiles holds distributional quantiles computed by
+	// aggregate.QuantileAggregation, keyed by "<field>_p<NN>" (e.g.
+	// "apy_p50", "points_per_eth_p90"). Empty for aggregators that don't
+	// report a distribution, only a point estimate.
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+
+	// PointsPerETHRate is the TVL-weighted mean points_per_eth_per_day rate
+	// computed by aggregate.RateAggregation from a provider's time-ordered
+	// PointsPerETH history, rather than a single snapshot value. Zero for
+	// aggregators that don't compute a rate.
+	PointsPerETHRate float64 `json:"points_per_eth_rate,omitempty"`
 }
 
 // NewMetric creates a new metric with current timestamp
@@ -72,3 +118,29 @@ func (m Metric) WithConfidence(confidence float64) Metric {
 	m.Confidence = confidence
 	return m
 }
+
+// MetricWithCI is an aggregated Metric annotated with the statistics needed
+// to judge how much to trust it: the weighted standard deviation, the
+// standard error of the weighted mean, and the resulting confidence interval
+// bounds for APY and PointsPerETH. Consumers can reject an aggregation whose
+// CI is too wide instead of trusting a mean that a single dominant provider
+// or wildly disagreeing providers could have produced.
+type MetricWithCI struct {
+	Metric
+
+	// Z is the z-score used to derive the confidence interval (1.96 for 95%).
+	Z float64 `json:"z"`
+
+	// EffectiveN is Kish's effective sample size: (Σw)² / Σw².
+	EffectiveN float64 `json:"effective_n"`
+
+	APYStdDev float64 `json:"apy_std_dev"`
+	APYStdErr float64 `json:"apy_std_err"`
+	APYCILow  float64 `json:"apy_ci_low"`
+	APYCIHigh float64 `json:"apy_ci_high"`
+
+	PointsStdDev float64 `json:"points_std_dev"`
+	PointsStdErr float64 `json:"points_std_err"`
+	PointsCILow  float64 `json:"points_ci_low"`
+	PointsCIHigh float64 `json:"points_ci_high"`
+}
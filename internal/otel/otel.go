@@ -1,53 +1,195 @@
+// Package otel wires up the OpenTelemetry tracer provider used across the
+// service: an OTLP exporter (HTTP or gRPC) feeding a batching tracer
+// provider, with a resource describing this instance and helpers for
+// recording errors and instrumenting outbound gRPC clients.
 package otel
 
 import (
 	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
-	"time"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/stats"
+
+	"github.com/yourorg/restake-yield-ea/internal/config"
 )
 
-func InitTracer(cfg config.Config) func() {
+const instrumentationName = "restake-yield-ea"
+
+// InitTracer builds an OTLP exporter and registers a tracer provider as the
+// global default, returning a shutdown func to flush and close it on exit.
+// enterpriseCfg may be nil; when present its chain names are added as a
+// resource attribute so traces can be filtered by which chains an instance
+// was configured for. It is a no-op (returning a no-op shutdown func) when
+// cfg.OtelEndpoint is empty.
+func InitTracer(cfg config.Config, enterpriseCfg *config.EnterpriseConfig) func() {
 	if cfg.OtelEndpoint == "" {
 		return func() {}
 	}
 
 	ctx := context.Background()
-	client := otlptracehttp.NewClient(
-		otlptracehttp.WithEndpoint(cfg.OtelEndpoint),
-		otlptracehttp.WithInsecure(),
-	)
-	
-	exporter, err := otlptrace.New(ctx, client)
+
+	exporter, err := newExporter(ctx, cfg)
 	if err != nil {
 		return func() {}
 	}
 
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName("restake-yield-ea"),
-		)),
+		sdktrace.WithResource(buildResource(ctx, cfg, enterpriseCfg)),
 	)
 	otel.SetTracerProvider(tp)
 
 	return func() {
-		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		_ = tp.Shutdown(ctx)
 	}
 }
 
+// newExporter builds the otlptrace client for cfg.OtelProtocol ("grpc" or
+// the default "http/protobuf"), applying TLS and auth-header settings
+// identically across both transports.
+func newExporter(ctx context.Context, cfg config.Config) (*otlptrace.Exporter, error) {
+	headers := exporterHeaders(cfg)
+
+	if cfg.OtelProtocol == "grpc" {
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.OtelEndpoint),
+			otlptracegrpc.WithHeaders(headers),
+		}
+		if cfg.OtelTLSEnabled {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+		} else {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.OtelEndpoint),
+		otlptracehttp.WithHeaders(headers),
+	}
+	if cfg.OtelTLSEnabled {
+		// otlptracehttp defaults to TLS; nothing further to set here beyond
+		// not calling WithInsecure.
+	} else {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return otlptrace.New(ctx, otlptracehttp.NewClient(opts...))
+}
+
+// exporterHeaders merges cfg.OtelBearerToken (as "Authorization: Bearer
+// ...") with the standard OTEL_EXPORTER_OTLP_HEADERS env var
+// ("k1=v1,k2=v2"), so managed backends that need either form of auth are
+// supported without a bespoke config field per vendor.
+func exporterHeaders(cfg config.Config) map[string]string {
+	headers := map[string]string{}
+
+	if raw := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"); raw != "" {
+		for _, pair := range strings.Split(raw, ",") {
+			k, v, ok := strings.Cut(pair, "=")
+			if !ok {
+				continue
+			}
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+
+	if cfg.OtelBearerToken != "" {
+		headers["Authorization"] = "Bearer " + cfg.OtelBearerToken
+	}
+
+	return headers
+}
+
+// buildResource describes this process: the fixed service name, version
+// and deployment environment from cfg, a stable instance ID, the set of
+// chains it's configured for (when enterpriseCfg is known), and the
+// standard host/OS/process detectors so traces can be correlated with the
+// machine and binary that produced them.
+func buildResource(ctx context.Context, cfg config.Config, enterpriseCfg *config.EnterpriseConfig) *resource.Resource {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(instrumentationName),
+		semconv.ServiceVersion(cfg.OtelServiceVersion),
+		semconv.ServiceInstanceID(instanceID()),
+		semconv.DeploymentEnvironment(cfg.OtelDeploymentEnv),
+	}
+	if chains := chainNames(enterpriseCfg); len(chains) > 0 {
+		attrs = append(attrs, attribute.StringSlice("restake.chains", chains))
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithHost(),
+		resource.WithOS(),
+		resource.WithProcess(),
+		resource.WithAttributes(attrs...),
+		resource.WithSchemaURL(semconv.SchemaURL),
+	)
+	if err != nil {
+		// Detector failures (e.g. sandboxed environments without /proc)
+		// shouldn't prevent tracing from starting; fall back to the
+		// attributes we built by hand.
+		return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+	}
+	return res
+}
+
+func instanceID() string {
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return host
+	}
+	return "unknown"
+}
+
+func chainNames(enterpriseCfg *config.EnterpriseConfig) []string {
+	if enterpriseCfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(enterpriseCfg.ChainConfigs))
+	for name := range enterpriseCfg.ChainConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tracer returns the package-wide tracer, sourced from whatever provider
+// InitTracer registered (or the global no-op provider if it was never
+// called).
 func Tracer() trace.Tracer {
-	return otel.Tracer("restake-yield-ea")
+	return otel.Tracer(instrumentationName)
 }
 
-func RecordError(ctx context.Context, err error) {
+// RecordError records err on the span in ctx and marks the span as having
+// errored, so a failed operation is visible both as an event and in the
+// span's aggregate status (the latter is what most backends use to compute
+// error rate).
+func RecordError(ctx context.Context, err error, opts ...trace.EventOption) {
 	span := trace.SpanFromContext(ctx)
-	span.RecordError(err)
-}
\ No newline at end of file
+	span.RecordError(err, opts...)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// NewGRPCStatsHandler returns a stats.Handler that instruments an outbound
+// gRPC client connection with OpenTelemetry spans, for use via
+// grpc.WithStatsHandler when dialing - e.g. an OCR transmitter's RPC
+// client - so each call gets a client-side span without manual
+// instrumentation at every call site.
+func NewGRPCStatsHandler() stats.Handler {
+	return otelgrpc.NewClientHandler()
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// keyFromRequest derives the per-client identifier used to bucket r under
+// the given mode. Unresolvable identifiers (no API key header on an
+// anonymous request, say) fall back to the client IP so a misconfigured
+// client still gets its own bucket instead of sharing the global one.
+func keyFromRequest(r *http.Request, mode KeyMode) string {
+	switch mode {
+	case KeyModeAPIKey:
+		if key := apiKeyFromRequest(r); key != "" {
+			return "key:" + key
+		}
+	case KeyModeWallet:
+		if wallet := r.Header.Get("X-Wallet-Address"); wallet != "" {
+			return "wallet:" + strings.ToLower(wallet)
+		}
+	}
+	return "ip:" + clientIP(r)
+}
+
+func apiKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// clientIP prefers the last hop of X-Forwarded-For and falls back to
+// RemoteAddr. The last hop is the one appended by this adapter's own
+// reverse proxy (nginx's default `proxy_set_header X-Forwarded-For
+// $proxy_add_x_forwarded_for` appends rather than replaces), so it's the
+// only entry the proxy itself controls. Trusting the first hop instead
+// would let a client bypass rate limiting simply by sending its own
+// `X-Forwarded-For: <anything>`, since that value survives unmodified as
+// element 0.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		parts := strings.Split(fwd, ",")
+		if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+			return ip
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	requests *prometheus.CounterVec
+}
+
+var (
+	metricsOnce  sync.Once
+	sharedMetrics *metrics
+)
+
+// registerMetrics returns the package's shared Prometheus counter,
+// registering it at most once regardless of how many Limiters are created.
+func registerMetrics() *metrics {
+	metricsOnce.Do(func() {
+		sharedMetrics = &metrics{
+			requests: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "restake_ratelimit_requests_total",
+					Help: "Requests seen by the per-identifier rate limiter, by route, key class, and outcome.",
+				},
+				[]string{"route", "key_class", "result"},
+			),
+		}
+		prometheus.MustRegister(sharedMetrics.requests)
+	})
+	return sharedMetrics
+}
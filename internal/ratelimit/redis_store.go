@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+)
+
+// RedisStore is a Store backed by Redis, so rate limits are shared across
+// every instance of the adapter behind a load balancer instead of each one
+// enforcing its own independent in-memory budget.
+//
+// It approximates a token bucket with a fixed-window counter: each key maps
+// to an INCR'd counter that expires after the bucket's window, which is
+// simpler and cheaper than a true sliding window at the cost of allowing up
+// to 2x burst right at a window boundary. That tradeoff is acceptable for
+// an HTTP-facing guard rail, not a precise fairness mechanism.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a RedisStore connected to addr (host:port).
+func NewRedisStore(addr string) *RedisStore {
+	return &RedisStore{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, limit rate.Limit, burst int) (Result, error) {
+	window := time.Duration(float64(burst) / float64(limit) * float64(time.Second))
+	if window <= 0 {
+		window = time.Second
+	}
+
+	redisKey := "ratelimit:" + key
+	count, err := s.client.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis incr: %w", err)
+	}
+	if count == 1 {
+		if err := s.client.Expire(ctx, redisKey, window).Err(); err != nil {
+			return Result{}, fmt.Errorf("redis expire: %w", err)
+		}
+	}
+
+	ttl, err := s.client.TTL(ctx, redisKey).Result()
+	if err != nil || ttl < 0 {
+		ttl = window
+	}
+	resetAt := time.Now().Add(ttl)
+
+	if int(count) > burst {
+		return Result{
+			Allowed:    false,
+			Remaining:  0,
+			ResetAt:    resetAt,
+			RetryAfter: ttl,
+		}, nil
+	}
+
+	return Result{
+		Allowed:   true,
+		Remaining: burst - int(count),
+		ResetAt:   resetAt,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Result is the outcome of a single Allow check.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	ResetAt    time.Time
+	RetryAfter time.Duration
+}
+
+// Store backs a Limiter's token buckets. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Allow consumes one token from the bucket identified by key, creating
+	// it with the given limit/burst on first use.
+	Allow(ctx context.Context, key string, limit rate.Limit, burst int) (Result, error)
+}
+
+// memoryStoreShards bounds the number of sync.Map shards a memoryStore
+// spreads its keys across, so a single hot shard's lock contention doesn't
+// serialize every request regardless of how many distinct keys are active.
+const memoryStoreShards = 32
+
+// memoryStore is a sharded, in-process Store backed by golang.org/x/time/rate
+// limiters, one per key. It's the default Store and fine for a single
+// adapter instance; multi-instance deployments that need a shared view of
+// remaining quota should use RedisStore instead.
+type memoryStore struct {
+	shards [memoryStoreShards]sync.Map // key -> *rate.Limiter
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{}
+}
+
+func (m *memoryStore) shardFor(key string) *sync.Map {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &m.shards[h.Sum32()%memoryStoreShards]
+}
+
+func (m *memoryStore) Allow(_ context.Context, key string, limit rate.Limit, burst int) (Result, error) {
+	shard := m.shardFor(key)
+	existing, _ := shard.LoadOrStore(key, rate.NewLimiter(limit, burst))
+	limiter := existing.(*rate.Limiter)
+
+	now := time.Now()
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return Result{}, nil
+	}
+
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.Cancel()
+		return Result{
+			Allowed:    false,
+			Remaining:  0,
+			ResetAt:    now.Add(delay),
+			RetryAfter: delay,
+		}, nil
+	}
+
+	return Result{
+		Allowed:   true,
+		Remaining: int(limiter.Tokens()),
+		ResetAt:   now,
+	}, nil
+}
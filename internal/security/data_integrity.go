@@ -10,17 +10,41 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // DataIntegrityService provides cryptographic verification for yield metrics
 type DataIntegrityService struct {
+	mu               sync.RWMutex
 	privateKey       *ecdsa.PrivateKey
 	publicKeyEncoded string
 	verificationOpts VerificationOptions
+
+	// keystorePath is set when the service was created via
+	// NewDataIntegrityServiceFromKeystore, enabling RotateKey to persist a
+	// new keyfile in place. It's empty for the in-memory-only constructor.
+	keystorePath string
+
+	// trustedPublicKeys holds public keys retired by RotateKey that are
+	// still accepted by VerifyPayload until they expire, so signatures
+	// issued just before a rotation don't suddenly fail verification.
+	// Populated only by RotateKey; nil for services that never rotate.
+	trustedPublicKeys []trustedPublicKey
+
+	// eip712Domain is set via WithEIP712Domain; SignTypedYieldReport
+	// refuses to sign until it's configured.
+	eip712Domain *EIP712Domain
+}
+
+// trustedPublicKey is a previously active public key kept around for a
+// grace period after RotateKey retires it.
+type trustedPublicKey struct {
+	encoded   string
+	expiresAt time.Time
 }
 
 // VerificationOptions configures the behavior of data integrity checks
@@ -29,6 +53,12 @@ type VerificationOptions struct {
 	VerificationRequired bool          `json:"verification_required"`
 	SignatureValidity    time.Duration `json:"signature_validity"`
 	StrictMode           bool          `json:"strict_mode"`
+
+	// KeyRotationGracePeriod controls how long a key retired by RotateKey
+	// remains acceptable to VerifyPayload. Only meaningful for services
+	// created via NewDataIntegrityServiceFromKeystore; defaults to 7 days
+	// when unset.
+	KeyRotationGracePeriod time.Duration `json:"key_rotation_grace_period"`
 }
 
 // NewDataIntegrityService creates a new service for data integrity
@@ -49,7 +79,7 @@ func NewDataIntegrityService(opts VerificationOptions) (*DataIntegrityService, e
 		verificationOpts: opts,
 	}
 
-	logrus.Infof("Data integrity service initialized with public key: %s", publicKeyEncoded[:16]+"...")
+	logging.Infof("Data integrity service initialized with public key: %s", publicKeyEncoded[:16]+"...")
 	return service, nil
 }
 
@@ -81,14 +111,19 @@ func (s *DataIntegrityService) SignPayload(payload interface{}) (map[string]inte
 	// Calculate hash of payload
 	hash := sha256.Sum256(payloadBytes)
 
+	s.mu.RLock()
+	privateKey := s.privateKey
+	publicKeyEncoded := s.publicKeyEncoded
+	s.mu.RUnlock()
+
 	// Sign the hash
-	r, s, err := ecdsa.Sign(rand.Reader, s.privateKey, hash[:])
+	r, sVal, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign payload: %w", err)
 	}
 
 	// Convert signature to base64
-	signature := append(r.Bytes(), s.Bytes()...)
+	signature := append(r.Bytes(), sVal.Bytes()...)
 	signatureEncoded := base64.StdEncoding.EncodeToString(signature)
 
 	// Create result with signature metadata
@@ -100,7 +135,7 @@ func (s *DataIntegrityService) SignPayload(payload interface{}) (map[string]inte
 	// Add signature metadata
 	resultMap["_signature"] = map[string]interface{}{
 		"signature":  signatureEncoded,
-		"publicKey":  s.publicKeyEncoded,
+		"publicKey":  publicKeyEncoded,
 		"algorithm":  "ECDSA-P256-SHA256",
 		"timestamp":  time.Now().Unix(),
 		"validUntil": time.Now().Add(s.verificationOpts.SignatureValidity).Unix(),
@@ -122,7 +157,7 @@ func (s *DataIntegrityService) VerifyPayload(signedPayload map[string]interface{
 		if s.verificationOpts.StrictMode {
 			return false, fmt.Errorf("signature metadata missing")
 		}
-		logrus.Warn("Signature metadata missing from payload")
+		logging.Warn("Signature metadata missing from payload")
 		return false, nil
 	}
 
@@ -137,6 +172,32 @@ func (s *DataIntegrityService) VerifyPayload(signedPayload map[string]interface{
 		return false, fmt.Errorf("invalid public key format")
 	}
 
+	// Every service, keystore-backed or in-memory, pins trust to its own
+	// key set from construction: the payload's embedded publicKey must
+	// match the service's current key or a retired key still inside its
+	// grace period. Without this, VerifyPayload would accept any payload
+	// signed with a throwaway keypair whose public key the attacker
+	// embeds alongside it.
+	s.mu.RLock()
+	currentKey := s.publicKeyEncoded
+	trusted := make([]trustedPublicKey, len(s.trustedPublicKeys))
+	copy(trusted, s.trustedPublicKeys)
+	s.mu.RUnlock()
+
+	if publicKeyStr != currentKey {
+		accepted := false
+		now := time.Now()
+		for _, tk := range trusted {
+			if tk.encoded == publicKeyStr && now.Before(tk.expiresAt) {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return false, fmt.Errorf("public key is not the current key or a retired key still within its rotation grace period")
+		}
+	}
+
 	// Check timestamp validity
 	timestamp, ok := sigMetadata["timestamp"].(float64)
 	if !ok {
@@ -199,10 +260,10 @@ func (s *DataIntegrityService) VerifyPayload(signedPayload map[string]interface{
 		return false, fmt.Errorf("invalid signature length: %d", len(signatureBytes))
 	}
 	r := new(big.Int).SetBytes(signatureBytes[:32])
-	s := new(big.Int).SetBytes(signatureBytes[32:])
+	sig := new(big.Int).SetBytes(signatureBytes[32:])
 
 	// Verify signature
-	if !ecdsa.Verify(publicKey, hash[:], r, s) {
+	if !ecdsa.Verify(publicKey, hash[:], r, sig) {
 		return false, fmt.Errorf("signature verification failed")
 	}
 
@@ -211,9 +272,30 @@ func (s *DataIntegrityService) VerifyPayload(signedPayload map[string]interface{
 
 // GetPublicKey returns the base64-encoded public key
 func (s *DataIntegrityService) GetPublicKey() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.publicKeyEncoded
 }
 
+// PublishedKeys returns the base64-encoded active public key followed by any
+// retired keys still within their RotateKey grace period, so downstream
+// Chainlink consumers can pin every key currently able to produce or have
+// produced a valid signature.
+func (s *DataIntegrityService) PublishedKeys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, 1+len(s.trustedPublicKeys))
+	keys = append(keys, s.publicKeyEncoded)
+	now := time.Now()
+	for _, tk := range s.trustedPublicKeys {
+		if now.Before(tk.expiresAt) {
+			keys = append(keys, tk.encoded)
+		}
+	}
+	return keys
+}
+
 // OnChainVerificationData generates data that can be verified on-chain by Chainlink contracts
 func (s *DataIntegrityService) OnChainVerificationData(payload interface{}) (map[string]interface{}, error) {
 	payloadBytes, err := json.Marshal(payload)
@@ -221,12 +303,16 @@ func (s *DataIntegrityService) OnChainVerificationData(payload interface{}) (map
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
+	s.mu.RLock()
+	privateKey := s.privateKey
+	s.mu.RUnlock()
+
 	// Calculate Keccak256 hash (Ethereum standard)
 	keccakHash := crypto.Keccak256Hash(payloadBytes)
 	hashHex := keccakHash.Hex()
 
 	// Sign the hash using Ethereum's signature scheme
-	signature, err := crypto.Sign(keccakHash.Bytes(), s.privateKey)
+	signature, err := crypto.Sign(keccakHash.Bytes(), privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign with Ethereum scheme: %w", err)
 	}
@@ -236,7 +322,7 @@ func (s *DataIntegrityService) OnChainVerificationData(payload interface{}) (map
 		"payload":       payload,
 		"keccak256Hash": hashHex,
 		"signature":     fmt.Sprintf("0x%x", signature),
-		"publicKey":     fmt.Sprintf("0x%x", crypto.FromECDSAPub(&s.privateKey.PublicKey)),
+		"publicKey":     fmt.Sprintf("0x%x", crypto.FromECDSAPub(&privateKey.PublicKey)),
 		"timestamp":     time.Now().Unix(),
 	}
 
@@ -0,0 +1,279 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// eciesAlgorithm identifies the envelope format produced by
+// EncryptForRecipient so DecryptFromSender can refuse anything else.
+const eciesAlgorithm = "ECIES-P256-AES128CTR-HMAC256"
+
+// EncryptForRecipient encrypts payload for whoever holds the private key
+// matching recipientPubKeyHex (a hex-encoded, optionally "0x"-prefixed
+// uncompressed P-256 point). It generates an ephemeral P-256 key pair,
+// derives a shared secret via ECDH with the recipient's public key, runs
+// the secret through a SHA-256 KDF2 (ANSI X9.63) to produce an AES-128 key
+// and an HMAC-SHA256 key, then encrypts with AES-128-CTR and authenticates
+// with HMAC-SHA256 over (iv || ciphertext). This is the same construction
+// go-ethereum's crypto/ecies package uses over P-256.
+func (s *DataIntegrityService) EncryptForRecipient(payload interface{}, recipientPubKeyHex string) (map[string]interface{}, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	recipientPub, err := decodeHexPublicKey(recipientPubKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient public key: %w", err)
+	}
+
+	ephemeralPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	encKey, macKey := eciesDeriveKeys(ecdhSharedSecret(ephemeralPriv, recipientPub))
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, fmt.Errorf("failed to generate iv: %w", err)
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	ciphertext := make([]byte, len(payloadBytes))
+	cipher.NewCTR(block, iv).XORKeyStream(ciphertext, payloadBytes)
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(iv)
+	mac.Write(ciphertext)
+
+	ephemeralPubBytes := elliptic.Marshal(elliptic.P256(), ephemeralPriv.PublicKey.X, ephemeralPriv.PublicKey.Y)
+
+	return map[string]interface{}{
+		"ephemeralPubKey": fmt.Sprintf("0x%x", ephemeralPubBytes),
+		"iv":              base64.StdEncoding.EncodeToString(iv),
+		"ciphertext":      base64.StdEncoding.EncodeToString(ciphertext),
+		"mac":             base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+		"algorithm":       eciesAlgorithm,
+	}, nil
+}
+
+// DecryptFromSender reverses EncryptForRecipient using s's own private key
+// as the recipient key. It verifies the HMAC before decrypting, and if
+// envelope carries a "signature" block (as added by SignAndEncrypt) it also
+// verifies that signature against the recovered plaintext, so a payload
+// that decrypts cleanly but was signed by someone else is still rejected.
+func (s *DataIntegrityService) DecryptFromSender(envelope map[string]interface{}) ([]byte, error) {
+	algorithm, _ := envelope["algorithm"].(string)
+	if algorithm != eciesAlgorithm {
+		return nil, fmt.Errorf("unsupported envelope algorithm: %q", algorithm)
+	}
+
+	ephemeralPubHex, ok := envelope["ephemeralPubKey"].(string)
+	if !ok {
+		return nil, fmt.Errorf("envelope missing ephemeralPubKey")
+	}
+	ephemeralPub, err := decodeHexPublicKey(ephemeralPubHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral public key: %w", err)
+	}
+
+	iv, err := decodeEnvelopeField(envelope, "iv")
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := decodeEnvelopeField(envelope, "ciphertext")
+	if err != nil {
+		return nil, err
+	}
+	tag, err := decodeEnvelopeField(envelope, "mac")
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	privateKey := s.privateKey
+	s.mu.RUnlock()
+
+	encKey, macKey := eciesDeriveKeys(ecdhSharedSecret(privateKey, ephemeralPub))
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(iv)
+	mac.Write(ciphertext)
+	if !hmac.Equal(mac.Sum(nil), tag) {
+		return nil, fmt.Errorf("mac verification failed")
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCTR(block, iv).XORKeyStream(plaintext, ciphertext)
+
+	if sigField, ok := envelope["signature"].(map[string]interface{}); ok {
+		if err := verifyPlaintextSignature(plaintext, sigField); err != nil {
+			return nil, fmt.Errorf("plaintext signature verification failed: %w", err)
+		}
+	}
+
+	return plaintext, nil
+}
+
+// SignAndEncrypt signs the SHA-256 hash of payload with s's signing key,
+// encrypts payload for recipientPubKeyHex via EncryptForRecipient, and
+// attaches the signature as a "signature" field alongside the envelope.
+// The signature rides outside the ciphertext as an authenticated-but-not-
+// secret field (it covers the plaintext hash, not the envelope), so
+// DecryptFromSender can confirm who produced the plaintext without anyone
+// needing to see the plaintext first.
+func (s *DataIntegrityService) SignAndEncrypt(payload interface{}, recipientPubKeyHex string) (map[string]interface{}, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	hash := sha256.Sum256(payloadBytes)
+
+	s.mu.RLock()
+	privateKey := s.privateKey
+	publicKeyEncoded := s.publicKeyEncoded
+	s.mu.RUnlock()
+
+	r, sVal, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payload: %w", err)
+	}
+	signatureEncoded := base64.StdEncoding.EncodeToString(encodeSignature(r, sVal))
+
+	envelope, err := s.EncryptForRecipient(payload, recipientPubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope["signature"] = map[string]interface{}{
+		"signature": signatureEncoded,
+		"publicKey": publicKeyEncoded,
+		"algorithm": "ECDSA-P256-SHA256",
+	}
+	return envelope, nil
+}
+
+// encodeSignature serializes r and s as a fixed 64-byte r||s signature, each
+// component zero-padded to 32 bytes via FillBytes (matching
+// ecdhSharedSecret's and eip712.go's uint256Word's padding). big.Int.Bytes()
+// alone drops leading zero bytes, which would make verifyPlaintextSignature's
+// fixed 32/32 split reject a perfectly valid signature whenever r or s is
+// numerically small.
+func encodeSignature(r, s *big.Int) []byte {
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig
+}
+
+func verifyPlaintextSignature(plaintext []byte, sigField map[string]interface{}) error {
+	signatureStr, ok := sigField["signature"].(string)
+	if !ok {
+		return fmt.Errorf("signature missing")
+	}
+	publicKeyStr, ok := sigField["publicKey"].(string)
+	if !ok {
+		return fmt.Errorf("public key missing")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signatureStr)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if len(signatureBytes) != 64 {
+		return fmt.Errorf("invalid signature length: %d", len(signatureBytes))
+	}
+
+	publicKeyBytes, err := base64.StdEncoding.DecodeString(publicKeyStr)
+	if err != nil {
+		return fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), publicKeyBytes)
+	if x == nil {
+		return fmt.Errorf("invalid public key")
+	}
+	publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	hash := sha256.Sum256(plaintext)
+	r := new(big.Int).SetBytes(signatureBytes[:32])
+	sVal := new(big.Int).SetBytes(signatureBytes[32:])
+	if !ecdsa.Verify(publicKey, hash[:], r, sVal) {
+		return fmt.Errorf("signature does not match plaintext")
+	}
+	return nil
+}
+
+func decodeEnvelopeField(envelope map[string]interface{}, key string) ([]byte, error) {
+	str, ok := envelope[key].(string)
+	if !ok {
+		return nil, fmt.Errorf("envelope missing %s", key)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s encoding: %w", key, err)
+	}
+	return decoded, nil
+}
+
+func decodeHexPublicKey(hexKey string) (*ecdsa.PublicKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex public key: %w", err)
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), b)
+	if x == nil {
+		return nil, fmt.Errorf("malformed P-256 public key")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+// ecdhSharedSecret computes the raw ECDH shared secret (the x-coordinate of
+// priv.D * pub) as a fixed 32-byte big-endian value for P-256.
+func ecdhSharedSecret(priv *ecdsa.PrivateKey, pub *ecdsa.PublicKey) []byte {
+	x, _ := priv.Curve.ScalarMult(pub.X, pub.Y, priv.D.Bytes())
+	return x.FillBytes(make([]byte, 32))
+}
+
+// eciesDeriveKeys runs secret through the ANSI X9.63 SHA-256 KDF (KDF2) to
+// produce a 16-byte AES-128 key followed by a 32-byte HMAC-SHA256 key.
+func eciesDeriveKeys(secret []byte) (encKey, macKey []byte) {
+	derived := kdf2SHA256(secret, 16+32)
+	return derived[:16], derived[16:]
+}
+
+func kdf2SHA256(secret []byte, keyLen int) []byte {
+	var out []byte
+	var counter uint32 = 1
+	for len(out) < keyLen {
+		var ctr [4]byte
+		binary.BigEndian.PutUint32(ctr[:], counter)
+		h := sha256.New()
+		h.Write(secret)
+		h.Write(ctr[:])
+		out = append(out, h.Sum(nil)...)
+		counter++
+	}
+	return out[:keyLen]
+}
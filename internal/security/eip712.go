@@ -0,0 +1,175 @@
+package security
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// eip712DomainTypeHash is keccak256 of the EIP-712 domain type string,
+// computed once since it never changes across reports or deployments.
+var eip712DomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+
+// typedYieldReportTypeString is the EIP-712 type string for
+// TypedYieldReport, in the exact field order encodeData hashes them in. All
+// EVM words are 32 bytes regardless of the Solidity-side declared width, so
+// epoch (declared uint64 on the verifier) still occupies a full word here.
+const typedYieldReportTypeString = "TypedYieldReport(string provider,uint256 apy,uint256 tvl,uint256 pointsPerETH,uint256 collectedAt,uint256 confidence,uint256 riskScore,string protocol,string chain,uint256 weight,string version,uint64 epoch,bytes32 feedId,uint256 chainId)"
+
+var typedYieldReportTypeHash = crypto.Keccak256([]byte(typedYieldReportTypeString))
+
+// eip712FixedPointScale is the number of decimals Go's float64 metric fields
+// are scaled by before being encoded as Solidity uint256 words, matching the
+// conventional 18-decimal fixed-point representation used for APY/TVL-style
+// values elsewhere on-chain.
+var eip712FixedPointScale = new(big.Float).SetFloat64(1e18)
+
+// EIP712Domain identifies the verifying contract and chain a
+// TypedYieldReport's signature is scoped to, preventing a signature minted
+// for one deployment from being replayed against another.
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// TypedYieldReport mirrors model.Metric's fields (excluding Error, which has
+// no meaning in a signed on-chain report) plus the fields a Chainlink
+// consumer needs to dedupe and scope a report: Epoch, FeedID, and ChainID.
+// Float fields are encoded as 18-decimal fixed-point uint256 words when
+// hashed, since Solidity has no native floating point type.
+type TypedYieldReport struct {
+	Provider     string
+	APY          float64
+	TVL          float64
+	PointsPerETH float64
+	CollectedAt  int64
+	Confidence   float64
+	RiskScore    float64
+	Protocol     string
+	Chain        string
+	Weight       float64
+	Version      string
+
+	Epoch   uint64
+	FeedID  [32]byte
+	ChainID *big.Int
+}
+
+// WithEIP712Domain sets the EIP-712 domain SignTypedYieldReport signs
+// against and returns s for chaining, e.g.
+// service.WithEIP712Domain("RestakeYieldAggregator", "1", chainID, verifier).
+func (s *DataIntegrityService) WithEIP712Domain(name, version string, chainID *big.Int, verifyingContract common.Address) *DataIntegrityService {
+	s.mu.Lock()
+	s.eip712Domain = &EIP712Domain{
+		Name:              name,
+		Version:           version,
+		ChainID:           chainID,
+		VerifyingContract: verifyingContract,
+	}
+	s.mu.Unlock()
+	return s
+}
+
+// SignTypedYieldReport signs report as EIP-712 typed data against the domain
+// set via WithEIP712Domain, and returns the domain separator, struct hash,
+// final digest, 65-byte r||s||v signature (v in {27,28}), and signer address
+// so a Solidity verifier can reproduce the digest and call ecrecover
+// directly instead of trying to match Go's JSON encoding.
+func (s *DataIntegrityService) SignTypedYieldReport(report TypedYieldReport) (map[string]interface{}, error) {
+	s.mu.RLock()
+	domain := s.eip712Domain
+	privateKey := s.privateKey
+	s.mu.RUnlock()
+
+	if domain == nil {
+		return nil, fmt.Errorf("EIP-712 domain not configured; call WithEIP712Domain first")
+	}
+
+	domainSeparator := hashEIP712Domain(domain)
+	structHash := hashTypedYieldReport(report)
+
+	digest := crypto.Keccak256(
+		[]byte{0x19, 0x01},
+		domainSeparator,
+		structHash,
+	)
+
+	signature, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing EIP-712 digest: %w", err)
+	}
+	// crypto.Sign returns v in {0,1}; Solidity's ecrecover expects {27,28}.
+	signature[64] += 27
+
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	return map[string]interface{}{
+		"domainSeparator": fmt.Sprintf("0x%x", domainSeparator),
+		"structHash":      fmt.Sprintf("0x%x", structHash),
+		"digest":          fmt.Sprintf("0x%x", digest),
+		"signature":       fmt.Sprintf("0x%x", signature),
+		"signer":          signer.Hex(),
+	}, nil
+}
+
+func hashEIP712Domain(domain *EIP712Domain) []byte {
+	return crypto.Keccak256(
+		eip712DomainTypeHash,
+		crypto.Keccak256([]byte(domain.Name)),
+		crypto.Keccak256([]byte(domain.Version)),
+		uint256Word(domain.ChainID),
+		addressWord(domain.VerifyingContract),
+	)
+}
+
+func hashTypedYieldReport(r TypedYieldReport) []byte {
+	return crypto.Keccak256(
+		typedYieldReportTypeHash,
+		crypto.Keccak256([]byte(r.Provider)),
+		uint256Word(fixedPoint(r.APY)),
+		uint256Word(fixedPoint(r.TVL)),
+		uint256Word(fixedPoint(r.PointsPerETH)),
+		uint256Word(big.NewInt(r.CollectedAt)),
+		uint256Word(fixedPoint(r.Confidence)),
+		uint256Word(fixedPoint(r.RiskScore)),
+		crypto.Keccak256([]byte(r.Protocol)),
+		crypto.Keccak256([]byte(r.Chain)),
+		uint256Word(fixedPoint(r.Weight)),
+		crypto.Keccak256([]byte(r.Version)),
+		uint256Word(new(big.Int).SetUint64(r.Epoch)),
+		r.FeedID[:],
+		uint256Word(r.ChainID),
+	)
+}
+
+// fixedPoint scales f by eip712FixedPointScale (1e18) and truncates to the
+// nearest integer, the same 18-decimal convention used for wei-denominated
+// on-chain values.
+func fixedPoint(f float64) *big.Int {
+	scaled := new(big.Float).Mul(new(big.Float).SetFloat64(f), eip712FixedPointScale)
+	i, _ := scaled.Int(nil)
+	return i
+}
+
+// uint256Word left-pads n to a 32-byte big-endian word, the abi.encode
+// representation of a Solidity uint256.
+func uint256Word(n *big.Int) []byte {
+	word := make([]byte, 32)
+	if n == nil {
+		return word
+	}
+	n.FillBytes(word)
+	return word
+}
+
+// addressWord left-pads a 20-byte address to a 32-byte word, the
+// abi.encode representation of a Solidity address.
+func addressWord(addr common.Address) []byte {
+	word := make([]byte, 32)
+	copy(word[12:], addr.Bytes())
+	return word
+}
@@ -0,0 +1,288 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Scrypt parameters for keystore encryption. These match go-ethereum's
+// keystore defaults (the "standard" light-client-unfriendly-but-safe
+// tradeoff), chosen so a keyfile produced here is recognizable to anyone
+// who has operated a geth-style keystore before.
+const (
+	scryptN     = 1 << 18 // 262144
+	scryptR     = 8
+	scryptP     = 1
+	scryptDKLen = 32
+)
+
+// keystoreJSON is the on-disk format of a key file: an scrypt-derived
+// AES-128-CTR encryption of the DER-encoded ECDSA private key, with a
+// Keccak256 MAC over the ciphertext so a wrong passphrase or a corrupted
+// file is detected before it's ever handed to x509.ParseECPrivateKey.
+type keystoreJSON struct {
+	Version int              `json:"version"`
+	Crypto  keystoreCryptoV1 `json:"crypto"`
+}
+
+type keystoreCryptoV1 struct {
+	Cipher       string               `json:"cipher"`
+	CipherText   string               `json:"ciphertext"`
+	CipherParams keystoreCipherParams `json:"cipherparams"`
+	KDF          string               `json:"kdf"`
+	KDFParams    keystoreScryptParams `json:"kdfparams"`
+	MAC          string               `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+type keystoreScryptParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// NewDataIntegrityServiceFromKeystore creates a DataIntegrityService whose
+// signing key survives restarts: on first run it generates a P-256 key and
+// writes it to path as a scrypt/AES-128-CTR encrypted key file (the same
+// shape as go-ethereum's keystore), and on subsequent runs it decrypts that
+// file with passphrase instead of minting a new key. This is what lets an
+// on-chain verifier pin DataIntegrityService's public key across restarts.
+func NewDataIntegrityServiceFromKeystore(path, passphrase string, opts VerificationOptions) (*DataIntegrityService, error) {
+	var privateKey *ecdsa.PrivateKey
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generating keystore key: %w", err)
+		}
+		if err := writeKeystore(path, privateKey, passphrase); err != nil {
+			return nil, fmt.Errorf("writing keystore: %w", err)
+		}
+		logging.Infof("Generated new data integrity keystore at %s", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("stat keystore %s: %w", path, err)
+	} else {
+		privateKey, err = readKeystore(path, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("reading keystore %s: %w", path, err)
+		}
+		logging.Infof("Loaded data integrity keystore from %s", path)
+	}
+
+	publicKeyBytes := elliptic.Marshal(elliptic.P256(), privateKey.PublicKey.X, privateKey.PublicKey.Y)
+
+	service := &DataIntegrityService{
+		privateKey:       privateKey,
+		publicKeyEncoded: encodePublicKey(publicKeyBytes),
+		verificationOpts: opts,
+		keystorePath:     path,
+	}
+
+	logging.Infof("Data integrity service initialized from keystore with public key: %s", service.publicKeyEncoded[:16]+"...")
+	return service, nil
+}
+
+// RotateKey generates a new signing key, re-encrypts and atomically replaces
+// the keystore file at s.keystorePath with newPassphrase, and retires the
+// previous public key into trustedPublicKeys for KeyRotationGracePeriod (7
+// days if unset) so signatures issued just before rotation still verify.
+// RotateKey only works for services created via
+// NewDataIntegrityServiceFromKeystore; calling it on an in-memory-only
+// service returns an error.
+func (s *DataIntegrityService) RotateKey(newPassphrase string) error {
+	if s.keystorePath == "" {
+		return fmt.Errorf("RotateKey requires a service created via NewDataIntegrityServiceFromKeystore")
+	}
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generating rotated key: %w", err)
+	}
+
+	if err := writeKeystore(s.keystorePath, newKey, newPassphrase); err != nil {
+		return fmt.Errorf("writing rotated keystore: %w", err)
+	}
+
+	grace := s.verificationOpts.KeyRotationGracePeriod
+	if grace <= 0 {
+		grace = 7 * 24 * time.Hour
+	}
+
+	newPublicKeyBytes := elliptic.Marshal(elliptic.P256(), newKey.PublicKey.X, newKey.PublicKey.Y)
+	newPublicKeyEncoded := encodePublicKey(newPublicKeyBytes)
+
+	s.mu.Lock()
+	retired := trustedPublicKey{encoded: s.publicKeyEncoded, expiresAt: time.Now().Add(grace)}
+	s.trustedPublicKeys = append(s.trustedPublicKeys, retired)
+	s.privateKey = newKey
+	s.publicKeyEncoded = newPublicKeyEncoded
+	s.mu.Unlock()
+
+	logging.WithFields(logging.Fields{
+		"retired_public_key": retired.encoded[:16] + "...",
+		"new_public_key":     newPublicKeyEncoded[:16] + "...",
+		"grace_period":       grace.String(),
+	}).Info("rotated data integrity signing key")
+
+	return nil
+}
+
+func writeKeystore(path string, privateKey *ecdsa.PrivateKey, passphrase string) error {
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("marshaling private key: %w", err)
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generating salt: %w", err)
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return fmt.Errorf("deriving key: %w", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return fmt.Errorf("generating iv: %w", err)
+	}
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return fmt.Errorf("creating cipher: %w", err)
+	}
+	cipherText := make([]byte, len(keyBytes))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, keyBytes)
+
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	ks := keystoreJSON{
+		Version: 1,
+		Crypto: keystoreCryptoV1{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: keystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: keystoreScryptParams{
+				N: scryptN, R: scryptR, P: scryptP, DKLen: scryptDKLen,
+				Salt: hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(mac),
+		},
+	}
+
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding keystore: %w", err)
+	}
+
+	// Write to a temp file and rename into place so a crash mid-write (or a
+	// concurrent reader) never observes a half-written keystore.
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temp keystore: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing temp keystore: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("closing temp keystore: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("setting keystore permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("renaming temp keystore into place: %w", err)
+	}
+	return nil
+}
+
+func readKeystore(path, passphrase string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading keystore file: %w", err)
+	}
+
+	var ks keystoreJSON
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("parsing keystore file: %w", err)
+	}
+	if ks.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported keystore cipher %q", ks.Crypto.Cipher)
+	}
+	if ks.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported keystore kdf %q", ks.Crypto.KDF)
+	}
+
+	salt, err := hex.DecodeString(ks.Crypto.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("decoding salt: %w", err)
+	}
+	iv, err := hex.DecodeString(ks.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("decoding iv: %w", err)
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("decoding ciphertext: %w", err)
+	}
+	wantMAC, err := hex.DecodeString(ks.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("decoding mac: %w", err)
+	}
+
+	p := ks.Crypto.KDFParams
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, p.DKLen)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %w", err)
+	}
+
+	gotMAC := crypto.Keccak256(derivedKey[16:32], cipherText)
+	if hex.EncodeToString(gotMAC) != hex.EncodeToString(wantMAC) {
+		return nil, fmt.Errorf("incorrect passphrase or corrupted keystore")
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	keyBytes := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(keyBytes, cipherText)
+
+	privateKey, err := x509.ParseECPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing decrypted private key: %w", err)
+	}
+	return privateKey, nil
+}
+
+func encodePublicKey(publicKeyBytes []byte) string {
+	return base64.StdEncoding.EncodeToString(publicKeyBytes)
+}
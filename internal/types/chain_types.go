@@ -17,10 +17,10 @@ const (
 
 // ChainConfig holds configuration for a specific blockchain network
 type ChainConfig struct {
-	Enabled       bool    `json:"enabled"`
-	RPCEndpoint   string  `json:"rpc_endpoint"`
-	APIEndpoint   string  `json:"api_endpoint"`
-	APIKey        string  `json:"api_key,omitempty"`
-	Weight        float64 `json:"weight"`       // Weight for cross-chain aggregation
-	GasMultiplier float64 `json:"gas_multiple"` // For gas cost normalization
+	Enabled       bool    `json:"enabled" description:"Whether this chain is queried for yield data"`
+	RPCEndpoint   string  `json:"rpc_endpoint" description:"JSON-RPC endpoint used for on-chain reads"`
+	APIEndpoint   string  `json:"api_endpoint" description:"Base URL of the chain's yield data API"`
+	APIKey        string  `json:"api_key,omitempty" description:"API key for the chain's data provider, or a secret:// reference"`
+	Weight        float64 `json:"weight" description:"Weight for cross-chain aggregation"`       // Weight for cross-chain aggregation
+	GasMultiplier float64 `json:"gas_multiple" description:"Multiplier applied to estimated gas costs"` // For gas cost normalization
 }
@@ -0,0 +1,189 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// RejectionReason identifies why FilterInvalidWithReport dropped a metric.
+type RejectionReason string
+
+const (
+	ReasonNegativeAPY          RejectionReason = "negative_apy"
+	ReasonExcessiveAPY         RejectionReason = "excessive_apy"
+	ReasonInsufficientTVL      RejectionReason = "insufficient_tvl"
+	ReasonStale                RejectionReason = "stale"
+	ReasonEmptyProvider        RejectionReason = "empty_provider"
+	ReasonNegativePointsPerETH RejectionReason = "negative_points_per_eth"
+	ReasonOutlier              RejectionReason = "outlier"
+)
+
+// FilterResult reports the outcome of FilterInvalidWithReport: how many
+// metrics survived, a breakdown of why the rest didn't, and whether
+// fail-open mode had to re-admit some of them.
+type FilterResult struct {
+	Metrics []model.Metric
+
+	Kept    int
+	Dropped int
+
+	// Reasons counts dropped metrics by RejectionReason. Counts reflect the
+	// strict pass before any fail-open re-admission.
+	Reasons map[RejectionReason]int
+
+	// FailOpen is true when the dropped ratio exceeded
+	// ValidationOptions.MaxUnhealthyRatio and Metrics was widened by
+	// re-admitting outlier-flagged and MaxAgeFallback-fresh-enough metrics.
+	FailOpen bool
+}
+
+// Reporter receives a structured notification whenever FilterInvalidWithReport
+// triggers fail-open mode, so callers can wire it to logging, metrics, or an
+// alerting pipeline without this package hardcoding one destination.
+type Reporter interface {
+	ReportFailOpen(result FilterResult)
+}
+
+// LoggingReporter is the default Reporter, emitting a structured warning via
+// the package logger.
+type LoggingReporter struct{}
+
+// ReportFailOpen logs result at warn level via the package logger.
+func (LoggingReporter) ReportFailOpen(result FilterResult) {
+	logging.WithFields(logging.Fields{
+		"component": "validation",
+		"kept":      result.Kept,
+		"dropped":   result.Dropped,
+		"reasons":   result.Reasons,
+	}).Warnf("validation fail-open triggered: %d of %d metrics were rejected, exceeding MaxUnhealthyRatio", result.Dropped, result.Kept+result.Dropped)
+}
+
+// FilterInvalidWithReport is FilterInvalidWithOptions plus per-reason
+// rejection counts and a fail-open degraded mode: if the fraction of
+// metrics rejected (by hard criteria or outlier detection combined) exceeds
+// opts.MaxUnhealthyRatio, most providers are presumed to be suffering a
+// systemic upstream issue rather than individually anomalous, so the filter
+// re-admits outlier-flagged metrics and relaxes the freshness bound to
+// opts.MaxAgeFallback (falling back to opts.MaxAge if unset) instead of
+// returning a near-empty set to the caller. reporter may be nil to skip
+// notification.
+func FilterInvalidWithReport(metrics []model.Metric, opts ValidationOptions, reporter Reporter) FilterResult {
+	reasons := make(map[RejectionReason]int)
+
+	var valid, rejectedAge, rejectedOther []model.Metric
+	for _, m := range metrics {
+		reason, ok := classifyMetric(m, opts)
+		if ok {
+			valid = append(valid, m)
+			continue
+		}
+		reasons[reason]++
+		if reason == ReasonStale {
+			rejectedAge = append(rejectedAge, m)
+		} else {
+			rejectedOther = append(rejectedOther, m)
+		}
+	}
+
+	kept := valid
+	var flaggedOutliers []model.Metric
+	if opts.EnableOutlierDetection && len(valid) > 3 {
+		if opts.UseRobustEstimator {
+			kept = FilterOutliersRobust(valid, opts.RobustK)
+		} else {
+			kept = filterOutliers(valid, opts.OutlierIQRMultiplier)
+		}
+		if dropped := len(valid) - len(kept); dropped > 0 {
+			reasons[ReasonOutlier] = dropped
+			flaggedOutliers = subsequenceDiff(valid, kept)
+		}
+	}
+
+	total := len(metrics)
+	dropped := total - len(kept)
+
+	result := FilterResult{
+		Metrics: kept,
+		Kept:    len(kept),
+		Dropped: dropped,
+		Reasons: reasons,
+	}
+
+	if opts.MaxUnhealthyRatio <= 0 || total == 0 || float64(dropped)/float64(total) <= opts.MaxUnhealthyRatio {
+		return result
+	}
+
+	failOpen := append([]model.Metric(nil), kept...)
+	failOpen = append(failOpen, flaggedOutliers...)
+
+	fallbackMaxAge := opts.MaxAgeFallback
+	if fallbackMaxAge <= 0 {
+		fallbackMaxAge = opts.MaxAge
+	}
+	for _, m := range rejectedAge {
+		if time.Since(time.Unix(m.CollectedAt, 0)) <= fallbackMaxAge {
+			failOpen = append(failOpen, m)
+		}
+	}
+
+	result.Metrics = failOpen
+	result.Kept = len(failOpen)
+	result.FailOpen = true
+
+	if reporter != nil {
+		reporter.ReportFailOpen(result)
+	}
+
+	return result
+}
+
+// classifyMetric is isValidMetric with the specific RejectionReason attached,
+// checked in the same order so strict-mode behavior is unchanged.
+func classifyMetric(m model.Metric, opts ValidationOptions) (RejectionReason, bool) {
+	if m.APY < 0 {
+		return ReasonNegativeAPY, false
+	}
+	if m.APY > opts.MaxAPY {
+		return ReasonExcessiveAPY, false
+	}
+	if m.TVL <= opts.MinTVL {
+		return ReasonInsufficientTVL, false
+	}
+	if time.Since(time.Unix(m.CollectedAt, 0)) > opts.MaxAge {
+		return ReasonStale, false
+	}
+	if m.Provider == "" {
+		return ReasonEmptyProvider, false
+	}
+	if opts.RequirePositivePointsPerETH && m.PointsPerETH < 0 {
+		return ReasonNegativePointsPerETH, false
+	}
+	return "", true
+}
+
+// subsequenceDiff returns the elements of all that aren't in kept, relying
+// on filterOutliers/FilterOutliersRobust never reordering or duplicating
+// entries - kept is always an in-order subsequence of all - so a two-pointer
+// walk suffices without model.Metric needing to be comparable (it holds
+// []byte/map fields that rule out ==).
+func subsequenceDiff(all, kept []model.Metric) []model.Metric {
+	dropped := make([]model.Metric, 0, len(all)-len(kept))
+	j := 0
+	for i := range all {
+		if j < len(kept) && metricIdentity(all[i]) == metricIdentity(kept[j]) {
+			j++
+			continue
+		}
+		dropped = append(dropped, all[i])
+	}
+	return dropped
+}
+
+// metricIdentity is a best-effort key distinguishing metrics within a single
+// aggregation batch, used only to diff filter input/output slices.
+func metricIdentity(m model.Metric) string {
+	return fmt.Sprintf("%s|%s|%d|%v|%v|%v", m.Provider, m.VaultID, m.CollectedAt, m.APY, m.TVL, m.PointsPerETH)
+}
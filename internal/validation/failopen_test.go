@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterInvalidWithReport_NoFailOpenBelowThreshold(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.06, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: -1, TVL: 1000, CollectedAt: now}, // rejected: negative APY
+	}
+
+	opts := DefaultValidationOptions()
+	opts.MaxUnhealthyRatio = 0.5
+
+	result := FilterInvalidWithReport(metrics, opts, nil)
+
+	assert.False(t, result.FailOpen)
+	require.Len(t, result.Metrics, 2)
+	assert.Equal(t, 2, result.Kept)
+	assert.Equal(t, 1, result.Dropped)
+	assert.Equal(t, 1, result.Reasons[ReasonNegativeAPY])
+}
+
+func TestFilterInvalidWithReport_TriggersFailOpenOnStaleMajority(t *testing.T) {
+	now := time.Now().Unix()
+	staleTs := time.Now().Add(-40 * time.Hour).Unix() // stale under MaxAge, fresh under MaxAgeFallback
+
+	metrics := []model.Metric{
+		{Provider: "fresh1", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "fresh2", APY: 0.051, TVL: 1000, CollectedAt: now},
+		{Provider: "stale1", APY: 0.052, TVL: 1000, CollectedAt: staleTs},
+		{Provider: "stale2", APY: 0.049, TVL: 1000, CollectedAt: staleTs},
+		{Provider: "stale3", APY: 0.053, TVL: 1000, CollectedAt: staleTs},
+	}
+
+	opts := DefaultValidationOptions()
+	opts.MaxUnhealthyRatio = 0.5 // 3/5 = 0.6 stale, exceeds this
+	opts.MaxAgeFallback = 48 * time.Hour
+
+	result := FilterInvalidWithReport(metrics, opts, nil)
+
+	assert.True(t, result.FailOpen)
+	assert.Equal(t, 3, result.Reasons[ReasonStale])
+	require.Len(t, result.Metrics, 5) // all 5 re-admitted under the relaxed fallback bound
+}
+
+func TestFilterInvalidWithReport_FailOpenReAdmitsOutliers(t *testing.T) {
+	now := time.Now().Unix()
+
+	// 5 metrics with one outlier; rejecting it alone isn't enough to trip
+	// fail-open, so force it via a low MaxUnhealthyRatio.
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: 0.052, TVL: 1000, CollectedAt: now},
+		{Provider: "d", APY: 0.049, TVL: 1000, CollectedAt: now},
+		{Provider: "whale", APY: 5.0, TVL: 1000, CollectedAt: now}, // outlier, but valid on hard criteria
+	}
+
+	opts := DefaultValidationOptions()
+	opts.MaxUnhealthyRatio = 0.1 // 1/5 = 0.2 outlier-dropped exceeds this
+
+	result := FilterInvalidWithReport(metrics, opts, nil)
+
+	assert.True(t, result.FailOpen)
+	assert.Equal(t, 1, result.Reasons[ReasonOutlier])
+	require.Len(t, result.Metrics, 5)
+
+	found := false
+	for _, m := range result.Metrics {
+		if m.Provider == "whale" {
+			found = true
+		}
+	}
+	assert.True(t, found, "outlier-flagged metric should be re-admitted in fail-open mode")
+}
+
+func TestFilterInvalidWithReport_CallsReporterOnFailOpen(t *testing.T) {
+	staleTs := time.Now().Add(-40 * time.Hour).Unix()
+
+	metrics := []model.Metric{
+		{Provider: "stale1", APY: 0.05, TVL: 1000, CollectedAt: staleTs},
+		{Provider: "stale2", APY: 0.051, TVL: 1000, CollectedAt: staleTs},
+	}
+
+	opts := DefaultValidationOptions()
+	opts.MaxUnhealthyRatio = 0.1
+	opts.MaxAgeFallback = 48 * time.Hour
+
+	spy := &spyReporter{}
+	result := FilterInvalidWithReport(metrics, opts, spy)
+
+	require.True(t, result.FailOpen)
+	require.Len(t, spy.results, 1)
+	assert.Equal(t, result.Kept, spy.results[0].Kept)
+}
+
+type spyReporter struct {
+	results []FilterResult
+}
+
+func (s *spyReporter) ReportFailOpen(result FilterResult) {
+	s.results = append(s.results, result)
+}
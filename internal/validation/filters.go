@@ -8,7 +8,7 @@ import (
 	"time"
 
 	"github.com/yourorg/restake-yield-ea/internal/model"
-	"github.com/sirupsen/logrus"
+	"github.com/yourorg/restake-yield-ea/internal/logging"
 )
 
 // ValidationOptions holds configuration for the validation process
@@ -30,6 +30,54 @@ type ValidationOptions struct {
 
 	// OutlierIQRMultiplier defines sensitivity for outlier detection (1.5 is standard)
 	OutlierIQRMultiplier float64
+
+	// UseRobustEstimator selects FilterOutliersRobust/CalculateConfidenceScoresRobust
+	// (TVL-weighted median + MAD) instead of the IQR/TVL-weighted-mean
+	// functions above. The robust estimator resists a single
+	// disproportionately large TVL provider skewing the consensus; the
+	// original functions are kept as the default for backward
+	// compatibility with existing deployments' tuning.
+	UseRobustEstimator bool
+
+	// RobustK is the number of scaled MADs a metric's APY may deviate from
+	// the weighted median before FilterOutliersRobust rejects it. 3.0
+	// (the conventional "outlier" threshold for MAD-based detection) is
+	// the default.
+	RobustK float64
+
+	// MaxUnhealthyRatio, used only by FilterInvalidWithReport, switches the
+	// filter to fail-open mode when more than this fraction of metrics
+	// would otherwise be rejected (by hard criteria or outlier detection
+	// combined): most providers looking bad at once points to a systemic
+	// upstream issue rather than a real anomaly, so it's better to degrade
+	// gracefully than return a near-empty set. 0 disables fail-open.
+	MaxUnhealthyRatio float64
+
+	// MaxAgeFallback is the relaxed freshness bound fail-open mode applies
+	// to re-admit age-rejected metrics, instead of the stricter MaxAge. 0
+	// falls back to MaxAge itself (i.e. no relaxation).
+	MaxAgeFallback time.Duration
+
+	// OutlierMethod selects the statistical strategy FilterInvalidWithOptions
+	// and FilterInvalidConcurrently use for outlier detection when set,
+	// taking priority over UseRobustEstimator/OutlierIQRMultiplier below.
+	// Empty preserves that legacy dispatch for backward compatibility with
+	// existing deployments' tuning.
+	OutlierMethod OutlierMethod
+
+	// OutlierParam is OutlierMethod's per-method parameter: the IQR
+	// multiplier for OutlierMethodIQR, the scaled-MAD threshold k for
+	// OutlierMethodMAD/OutlierMethodModifiedZScore, or the trim fraction
+	// for OutlierMethodTrimmedMean. <=0 uses that method's own default.
+	OutlierParam float64
+
+	// ConfidenceTrimFraction is α for CalculateConfidenceScoresWithTrim's
+	// TVL-weighted trimmed mean reference: the fraction of total weight
+	// dropped from each tail before averaging. CalculateConfidenceScores
+	// uses defaultConfidenceTrimFraction directly rather than this field,
+	// since it takes no ValidationOptions; set it when calling
+	// CalculateConfidenceScoresWithTrim(metrics, opts.ConfidenceTrimFraction).
+	ConfidenceTrimFraction float64
 }
 
 // DefaultValidationOptions returns sensible defaults for validation
@@ -41,6 +89,13 @@ func DefaultValidationOptions() ValidationOptions {
 		RequirePositivePointsPerETH: true,
 		EnableOutlierDetection:   true,
 		OutlierIQRMultiplier:     1.5,
+		UseRobustEstimator:       false,
+		RobustK:                  3.0,
+		MaxUnhealthyRatio:        0,
+		MaxAgeFallback:           0,
+		OutlierMethod:            "",
+		OutlierParam:             0,
+		ConfidenceTrimFraction:   defaultConfidenceTrimFraction,
 	}
 }
 
@@ -57,6 +112,12 @@ func FilterInvalidWithOptions(metrics []model.Metric, opts ValidationOptions) []
 
 	// Then apply statistical filters if enabled
 	if opts.EnableOutlierDetection && len(valid) > 3 {
+		if opts.OutlierMethod != "" {
+			return FilterByOutlierMethod(valid, opts.OutlierMethod, opts.OutlierParam)
+		}
+		if opts.UseRobustEstimator {
+			return FilterOutliersRobust(valid, opts.RobustK)
+		}
 		return filterOutliers(valid, opts.OutlierIQRMultiplier)
 	}
 
@@ -109,6 +170,12 @@ func FilterInvalidConcurrently(metrics []model.Metric, opts ValidationOptions) [
 
 	// Apply outlier detection on the combined result
 	if opts.EnableOutlierDetection && len(validMetrics) > 3 {
+		if opts.OutlierMethod != "" {
+			return FilterByOutlierMethod(validMetrics, opts.OutlierMethod, opts.OutlierParam)
+		}
+		if opts.UseRobustEstimator {
+			return FilterOutliersRobust(validMetrics, opts.RobustK)
+		}
 		return filterOutliers(validMetrics, opts.OutlierIQRMultiplier)
 	}
 
@@ -122,7 +189,7 @@ func filterBasicCriteria(metrics []model.Metric, opts ValidationOptions) []model
 		if isValidMetric(m, opts) {
 			valid = append(valid, m)
 		} else {
-			logrus.WithFields(logrus.Fields{
+			logging.WithFields(logging.Fields{
 				"provider": m.Provider,
 				"apy":      m.APY,
 				"tvl":      m.TVL,
@@ -205,7 +272,7 @@ func filterOutliers(metrics []model.Metric, iqrMultiplier float64) []model.Metri
 		if m.APY >= lowerBound && m.APY <= upperBound {
 			valid = append(valid, m)
 		} else {
-			logrus.WithFields(logrus.Fields{
+			logging.WithFields(logging.Fields{
 				"provider": m.Provider,
 				"apy":      m.APY,
 				"bounds":   []float64{lowerBound, upperBound},
@@ -214,7 +281,7 @@ func filterOutliers(metrics []model.Metric, iqrMultiplier float64) []model.Metri
 	}
 
 	// Log summary
-	logrus.WithFields(logrus.Fields{
+	logging.WithFields(logging.Fields{
 		"total":    len(metrics),
 		"filtered": len(metrics) - len(valid),
 		"bounds":   []float64{lowerBound, upperBound},
@@ -236,38 +303,244 @@ func calculateMean(values []float64) float64 {
 	return sum / float64(len(values))
 }
 
+// defaultConfidenceTrimFraction is the α CalculateConfidenceScores uses:
+// the fraction of total TVL weight trimmed from each tail of the APY
+// distribution before averaging to get the reference value.
+const defaultConfidenceTrimFraction = 0.1
+
 // CalculateConfidenceScores assigns a confidence score (0-1) to each metric
 // based on its agreement with other providers
 func CalculateConfidenceScores(metrics []model.Metric) []model.Metric {
+	return CalculateConfidenceScoresWithTrim(metrics, defaultConfidenceTrimFraction)
+}
+
+// CalculateConfidenceScoresWithTrim is CalculateConfidenceScores with a
+// tunable α (see ValidationOptions.ConfidenceTrimFraction): the reference
+// APY is a TVL-weighted trimmed mean that drops the top/bottom trimFraction
+// of total weight before averaging, rather than a plain weighted mean, so a
+// single outsized-TVL provider with an anomalous APY can't single-handedly
+// drag the reference every other provider gets scored against.
+func CalculateConfidenceScoresWithTrim(metrics []model.Metric, trimFraction float64) []model.Metric {
 	if len(metrics) <= 1 {
 		return metrics // Can't calculate confidence with fewer than 2 metrics
 	}
 
-	// Calculate weighted average as our reference point
-	var totalAPY, totalTVL float64
-	for _, m := range metrics {
-		totalAPY += m.APY * m.TVL
-		totalTVL += m.TVL
-	}
-	refAPY := totalAPY / totalTVL
+	refAPY := tvlWeightedTrimmedMean(metrics, trimFraction)
 
 	// Calculate score based on distance from reference
 	result := make([]model.Metric, len(metrics))
 	for i, m := range metrics {
 		copy := m
-		
+
 		// Calculate relative distance from consensus
 		relativeDist := math.Abs(m.APY - refAPY) / refAPY
 		if refAPY == 0 {
 			relativeDist = math.Abs(m.APY)
 		}
-		
+
 		// Convert to confidence score (1 = perfect agreement, 0 = no confidence)
 		confidence := 1.0 / (1.0 + relativeDist*5)
 		copy.Confidence = confidence
-		
+
 		result[i] = copy
 	}
 
 	return result
 }
+
+// tvlWeightedTrimmedMean computes the TVL-weighted mean APY across metrics
+// after trimming trimFraction of total weight from each tail of the sorted
+// APY distribution. A metric with TVL <= 0 is weighted as 1, matching
+// weightedMedian's treatment of missing TVL.
+func tvlWeightedTrimmedMean(metrics []model.Metric, trimFraction float64) float64 {
+	weights := make([]float64, len(metrics))
+	for i, m := range metrics {
+		w := m.TVL
+		if w <= 0 {
+			w = 1
+		}
+		weights[i] = w
+	}
+	return weightedTrimmedMean(extractAPYs(metrics), weights, trimFraction)
+}
+
+// weightedTrimmedMean computes the weighted mean of apys (weights parallel
+// to apys) after trimming trimFraction of total weight from each tail of
+// the sorted distribution, continuously splitting a sample that straddles a
+// trim boundary rather than trimming by whole samples. Used both by
+// tvlWeightedTrimmedMean (weights derived straight from TVL) and
+// CalculateConfidenceScoresWithShaping (weights pre-shaped by ShapeWeights).
+func weightedTrimmedMean(apys, weights []float64, trimFraction float64) float64 {
+	if trimFraction < 0 {
+		trimFraction = 0
+	}
+	if trimFraction >= 0.5 {
+		trimFraction = 0.49
+	}
+
+	type weighted struct {
+		apy    float64
+		weight float64
+	}
+	samples := make([]weighted, len(apys))
+	var totalWeight float64
+	for i, apy := range apys {
+		samples[i] = weighted{apy: apy, weight: weights[i]}
+		totalWeight += weights[i]
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].apy < samples[j].apy })
+
+	trimWeight := totalWeight * trimFraction
+	keepUpper := totalWeight - trimWeight
+
+	var cumulative, weightedSum, usedWeight float64
+	for _, s := range samples {
+		lower := cumulative
+		upper := cumulative + s.weight
+		cumulative = upper
+
+		includedLow := math.Max(lower, trimWeight)
+		includedHigh := math.Min(upper, keepUpper)
+		if includedHigh > includedLow {
+			included := includedHigh - includedLow
+			weightedSum += s.apy * included
+			usedWeight += included
+		}
+	}
+
+	return weightedSum / usedWeight
+}
+
+// madScaleFactor converts a Median Absolute Deviation into a robust
+// estimate of standard deviation under a normal distribution assumption -
+// the standard 1.4826 constant (1/Φ⁻¹(0.75)).
+const madScaleFactor = 1.4826
+
+// medianWeightCap bounds any single provider's share of the total weight
+// fed into weightedMedian, via the same ShapeWeights water-filling
+// chunk9-3 uses for the trimmed-mean reference. Without it, a provider
+// whose raw TVL alone exceeds half the total weight lands the cumulative
+// walk directly on its own sample - the "median" becomes the attacker's
+// value, and FilterOutliersRobust/CalculateConfidenceScoresRobust then
+// measure everyone else's distance from the attacker instead of the other
+// way around.
+const medianWeightCap = 0.3
+
+// weightedMedian returns the weight-capped, TVL-weighted median APY across
+// metrics using Wirth's selection approach: sort by value, then walk the
+// cumulative weight until it crosses half the total weight. Capping each
+// provider's weight at medianWeightCap keeps one outsized-TVL provider from
+// pulling the median onto its own sample; an uncapped weighted median can
+// only ever shift the result to an adjacent sample, which isn't enough once
+// a single weight exceeds half the total on its own.
+func weightedMedian(metrics []model.Metric) float64 {
+	if len(metrics) == 0 {
+		return 0
+	}
+
+	shaped := ShapeWeights(metrics, WeightShapingOptions{MaxProviderWeight: medianWeightCap})
+
+	type weighted struct {
+		apy    float64
+		weight float64
+	}
+	samples := make([]weighted, len(metrics))
+	var totalWeight float64
+	for i, m := range metrics {
+		samples[i] = weighted{apy: m.APY, weight: shaped[i]}
+		totalWeight += shaped[i]
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].apy < samples[j].apy })
+
+	half := totalWeight / 2
+	var cumulative float64
+	for _, s := range samples {
+		cumulative += s.weight
+		if cumulative >= half {
+			return s.apy
+		}
+	}
+	return samples[len(samples)-1].apy
+}
+
+// medianAbsoluteDeviation returns MAD = median(|x_i - center|) across the
+// metrics' APY values, scaled by madScaleFactor so it's comparable to a
+// standard deviation.
+func medianAbsoluteDeviation(metrics []model.Metric, center float64) float64 {
+	deviations := make([]float64, len(metrics))
+	for i, m := range metrics {
+		deviations[i] = math.Abs(m.APY - center)
+	}
+	sort.Float64s(deviations)
+	return deviations[len(deviations)/2] * madScaleFactor
+}
+
+// FilterOutliersRobust removes metrics whose APY deviates from the
+// TVL-weighted median by more than k scaled MADs - a robust alternative to
+// filterOutliers' Tukey-IQR approach that doesn't need the "bounds too
+// tight" mean-based fallback, since MAD itself only collapses to zero when
+// more than half the providers agree exactly (in which case any
+// disagreement is, by definition, the minority).
+func FilterOutliersRobust(metrics []model.Metric, k float64) []model.Metric {
+	if len(metrics) <= 3 {
+		return metrics
+	}
+
+	median := weightedMedian(metrics)
+	mad := medianAbsoluteDeviation(metrics, median)
+
+	valid := make([]model.Metric, 0, len(metrics))
+	for _, m := range metrics {
+		if mad == 0 {
+			// All (or a majority-weighted cluster of) providers agree
+			// exactly; only keep metrics that also agree exactly.
+			if m.APY == median {
+				valid = append(valid, m)
+			}
+			continue
+		}
+		if math.Abs(m.APY-median) <= k*mad {
+			valid = append(valid, m)
+		} else {
+			logging.WithFields(logging.Fields{
+				"provider": m.Provider,
+				"apy":      m.APY,
+				"median":   median,
+				"mad":      mad,
+			}).Info("Filtered outlier metric (robust)")
+		}
+	}
+	return valid
+}
+
+// CalculateConfidenceScoresRobust assigns each metric a confidence score
+// based on its relative distance from the weight-capped TVL-weighted
+// median, using the same hyperbolic 1/(1+5*relativeDist) shape
+// CalculateConfidenceScores uses. An earlier version scored distance in
+// scaled-MAD units instead, but MAD measured against a tightly clustered
+// honest majority is small enough that even a legitimate provider a few
+// basis points off center scored near zero - relative-to-median distance
+// doesn't have that failure mode and still collapses the adversary's
+// score once its weight can no longer drag the median toward it.
+func CalculateConfidenceScoresRobust(metrics []model.Metric) []model.Metric {
+	if len(metrics) <= 1 {
+		return metrics
+	}
+
+	median := weightedMedian(metrics)
+
+	result := make([]model.Metric, len(metrics))
+	for i, m := range metrics {
+		copy := m
+
+		relativeDist := math.Abs(m.APY-median) / median
+		if median == 0 {
+			relativeDist = math.Abs(m.APY)
+		}
+		copy.Confidence = 1.0 / (1.0 + relativeDist*5)
+
+		result[i] = copy
+	}
+	return result
+}
@@ -297,3 +297,78 @@ func TestCalculateConfidenceScores_ZeroValues(t *testing.T) {
 		assert.Equal(t, 1.0, m.Confidence)
 	}
 }
+
+// TestFilterOutliersRobust_ResistsLargeTVLAdversary demonstrates the
+// motivating case for FilterOutliersRobust: a single provider with TVL
+// 100x the rest reports a wildly different APY. A TVL-weighted mean would
+// be dragged almost entirely to that provider's value; the weighted
+// median instead tracks the honest majority, so the adversarial metric is
+// the one correctly identified and removed as the outlier.
+func TestFilterOutliersRobust_ResistsLargeTVLAdversary(t *testing.T) {
+	now := time.Now().Unix()
+
+	metrics := []model.Metric{
+		{Provider: "honest1", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "honest2", APY: 0.052, TVL: 1200, CollectedAt: now},
+		{Provider: "honest3", APY: 0.048, TVL: 900, CollectedAt: now},
+		{Provider: "honest4", APY: 0.051, TVL: 1100, CollectedAt: now},
+		{Provider: "adversary", APY: 0.5, TVL: 100000, CollectedAt: now}, // 100x TVL, wildly off APY
+	}
+
+	filtered := FilterOutliersRobust(metrics, 3.0)
+
+	require.Len(t, filtered, 4)
+	for _, m := range filtered {
+		assert.NotEqual(t, "adversary", m.Provider)
+	}
+}
+
+func TestFilterOutliersRobust_TooFewForDetection(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "provider1", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "provider2", APY: 0.5, TVL: 100000, CollectedAt: now},
+	}
+
+	filtered := FilterOutliersRobust(metrics, 3.0)
+	assert.Len(t, filtered, 2) // not enough data points for outlier detection
+}
+
+func TestCalculateConfidenceScoresRobust_ResistsLargeTVLAdversary(t *testing.T) {
+	now := time.Now().Unix()
+
+	metrics := []model.Metric{
+		{Provider: "honest1", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "honest2", APY: 0.052, TVL: 1200, CollectedAt: now},
+		{Provider: "honest3", APY: 0.048, TVL: 900, CollectedAt: now},
+		{Provider: "honest4", APY: 0.051, TVL: 1100, CollectedAt: now},
+		{Provider: "adversary", APY: 0.5, TVL: 100000, CollectedAt: now},
+	}
+
+	result := CalculateConfidenceScoresRobust(metrics)
+	require.Len(t, result, 5)
+
+	var adversaryConfidence float64
+	for _, m := range result {
+		if m.Provider == "adversary" {
+			adversaryConfidence = m.Confidence
+			continue
+		}
+		assert.Greater(t, m.Confidence, 0.5, "honest provider %s should have high confidence", m.Provider)
+	}
+	assert.Less(t, adversaryConfidence, 0.1, "adversarial 100x-TVL provider should have near-zero confidence")
+}
+
+func TestCalculateConfidenceScoresRobust_AllAgree(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "provider1", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "provider2", APY: 0.05, TVL: 2000, CollectedAt: now},
+	}
+
+	result := CalculateConfidenceScoresRobust(metrics)
+	require.Len(t, result, 2)
+	for _, m := range result {
+		assert.Equal(t, 1.0, m.Confidence)
+	}
+}
@@ -0,0 +1,200 @@
+package validation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/yourorg/restake-yield-ea/internal/logging"
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// OutlierMethod selects the statistical strategy FilterByOutlierMethod uses
+// to flag outlying APY values, and is consumed by FilterInvalidWithOptions/
+// FilterInvalidConcurrently via ValidationOptions.OutlierMethod.
+type OutlierMethod string
+
+const (
+	// OutlierMethodIQR is the Tukey 1.5*IQR rule filterOutliers already
+	// implements; OutlierParam is the IQR multiplier (default 1.5).
+	OutlierMethodIQR OutlierMethod = "iqr"
+
+	// OutlierMethodMAD flags a value whose unweighted median-absolute-
+	// deviation distance exceeds OutlierParam (default 3.5).
+	OutlierMethodMAD OutlierMethod = "mad"
+
+	// OutlierMethodModifiedZScore is the textbook Iglewicz-Hoaglin modified
+	// z-score, M_i = (x_i - median) / (MAD/0.6745); since 1/0.6745 ==
+	// madScaleFactor (1.4826), this is the same computation as
+	// OutlierMethodMAD expressed with the conventional z-score name and
+	// threshold (OutlierParam, default 3.5).
+	OutlierMethodModifiedZScore OutlierMethod = "modified_z_score"
+
+	// OutlierMethodTrimmedMean drops the top/bottom OutlierParam fraction
+	// (default 0.1) of metrics by sorted APY value entirely, rather than
+	// scoring/flagging by distance from a center.
+	OutlierMethodTrimmedMean OutlierMethod = "trimmed_mean"
+)
+
+// minOutlierSampleSize is the smallest input FilterByOutlierMethod applies
+// its method to; smaller sets pass through unchanged, mirroring
+// filterOutliers' len<=3 passthrough.
+const minOutlierSampleSize = 5
+
+const (
+	defaultMADThreshold        = 3.5
+	defaultTrimmedMeanFraction = 0.1
+)
+
+// FilterByOutlierMethod removes metrics flagged as APY outliers under
+// method, using param as that method's per-method parameter (<=0 uses the
+// method's own default). Inputs smaller than minOutlierSampleSize are
+// returned unchanged.
+func FilterByOutlierMethod(metrics []model.Metric, method OutlierMethod, param float64) []model.Metric {
+	if len(metrics) < minOutlierSampleSize {
+		return metrics
+	}
+
+	switch method {
+	case OutlierMethodMAD, OutlierMethodModifiedZScore:
+		return filterOutliersMAD(metrics, param)
+	case OutlierMethodTrimmedMean:
+		return filterOutliersTrimmedMean(metrics, param)
+	case OutlierMethodIQR:
+		if param <= 0 {
+			param = 1.5
+		}
+		return filterOutliers(metrics, param)
+	default:
+		if param <= 0 {
+			param = 1.5
+		}
+		return filterOutliers(metrics, param)
+	}
+}
+
+// filterOutliersMAD flags metrics whose APY deviates from the unweighted
+// median by more than k scaled MADs: median m, MAD = median(|x_i-m|), flag
+// when |x_i-m| / (1.4826*MAD) > k. When MAD collapses to zero, it falls
+// back to the mean absolute deviation as the scale estimate instead (an
+// unscaled fallback, since MAD's 1.4826 normal-equivalence doesn't apply to
+// MAD itself).
+func filterOutliersMAD(metrics []model.Metric, k float64) []model.Metric {
+	if k <= 0 {
+		k = defaultMADThreshold
+	}
+
+	apys := extractAPYs(metrics)
+	med := unweightedMedian(apys)
+
+	scale := medianAbsoluteDeviationRaw(apys, med) * madScaleFactor
+	if scale == 0 {
+		scale = meanAbsoluteDeviation(apys, med)
+	}
+	if scale == 0 {
+		// Every value is identical; nothing to flag.
+		return metrics
+	}
+
+	valid := make([]model.Metric, 0, len(metrics))
+	for _, m := range metrics {
+		if math.Abs(m.APY-med)/scale <= k {
+			valid = append(valid, m)
+		} else {
+			logging.WithFields(logging.Fields{
+				"provider": m.Provider,
+				"apy":      m.APY,
+				"median":   med,
+				"scale":    scale,
+			}).Info("Filtered outlier metric (MAD)")
+		}
+	}
+	return valid
+}
+
+// filterOutliersTrimmedMean drops the top/bottom fraction of metrics by
+// sorted APY value entirely, keeping the interquartile-like middle band.
+// fraction is clamped to [0, 0.49]; <=0 uses defaultTrimmedMeanFraction.
+func filterOutliersTrimmedMean(metrics []model.Metric, fraction float64) []model.Metric {
+	if fraction <= 0 {
+		fraction = defaultTrimmedMeanFraction
+	}
+	if fraction >= 0.5 {
+		fraction = 0.49
+	}
+
+	apys := extractAPYs(metrics)
+	sorted := append([]float64(nil), apys...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	trim := int(float64(n) * fraction)
+	if trim*2 >= n {
+		return metrics
+	}
+
+	lowerBound := sorted[trim]
+	upperBound := sorted[n-1-trim]
+
+	valid := make([]model.Metric, 0, len(metrics))
+	for _, m := range metrics {
+		if m.APY >= lowerBound && m.APY <= upperBound {
+			valid = append(valid, m)
+		} else {
+			logging.WithFields(logging.Fields{
+				"provider": m.Provider,
+				"apy":      m.APY,
+				"bounds":   []float64{lowerBound, upperBound},
+			}).Info("Filtered outlier metric (trimmed mean)")
+		}
+	}
+	return valid
+}
+
+// extractAPYs returns the APY field of each metric, in order.
+func extractAPYs(metrics []model.Metric) []float64 {
+	apys := make([]float64, len(metrics))
+	for i, m := range metrics {
+		apys[i] = m.APY
+	}
+	return apys
+}
+
+// unweightedMedian returns the median of values, which is NOT assumed to be
+// sorted.
+func unweightedMedian(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return sorted[n/2]
+}
+
+// medianAbsoluteDeviationRaw returns the unscaled median(|x_i-center|),
+// unlike medianAbsoluteDeviation which bakes in madScaleFactor.
+func medianAbsoluteDeviationRaw(values []float64, center float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - center)
+	}
+	sort.Float64s(deviations)
+	n := len(deviations)
+	if n%2 == 0 {
+		return (deviations[n/2-1] + deviations[n/2]) / 2
+	}
+	return deviations[n/2]
+}
+
+// meanAbsoluteDeviation returns the arithmetic mean of |x_i-center|, the
+// fallback scale estimate filterOutliersMAD uses when the MAD itself is 0.
+func meanAbsoluteDeviation(values []float64, center float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += math.Abs(v - center)
+	}
+	return sum / float64(len(values))
+}
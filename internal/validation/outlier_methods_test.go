@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterByOutlierMethod_MADRemovesOutlier(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: 0.052, TVL: 1000, CollectedAt: now},
+		{Provider: "d", APY: 0.049, TVL: 1000, CollectedAt: now},
+		{Provider: "outlier", APY: 5.0, TVL: 1000, CollectedAt: now},
+	}
+
+	filtered := FilterByOutlierMethod(metrics, OutlierMethodMAD, 0)
+
+	require.Len(t, filtered, 4)
+	for _, m := range filtered {
+		assert.NotEqual(t, "outlier", m.Provider)
+	}
+}
+
+func TestFilterByOutlierMethod_ModifiedZScoreMatchesMAD(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: 0.052, TVL: 1000, CollectedAt: now},
+		{Provider: "d", APY: 0.049, TVL: 1000, CollectedAt: now},
+		{Provider: "outlier", APY: 5.0, TVL: 1000, CollectedAt: now},
+	}
+
+	mad := FilterByOutlierMethod(metrics, OutlierMethodMAD, 3.5)
+	zscore := FilterByOutlierMethod(metrics, OutlierMethodModifiedZScore, 3.5)
+
+	assert.Equal(t, len(mad), len(zscore))
+}
+
+func TestFilterByOutlierMethod_MADFallsBackToMeanAbsoluteDeviation(t *testing.T) {
+	now := time.Now().Unix()
+	// Six identical values plus one outlier: MAD is 0 (majority agree
+	// exactly), so the filter must fall back to mean absolute deviation
+	// instead of dividing by zero / keeping everything.
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "d", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "e", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "outlier", APY: 5.0, TVL: 1000, CollectedAt: now},
+	}
+
+	filtered := FilterByOutlierMethod(metrics, OutlierMethodMAD, 3.5)
+
+	require.Len(t, filtered, 5)
+	for _, m := range filtered {
+		assert.NotEqual(t, "outlier", m.Provider)
+	}
+}
+
+func TestFilterByOutlierMethod_TrimmedMeanDropsTails(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "low", APY: 0.0, TVL: 1000, CollectedAt: now},
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: 0.052, TVL: 1000, CollectedAt: now},
+		{Provider: "high", APY: 5.0, TVL: 1000, CollectedAt: now},
+	}
+
+	filtered := FilterByOutlierMethod(metrics, OutlierMethodTrimmedMean, 0.2)
+
+	require.Len(t, filtered, 3)
+	for _, m := range filtered {
+		assert.NotEqual(t, "low", m.Provider)
+		assert.NotEqual(t, "high", m.Provider)
+	}
+}
+
+func TestFilterByOutlierMethod_TooFewForDetection(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 5.0, TVL: 1000, CollectedAt: now},
+	}
+
+	filtered := FilterByOutlierMethod(metrics, OutlierMethodMAD, 3.5)
+	assert.Len(t, filtered, 2)
+}
+
+func TestCalculateConfidenceScoresWithTrim_WhaleDoesNotSkewReference(t *testing.T) {
+	now := time.Now().Unix()
+
+	// The adversary carries ~32% of total TVL - large enough to visibly
+	// skew a plain weighted mean, but (unlike a 100x+ dominant whale)
+	// small enough that a 0.3 trim fraction can still exclude most of its
+	// weight from the reference.
+	metrics := []model.Metric{
+		{Provider: "honest1", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "honest2", APY: 0.052, TVL: 1200, CollectedAt: now},
+		{Provider: "honest3", APY: 0.048, TVL: 900, CollectedAt: now},
+		{Provider: "honest4", APY: 0.051, TVL: 1100, CollectedAt: now},
+		{Provider: "adversary", APY: 0.2, TVL: 2000, CollectedAt: now},
+	}
+
+	trimmed := CalculateConfidenceScoresWithTrim(metrics, 0.3)
+	untrimmed := CalculateConfidenceScoresWithTrim(metrics, 0)
+	require.Len(t, trimmed, 5)
+	require.Len(t, untrimmed, 5)
+
+	var trimmedAdversary, untrimmedAdversary float64
+	for _, m := range trimmed {
+		if m.Provider == "adversary" {
+			trimmedAdversary = m.Confidence
+			continue
+		}
+		assert.Greater(t, m.Confidence, 0.4, "honest provider %s should have high confidence against the trimmed reference", m.Provider)
+	}
+	for _, m := range untrimmed {
+		if m.Provider == "adversary" {
+			untrimmedAdversary = m.Confidence
+		}
+	}
+
+	assert.Less(t, trimmedAdversary, 0.15, "adversary should score low confidence once trimming excludes most of its weight from the reference")
+	assert.Less(t, trimmedAdversary, untrimmedAdversary, "trimming should lower the adversary's confidence relative to a plain weighted-mean reference")
+}
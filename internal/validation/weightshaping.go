@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"math"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+)
+
+// WeightShapingOptions bounds how much a single provider's TVL can dominate
+// CalculateConfidenceScoresWithShaping's consensus reference, mirroring
+// power-shaping ideas from validator-set designs: no amount of raw TVL lets
+// one provider outvote the rest.
+type WeightShapingOptions struct {
+	// MaxProviderWeight caps any single provider's share of total weight
+	// (e.g. 0.33 - no provider contributes more than a third regardless of
+	// raw TVL). <=0 disables capping.
+	MaxProviderWeight float64
+
+	// MinProviders is the fewest metrics CalculateConfidenceScoresWithShaping
+	// will score normally; below it, every confidence score is clamped to 0
+	// since a consensus among too few providers isn't trustworthy regardless
+	// of how its weights are shaped. <=0 disables the floor.
+	MinProviders int
+}
+
+// ShapeWeights returns a TVL-derived weight per metric (parallel to metrics,
+// summing to the same total as the unshaped TVLs) with opts.MaxProviderWeight
+// enforced via iterative water-filling: sort by descending weight, and if the
+// largest exceeds the cap, clamp it to the cap and redistribute the surplus
+// proportionally across the remaining providers, repeating until no weight
+// exceeds the cap. A metric with TVL <= 0 is weighted as 1, matching
+// tvlWeightedTrimmedMean. When MaxProviderWeight*len(metrics) < 1 it's
+// infeasible to cap every provider at once, so the last remaining uncapped
+// provider absorbs whatever surplus is left even if that pushes it over the
+// nominal cap.
+func ShapeWeights(metrics []model.Metric, opts WeightShapingOptions) []float64 {
+	weights := make([]float64, len(metrics))
+	var total float64
+	for i, m := range metrics {
+		w := m.TVL
+		if w <= 0 {
+			w = 1
+		}
+		weights[i] = w
+		total += w
+	}
+
+	if opts.MaxProviderWeight <= 0 || total <= 0 {
+		return weights
+	}
+
+	weightCap := total * opts.MaxProviderWeight
+	capped := make([]bool, len(weights))
+
+	for {
+		// Find the largest still-uncapped weight.
+		top := -1
+		for i, w := range weights {
+			if capped[i] {
+				continue
+			}
+			if top == -1 || w > weights[top] {
+				top = i
+			}
+		}
+		if top == -1 {
+			break
+		}
+
+		numUncapped := 0
+		for _, c := range capped {
+			if !c {
+				numUncapped++
+			}
+		}
+		if numUncapped == 1 {
+			// Last uncapped provider: nowhere left to redistribute a surplus
+			// to, so it must absorb whatever remains even if over cap.
+			break
+		}
+
+		if weights[top] <= weightCap {
+			break
+		}
+
+		surplus := weights[top] - weightCap
+		weights[top] = weightCap
+		capped[top] = true
+
+		var uncappedTotal float64
+		for i, w := range weights {
+			if !capped[i] {
+				uncappedTotal += w
+			}
+		}
+		if uncappedTotal <= 0 {
+			break
+		}
+		for i, w := range weights {
+			if !capped[i] {
+				weights[i] = w + surplus*(w/uncappedTotal)
+			}
+		}
+	}
+
+	return weights
+}
+
+// CalculateConfidenceScoresWithShaping is CalculateConfidenceScoresWithTrim
+// with ShapeWeights applied before the trimmed-mean reference is computed, so
+// a dominant-TVL provider is weight-capped rather than merely down-weighted
+// by trimming (trimming alone can't exclude a provider whose own weight
+// exceeds roughly 1-2*trimFraction of the total). When fewer than
+// shaping.MinProviders metrics are present, every score is clamped to 0.
+func CalculateConfidenceScoresWithShaping(metrics []model.Metric, trimFraction float64, shaping WeightShapingOptions) []model.Metric {
+	if len(metrics) <= 1 {
+		return metrics
+	}
+
+	if shaping.MinProviders > 0 && len(metrics) < shaping.MinProviders {
+		result := make([]model.Metric, len(metrics))
+		for i, m := range metrics {
+			copy := m
+			copy.Confidence = 0
+			result[i] = copy
+		}
+		return result
+	}
+
+	weights := ShapeWeights(metrics, shaping)
+	refAPY := weightedTrimmedMean(extractAPYs(metrics), weights, trimFraction)
+
+	result := make([]model.Metric, len(metrics))
+	for i, m := range metrics {
+		copy := m
+
+		relativeDist := math.Abs(m.APY-refAPY) / refAPY
+		if refAPY == 0 {
+			relativeDist = math.Abs(m.APY)
+		}
+
+		confidence := 1.0 / (1.0 + relativeDist*5)
+		copy.Confidence = confidence
+
+		result[i] = copy
+	}
+
+	return result
+}
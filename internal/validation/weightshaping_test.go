@@ -0,0 +1,129 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourorg/restake-yield-ea/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShapeWeights_DominantProviderCappedAtThird(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "dominant", APY: 0.05, TVL: 900, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 50, CollectedAt: now},
+		{Provider: "c", APY: 0.049, TVL: 50, CollectedAt: now},
+	}
+
+	weights := ShapeWeights(metrics, WeightShapingOptions{MaxProviderWeight: 0.33})
+
+	require.Len(t, weights, 3)
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	assert.InDelta(t, 1000, total, 0.01, "total weight should be preserved")
+	assert.InDelta(t, 330, weights[0], 0.5, "dominant provider's weight should land at the 33%% cap")
+	assert.InDelta(t, 330, weights[1], 0.5)
+	assert.InDelta(t, 340, weights[2], 0.5, "the last uncapped provider absorbs the remainder even past the nominal cap")
+}
+
+func TestShapeWeights_RedistributionConvergesWithTwoAlreadyOverCap(t *testing.T) {
+	now := time.Now().Unix()
+	// Two providers already sit above a 33% cap before any shaping.
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 400, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 400, CollectedAt: now},
+		{Provider: "c", APY: 0.049, TVL: 200, CollectedAt: now},
+	}
+
+	weights := ShapeWeights(metrics, WeightShapingOptions{MaxProviderWeight: 0.33})
+
+	require.Len(t, weights, 3)
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	assert.InDelta(t, 1000, total, 0.01)
+	assert.InDelta(t, 330, weights[0], 0.5)
+	assert.InDelta(t, 330, weights[1], 0.5)
+	assert.InDelta(t, 340, weights[2], 0.5)
+}
+
+func TestShapeWeights_NoCapLeavesWeightsUnchanged(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 900, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 100, CollectedAt: now},
+	}
+
+	weights := ShapeWeights(metrics, WeightShapingOptions{})
+
+	require.Len(t, weights, 2)
+	assert.Equal(t, 900.0, weights[0])
+	assert.Equal(t, 100.0, weights[1])
+}
+
+func TestCalculateConfidenceScoresWithShaping_CapsDominantProviderInfluence(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "honest1", APY: 0.05, TVL: 50, CollectedAt: now},
+		{Provider: "honest2", APY: 0.051, TVL: 50, CollectedAt: now},
+		{Provider: "dominant", APY: 0.5, TVL: 900, CollectedAt: now},
+	}
+
+	shaped := CalculateConfidenceScoresWithShaping(metrics, 0.1, WeightShapingOptions{MaxProviderWeight: 0.33})
+
+	require.Len(t, shaped, 3)
+	var honest1, dominant float64
+	for _, m := range shaped {
+		switch m.Provider {
+		case "honest1":
+			honest1 = m.Confidence
+		case "dominant":
+			dominant = m.Confidence
+		}
+	}
+	assert.Greater(t, honest1, dominant, "capping the dominant provider's weight should keep the reference near the honest consensus")
+}
+
+func TestCalculateConfidenceScoresWithShaping_ClampsToZeroBelowMinProviders(t *testing.T) {
+	now := time.Now().Unix()
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 1000, CollectedAt: now},
+	}
+
+	shaped := CalculateConfidenceScoresWithShaping(metrics, 0.1, WeightShapingOptions{MinProviders: 3})
+
+	require.Len(t, shaped, 2)
+	for _, m := range shaped {
+		assert.Equal(t, 0.0, m.Confidence)
+	}
+}
+
+func TestCalculateConfidenceScoresWithShaping_WeightCapStillFlaggedByOutlierFilter(t *testing.T) {
+	now := time.Now().Unix()
+	// A provider can have its confidence-scoring weight capped while still
+	// being flagged as an outlier: weight shaping only changes the
+	// consensus reference used for scoring, not the independent outlier
+	// filter's own decision.
+	metrics := []model.Metric{
+		{Provider: "a", APY: 0.05, TVL: 1000, CollectedAt: now},
+		{Provider: "b", APY: 0.051, TVL: 1000, CollectedAt: now},
+		{Provider: "c", APY: 0.052, TVL: 1000, CollectedAt: now},
+		{Provider: "d", APY: 0.049, TVL: 1000, CollectedAt: now},
+		{Provider: "dominant-anomaly", APY: 5.0, TVL: 9000, CollectedAt: now},
+	}
+
+	filtered := FilterByOutlierMethod(metrics, OutlierMethodMAD, 0)
+	require.Len(t, filtered, 4)
+	for _, m := range filtered {
+		assert.NotEqual(t, "dominant-anomaly", m.Provider, "outlier detection should flag the anomaly regardless of its TVL dominance")
+	}
+
+	weights := ShapeWeights(metrics, WeightShapingOptions{MaxProviderWeight: 0.33})
+	assert.InDelta(t, 13000.0*0.33, weights[4], 0.5, "weight shaping independently caps the same provider's influence on the reference")
+}